@@ -1,7 +1,11 @@
 package main
 
 import (
+	"context"
 	"log"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/frtasoniero/subsmanager/internal/app"
 )
@@ -12,13 +16,18 @@ func main() {
 	if err != nil {
 		log.Fatal("Failed to initialize application:", err)
 	}
-	defer application.Close()
 
 	// Create and configure server
 	server := app.NewServer(application)
 
-	// Start server
-	if err := server.Start(); err != nil {
-		log.Fatal("Failed to start server:", err)
+	// Serve until SIGINT or SIGTERM, then drain requests and close the storage
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	// A second signal kills the process without waiting for the shutdown
+	context.AfterFunc(ctx, stop)
+
+	if err := server.Run(ctx); err != nil {
+		log.Fatal("Server stopped with an error: ", err)
 	}
+	log.Println("👋 Server stopped gracefully")
 }
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/frtasoniero/subsmanager/internal/infrastructure/database/backup"
+	"github.com/spf13/cobra"
+)
+
+func (c *cli) newBackupCmd() *cobra.Command {
+	var out string
+
+	cmd := &cobra.Command{
+		Use:         "backup",
+		Annotations: mongoOnly,
+		Short:       "Dump every collection to a compressed archive",
+		Long:        "Dump every data collection to a gzipped tar archive of extended JSON, together with the schema migration version of the database.",
+		GroupID:     "database",
+		Args:        cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if out == "" {
+				out = defaultBackupPath(c.cfg.Database.Name)
+			}
+			manifest, err := c.backup(out)
+			if err != nil {
+				return err
+			}
+			return c.renderManifest(manifest)
+		},
+	}
+
+	cmd.Flags().StringVar(&out, "out", "", "Archive path (defaults to backup-<db>-<timestamp>.tar.gz)")
+	cmd.MarkFlagFilename("out", "gz")
+	return cmd
+}
+
+func (c *cli) newRestoreCmd() *cobra.Command {
+	var file string
+	var collections []string
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:         "restore",
+		Annotations: mongoOnly,
+		Short:       "Replace collections with the content of a backup archive",
+		Long:        "Migrate the target database and replace the archived collections with their backed up documents. The archive must have been taken at the schema version of this build.",
+		GroupID:     "database",
+		Args:        cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			f, err := os.Open(file)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+
+			manifest, err := backup.ReadManifest(f)
+			if err != nil {
+				return err
+			}
+			if !yes {
+				target := strings.Join(collections, ", ")
+				if target == "" {
+					target = "every archived collection"
+				}
+				question := fmt.Sprintf("Replace %s in database %q with the backup of %q taken %s?",
+					target, c.cfg.Database.Name, manifest.Database, manifest.CreatedAt.Format(time.RFC3339))
+				if !confirm(question, false) {
+					return errors.New("restore aborted")
+				}
+			}
+
+			if _, err := f.Seek(0, 0); err != nil {
+				return err
+			}
+			db, err := c.database()
+			if err != nil {
+				return err
+			}
+			result, err := backup.Restore(context.Background(), db, f, backup.RestoreOptions{Collections: collections})
+			if err != nil {
+				return err
+			}
+
+			rows := make([][]string, 0, len(result.Collections))
+			for _, collection := range result.Collections {
+				rows = append(rows, []string{collection.Name, strconv.FormatInt(collection.Documents, 10)})
+			}
+			c.info("✅ Restored %s", file)
+			return c.render(result, []string{"COLLECTION", "DOCUMENTS"}, rows)
+		},
+	}
+
+	cmd.Flags().StringVar(&file, "file", "", "Archive to restore (required)")
+	cmd.Flags().StringSliceVar(&collections, "collections", nil, "Only restore these collections, comma separated")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Do not ask for confirmation")
+	cmd.MarkFlagRequired("file")
+	cmd.MarkFlagFilename("file", "gz")
+	return cmd
+}
+
+// backup writes an archive of the database to path
+func (c *cli) backup(path string) (*backup.Manifest, error) {
+	db, err := c.database()
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	manifest, err := backup.Write(context.Background(), db, f)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path)
+		return nil, fmt.Errorf("backup failed: %w", err)
+	}
+
+	c.info("💾 Backed up database %q to %s", db.Name(), path)
+	return manifest, nil
+}
+
+func (c *cli) renderManifest(manifest *backup.Manifest) error {
+	rows := make([][]string, 0, len(manifest.Collections))
+	for _, collection := range manifest.Collections {
+		rows = append(rows, []string{collection.Name, strconv.FormatInt(collection.Documents, 10)})
+	}
+	return c.render(manifest, []string{"COLLECTION", "DOCUMENTS"}, rows)
+}
+
+func defaultBackupPath(database string) string {
+	return fmt.Sprintf("backup-%s-%s.tar.gz", database, time.Now().UTC().Format("20060102-150405"))
+}
+
+// interactive returns true when stdin is a terminal, so questions can be asked
+func interactive() bool {
+	info, err := os.Stdin.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// confirm asks a yes/no question on the terminal, returning fallback on an empty answer
+func confirm(question string, fallback bool) bool {
+	hint := "[y/N]"
+	if fallback {
+		hint = "[Y/n]"
+	}
+	fmt.Fprintf(os.Stderr, "%s %s ", question, hint)
+
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return false
+	}
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "":
+		return fallback
+	case "y", "yes":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/frtasoniero/subsmanager/internal/domain/entities"
+	"github.com/frtasoniero/subsmanager/internal/usecases"
+	"github.com/spf13/cobra"
+)
+
+// newCalendarURLCmd prints the token-protected calendar feed URL of a user
+func (c *cli) newCalendarURLCmd() *cobra.Command {
+	var user, baseURL string
+
+	cmd := &cobra.Command{
+		Use:     "calendar-url",
+		Short:   "Print the calendar feed URL of a user",
+		GroupID: "resources",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			userID, err := entities.ParseID(user)
+			if err != nil {
+				return err
+			}
+
+			// Only the token is needed, so no database connection is opened
+			calendarUseCase := usecases.NewCalendarUseCase(nil, c.cfg.CalendarSecret(), c.cfg.Calendar.AlarmDays)
+			if !calendarUseCase.Enabled() {
+				return usecases.ErrCalendarDisabled
+			}
+
+			fmt.Printf("%s/api/v1/users/%s/calendar.ics?token=%s\n",
+				strings.TrimRight(baseURL, "/"), userID.String(), url.QueryEscape(calendarUseCase.Token(userID)))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&user, "user", "", "ID of the user (required)")
+	cmd.Flags().StringVar(&baseURL, "base-url", "http://localhost:"+c.cfg.Server.Port, "Public base URL of the API")
+	cmd.MarkFlagRequired("user")
+	return cmd
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/frtasoniero/subsmanager/internal/config"
+	"github.com/frtasoniero/subsmanager/internal/infrastructure/database"
+	"github.com/frtasoniero/subsmanager/internal/infrastructure/database/seeds"
+	"github.com/spf13/cobra"
+)
+
+func (c *cli) newInitDBCmd() *cobra.Command {
+	var profile string
+
+	cmd := &cobra.Command{
+		Use:     "init-db",
+		Short:   "Migrate the database and populate it with a seed profile",
+		GroupID: "database",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			switch c.cfg.Storage.Driver {
+			case config.StoragePostgres, config.StorageSQLite:
+				return c.initSQLDatabase(profile)
+			case config.StorageMemory:
+				return errMemoryStorage
+			}
+
+			fmt.Println("🚀 Initializing MongoDB database...")
+			if err := database.InitializeDatabase(c.dbConfig(), profile); err != nil {
+				return fmt.Errorf("failed to initialize database: %w", err)
+			}
+			fmt.Println("✅ Database initialized successfully!")
+			return nil
+		},
+	}
+
+	addProfileFlag(cmd, &profile)
+	return cmd
+}
+
+func (c *cli) newCleanDBCmd() *cobra.Command {
+	var backupPath, profile string
+	var noBackup bool
+
+	cmd := &cobra.Command{
+		Use:         "clean-db",
+		Annotations: mongoOnly,
+		Short:       "Clean the database and reset it to a seed profile",
+		Long:        "Clean the database and reset it to a seed profile. When run from a terminal without --backup or --no-backup, it offers to back up the database first.",
+		GroupID:     "database",
+		Args:        cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if backupPath == "" && !noBackup && interactive() && confirm("Back up the database before cleaning it?", true) {
+				backupPath = defaultBackupPath(c.cfg.Database.Name)
+			}
+			if backupPath != "" {
+				if _, err := c.backup(backupPath); err != nil {
+					return err
+				}
+				c.info("↩️  Restore it with: cli restore --file %s", backupPath)
+			}
+
+			fmt.Println("🧹 Cleaning and resetting MongoDB database...")
+			if err := database.CleanDatabase(c.dbConfig(), profile); err != nil {
+				return fmt.Errorf("failed to clean database: %w", err)
+			}
+			fmt.Println("✅ Database cleaned and reset successfully!")
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&backupPath, "backup", "", "Back up the database to this archive before cleaning it")
+	cmd.Flags().BoolVar(&noBackup, "no-backup", false, "Clean without offering a backup")
+	cmd.MarkFlagsMutuallyExclusive("backup", "no-backup")
+	addProfileFlag(cmd, &profile)
+	return cmd
+}
+
+// initSQLDatabase is init-db for PostgreSQL and SQLite: it applies the SQL migrations and
+// seeds the profile through the repositories when the tables are empty
+func (c *cli) initSQLDatabase(profile string) error {
+	db, err := c.sqlDatabase()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("🚀 Initializing %s database...\n", db.Title())
+	migrator, err := c.migrator()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	applied, err := migrator.Up(ctx)
+	if err := c.reportMigrations(applied, "Applied", err); err != nil {
+		return fmt.Errorf("failed to migrate database: %w", err)
+	}
+
+	r, err := c.repositories()
+	if err != nil {
+		return err
+	}
+	for _, count := range []func(context.Context) (int64, error){r.users.Count, r.products.Count, r.subscriptions.Count} {
+		n, err := count(ctx)
+		if err != nil {
+			return err
+		}
+		if n > 0 {
+			fmt.Println("⚠️  Database already contains data. Skipping initialization.")
+			return nil
+		}
+	}
+
+	loaded, err := seeds.LoadProfile(profile)
+	if err != nil {
+		return err
+	}
+	dataset, err := loaded.Build(time.Now())
+	if err != nil {
+		return err
+	}
+	if err := seeds.Create(ctx, r.users, r.products, r.subscriptions, dataset); err != nil {
+		return err
+	}
+	fmt.Printf("✅ Database initialized with the %s profile!\n", loaded.Name)
+	return nil
+}
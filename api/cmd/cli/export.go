@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/frtasoniero/subsmanager/internal/usecases"
+	"github.com/frtasoniero/subsmanager/pkg/export"
+	"github.com/spf13/cobra"
+)
+
+// newExportCmd exports subscriptions or spending summaries to a file or stdout
+func (c *cli) newExportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "export",
+		Short:   "Export data as CSV, TSV, NDJSON or ODS",
+		GroupID: "data",
+	}
+
+	cmd.AddCommand(
+		c.newExportDatasetCmd("subscriptions", "Export every subscription with its product", (*usecases.ExportUseCase).ExportSubscriptions),
+		c.newExportDatasetCmd("spending", "Export the spending summary of every user", (*usecases.ExportUseCase).ExportSpendingSummaries),
+	)
+	return cmd
+}
+
+func (c *cli) newExportDatasetCmd(dataset, short string, write func(*usecases.ExportUseCase, context.Context, io.Writer, string) error) *cobra.Command {
+	var format, out string
+
+	cmd := &cobra.Command{
+		Use:   dataset,
+		Short: short,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !export.IsSupported(format) {
+				return fmt.Errorf("unsupported export format %q", format)
+			}
+
+			r, err := c.repositories()
+			if err != nil {
+				return err
+			}
+			exportUseCase := usecases.NewExportUseCase(r.subscriptions)
+
+			var w io.Writer = os.Stdout
+			if out != "" {
+				f, err := os.Create(out)
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				w = f
+			}
+
+			if err := write(exportUseCase, context.Background(), w, format); err != nil {
+				return err
+			}
+			if out != "" {
+				fmt.Fprintf(os.Stderr, "✅ Exported %s to %s\n", dataset, out)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", export.FormatCSV, "Output format: csv, tsv, ndjson or ods")
+	cmd.Flags().StringVar(&out, "out", "", "Output file (defaults to stdout)")
+	cmd.RegisterFlagCompletionFunc("format", fixedCompletion(export.FormatCSV, export.FormatTSV, export.FormatNDJSON, export.FormatODS))
+	return cmd
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/frtasoniero/subsmanager/internal/domain/entities"
+	"github.com/frtasoniero/subsmanager/internal/usecases"
+	"github.com/spf13/cobra"
+)
+
+// newImportCmd imports a CSV or JSON file of subscriptions for a user
+func (c *cli) newImportCmd() *cobra.Command {
+	var user, file, format string
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:     "import",
+		Short:   "Import subscriptions for a user from a CSV or JSON file",
+		GroupID: "data",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			userID, err := entities.ParseID(user)
+			if err != nil {
+				return err
+			}
+
+			if format == "" {
+				format = strings.TrimPrefix(strings.ToLower(filepath.Ext(file)), ".")
+			}
+
+			f, err := os.Open(file)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+
+			var rows []usecases.ImportRow
+			switch format {
+			case "csv":
+				rows, err = usecases.ParseImportCSV(f)
+			case "json":
+				rows, err = usecases.ParseImportJSON(f)
+			default:
+				return fmt.Errorf("unsupported import format %q, expected csv or json", format)
+			}
+			if err != nil {
+				return err
+			}
+
+			r, err := c.repositories()
+			if err != nil {
+				return err
+			}
+
+			importUseCase := usecases.NewImportUseCase(r.subscriptions, r.products, r.users)
+
+			result, err := importUseCase.ImportSubscriptions(context.Background(), userID, rows, dryRun)
+			if c.output != outputTable {
+				if renderErr := c.render(result, nil, nil); renderErr != nil {
+					return renderErr
+				}
+				return err
+			}
+
+			for _, rowError := range result.Errors {
+				fmt.Printf("❌ Row %d, %s: %s\n", rowError.Row, rowError.Field, rowError.Message)
+			}
+			if err != nil {
+				return err
+			}
+
+			if dryRun {
+				for _, name := range result.CreatedProducts {
+					fmt.Printf("🆕 Product %q would be created\n", name)
+				}
+				fmt.Printf("✅ %d rows are valid, nothing was written (dry run)\n", result.Total)
+				return nil
+			}
+			for _, name := range result.CreatedProducts {
+				fmt.Printf("🆕 Created product %q\n", name)
+			}
+			fmt.Printf("✅ Imported %d subscriptions\n", result.Imported)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&user, "user", "", "ID of the user owning the subscriptions (required)")
+	cmd.Flags().StringVar(&file, "file", "", "Path of the CSV or JSON file to import (required)")
+	cmd.Flags().StringVar(&format, "format", "", "File format: csv or json (defaults to the file extension)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Only validate the file, without writing anything")
+	cmd.MarkFlagRequired("user")
+	cmd.MarkFlagRequired("file")
+	cmd.MarkFlagFilename("file", "csv", "json")
+	cmd.RegisterFlagCompletionFunc("format", fixedCompletion("csv", "json"))
+	return cmd
+}
@@ -0,0 +1,265 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/frtasoniero/subsmanager/internal/config"
+	"github.com/frtasoniero/subsmanager/internal/infrastructure/database/migrate"
+	"github.com/frtasoniero/subsmanager/internal/infrastructure/database/sqlstore"
+	"github.com/spf13/cobra"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func (c *cli) newMigrateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "migrate",
+		Short:   "Apply or revert schema migrations",
+		Long:    "Apply or revert versioned schema migrations of the --storage backend. Applied versions are recorded in the schema_migrations collection or table, and a lock prevents two processes from migrating at once.",
+		GroupID: "database",
+	}
+
+	cmd.AddCommand(
+		c.newMigrateUpCmd(),
+		c.newMigrateDownCmd(),
+		c.newMigrateStatusCmd(),
+		c.newMigrateToCmd(),
+		c.newMigrateCheckCmd(),
+	)
+	return cmd
+}
+
+// migration identifies a migration a command applied or reverted
+type migration struct {
+	Version int64
+	Name    string
+}
+
+// schemaMigrator runs the migrations of one storage backend: Go migrations
+// for MongoDB, SQL files for PostgreSQL and SQLite
+type schemaMigrator interface {
+	Up(ctx context.Context) ([]migration, error)
+	Down(ctx context.Context, steps int) ([]migration, error)
+	To(ctx context.Context, version int64) ([]migration, error)
+	Status(ctx context.Context) ([]migrate.Status, error)
+	Check(ctx context.Context) error
+}
+
+func (c *cli) migrator() (schemaMigrator, error) {
+	if c.cfg.Storage.Driver == config.StorageMemory {
+		return nil, errMemoryStorage
+	}
+	if c.cfg.Storage.Driver == config.StoragePostgres || c.cfg.Storage.Driver == config.StorageSQLite {
+		db, err := c.sqlDatabase()
+		if err != nil {
+			return nil, err
+		}
+		migrator, err := sqlstore.NewMigrator(db)
+		if err != nil {
+			return nil, err
+		}
+		return sqlMigrator{migrator}, nil
+	}
+
+	db, err := c.database()
+	if err != nil {
+		return nil, err
+	}
+	return mongoMigrator{migrate.NewMigrator(db), db}, nil
+}
+
+// mongoMigrator adapts migrate.Migrator to schemaMigrator
+type mongoMigrator struct {
+	*migrate.Migrator
+	db *mongo.Database
+}
+
+func mongoMigrations(done []*migrate.Migration, err error) ([]migration, error) {
+	migrations := make([]migration, 0, len(done))
+	for _, m := range done {
+		migrations = append(migrations, migration{Version: m.Version, Name: m.Name})
+	}
+	return migrations, err
+}
+
+func (m mongoMigrator) Up(ctx context.Context) ([]migration, error) {
+	return mongoMigrations(m.Migrator.Up(ctx))
+}
+
+func (m mongoMigrator) Down(ctx context.Context, steps int) ([]migration, error) {
+	return mongoMigrations(m.Migrator.Down(ctx, steps))
+}
+
+func (m mongoMigrator) To(ctx context.Context, version int64) ([]migration, error) {
+	return mongoMigrations(m.Migrator.To(ctx, version))
+}
+
+// Check also verifies the declared indexes and validators
+func (m mongoMigrator) Check(ctx context.Context) error {
+	return migrate.CheckSchema(ctx, m.db)
+}
+
+// sqlMigrator adapts sqlstore.Migrator to schemaMigrator
+type sqlMigrator struct {
+	*sqlstore.Migrator
+}
+
+func sqlMigrations(done []*sqlstore.Migration, err error) ([]migration, error) {
+	migrations := make([]migration, 0, len(done))
+	for _, m := range done {
+		migrations = append(migrations, migration{Version: m.Version, Name: m.Name})
+	}
+	return migrations, err
+}
+
+func (m sqlMigrator) Up(ctx context.Context) ([]migration, error) {
+	return sqlMigrations(m.Migrator.Up(ctx))
+}
+
+func (m sqlMigrator) Down(ctx context.Context, steps int) ([]migration, error) {
+	return sqlMigrations(m.Migrator.Down(ctx, steps))
+}
+
+func (m sqlMigrator) To(ctx context.Context, version int64) ([]migration, error) {
+	return sqlMigrations(m.Migrator.To(ctx, version))
+}
+
+func (m sqlMigrator) Status(ctx context.Context) ([]migrate.Status, error) {
+	statuses, err := m.Migrator.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+	converted := make([]migrate.Status, 0, len(statuses))
+	for _, status := range statuses {
+		converted = append(converted, migrate.Status(status))
+	}
+	return converted, nil
+}
+
+// reportMigrations prints the migrations a command applied or reverted
+func (c *cli) reportMigrations(done []migration, verb string, err error) error {
+	for _, migration := range done {
+		c.info("✅ %s %d_%s", verb, migration.Version, migration.Name)
+	}
+	if err != nil {
+		return err
+	}
+	if len(done) == 0 {
+		c.info("Nothing to migrate, the database is up to date")
+	}
+	return nil
+}
+
+func (c *cli) newMigrateUpCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "up",
+		Short: "Apply every pending migration",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			migrator, err := c.migrator()
+			if err != nil {
+				return err
+			}
+			done, err := migrator.Up(context.Background())
+			return c.reportMigrations(done, "Applied", err)
+		},
+	}
+}
+
+func (c *cli) newMigrateDownCmd() *cobra.Command {
+	var steps int
+
+	cmd := &cobra.Command{
+		Use:   "down",
+		Short: "Revert the most recent migrations",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			migrator, err := c.migrator()
+			if err != nil {
+				return err
+			}
+			done, err := migrator.Down(context.Background(), steps)
+			return c.reportMigrations(done, "Reverted", err)
+		},
+	}
+
+	cmd.Flags().IntVar(&steps, "steps", 1, "Number of migrations to revert")
+	return cmd
+}
+
+func (c *cli) newMigrateToCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "to <version>",
+		Short: "Migrate up or down to the given version",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			version, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid version %q", args[0])
+			}
+			migrator, err := c.migrator()
+			if err != nil {
+				return err
+			}
+			done, err := migrator.To(context.Background(), version)
+			return c.reportMigrations(done, "Migrated", err)
+		},
+	}
+}
+
+func (c *cli) newMigrateStatusCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "List migrations and whether they are applied",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			migrator, err := c.migrator()
+			if err != nil {
+				return err
+			}
+			statuses, err := migrator.Status(context.Background())
+			if err != nil {
+				return err
+			}
+
+			rows := make([][]string, 0, len(statuses))
+			for _, status := range statuses {
+				state := "pending"
+				switch {
+				case status.Missing:
+					state = "applied, file missing"
+				case status.Modified:
+					state = "applied, file modified"
+				case status.Applied:
+					state = "applied"
+				}
+				appliedAt := ""
+				if status.AppliedAt != nil {
+					appliedAt = status.AppliedAt.Format("2006-01-02 15:04:05")
+				}
+				rows = append(rows, []string{strconv.FormatInt(status.Version, 10), status.Name, state, appliedAt})
+			}
+			return c.render(statuses, []string{"VERSION", "NAME", "STATE", "APPLIED AT"}, rows)
+		},
+	}
+}
+
+func (c *cli) newMigrateCheckCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "check",
+		Short: "Verify that migrations, indexes and validators are in place, as done at API startup",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			migrator, err := c.migrator()
+			if err != nil {
+				return err
+			}
+			if err := migrator.Check(context.Background()); err != nil {
+				return err
+			}
+			c.info("✅ Database schema is up to date")
+			return nil
+		},
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/frtasoniero/subsmanager/internal/infrastructure/web"
+	"github.com/frtasoniero/subsmanager/internal/infrastructure/web/openapi"
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/cobra"
+)
+
+// openAPIProblem is one row of the openapi check output
+type openAPIProblem struct {
+	Method  string `json:"method"`
+	Path    string `json:"path"`
+	Problem string `json:"problem"`
+}
+
+func (c *cli) newOpenAPICmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "openapi",
+		Short:   "Work with the OpenAPI document of the REST API",
+		GroupID: "api",
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "check",
+		Short: "Check that the OpenAPI document covers every route",
+		Long: "Register the routes of the REST API and compare them with the operations of the embedded OpenAPI document. " +
+			"Fails when a route is missing from the document or a documented operation has no route. No database is needed.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			gin.SetMode(gin.ReleaseMode)
+			router := gin.New()
+			// Handlers are only referenced, never called, so none is built
+			web.SetupRoutes(router, &web.AppHandlers{})
+
+			mismatch, err := openapi.Check(router.Routes())
+			if err != nil {
+				return err
+			}
+			problems := []openAPIProblem{}
+			for _, operation := range mismatch.Undocumented {
+				problems = append(problems, openAPIProblem{Method: operation.Method, Path: operation.Path, Problem: "route missing from the document"})
+			}
+			for _, operation := range mismatch.Unrouted {
+				problems = append(problems, openAPIProblem{Method: operation.Method, Path: operation.Path, Problem: "documented operation has no route"})
+			}
+
+			if mismatch.OK() {
+				fmt.Printf("✅ All %d routes are documented\n", len(router.Routes()))
+				return nil
+			}
+			rows := make([][]string, 0, len(problems))
+			for _, problem := range problems {
+				rows = append(rows, []string{problem.Method, problem.Path, problem.Problem})
+			}
+			if err := c.render(problems, []string{"METHOD", "PATH", "PROBLEM"}, rows); err != nil {
+				return err
+			}
+			return fmt.Errorf("%d routes and operations do not match", len(problems))
+		},
+	})
+	return cmd
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// render prints value as JSON or YAML, or the given header and rows as a table
+func (c *cli) render(value any, header []string, rows [][]string) error {
+	switch c.output {
+	case outputJSON:
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(value)
+
+	case outputYAML:
+		// Round-trip through JSON so YAML keys match the API field names
+		data, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		var generic any
+		if err := json.Unmarshal(data, &generic); err != nil {
+			return err
+		}
+		encoder := yaml.NewEncoder(os.Stdout)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(generic); err != nil {
+			return err
+		}
+		return encoder.Close()
+
+	default:
+		writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		for _, row := range append([][]string{header}, rows...) {
+			for i, cell := range row {
+				if i > 0 {
+					fmt.Fprint(writer, "\t")
+				}
+				fmt.Fprint(writer, cell)
+			}
+			fmt.Fprintln(writer)
+		}
+		return writer.Flush()
+	}
+}
+
+// info prints a progress or confirmation message. Messages are suppressed for
+// machine-readable output so stdout stays parseable.
+func (c *cli) info(format string, args ...any) {
+	if c.output == outputTable {
+		fmt.Printf(format+"\n", args...)
+	}
+}
+
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
+
+func formatOptionalDate(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return formatDate(*t)
+}
+
+func formatPrice(price float64) string {
+	return strconv.FormatFloat(price, 'f', 2, 64)
+}
@@ -0,0 +1,256 @@
+package main
+
+import (
+	"context"
+	"errors"
+
+	"github.com/frtasoniero/subsmanager/internal/domain/entities"
+	"github.com/spf13/cobra"
+)
+
+var billingTypes = []string{"weekly", "monthly", "yearly"}
+
+func (c *cli) newProductsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "products",
+		Aliases: []string{"product"},
+		Short:   "Manage products",
+		GroupID: "resources",
+	}
+
+	cmd.AddCommand(
+		c.newProductsListCmd(),
+		c.newProductsGetCmd(),
+		c.newProductsCreateCmd(),
+		c.newProductsUpdateCmd(),
+		c.newProductsDeactivateCmd(),
+		c.newProductsDeleteCmd(),
+	)
+	return cmd
+}
+
+func (c *cli) renderProducts(products ...*entities.Product) error {
+	rows := make([][]string, 0, len(products))
+	for _, product := range products {
+		rows = append(rows, []string{
+			product.ID.String(), product.Name, product.Category, formatPrice(product.Price), product.BillingType, product.Status,
+		})
+	}
+
+	var value any = products
+	if len(products) == 1 {
+		value = products[0]
+	}
+	return c.render(value, []string{"ID", "NAME", "CATEGORY", "PRICE", "BILLING", "STATUS"}, rows)
+}
+
+func (c *cli) newProductsListCmd() *cobra.Command {
+	var category string
+	var active bool
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List products",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			productUseCase, err := c.productUseCase()
+			if err != nil {
+				return err
+			}
+
+			ctx := context.Background()
+			var products []*entities.Product
+			switch {
+			case category != "":
+				products, err = productUseCase.GetProductsByCategory(ctx, category)
+			case active:
+				products, err = productUseCase.GetActiveProducts(ctx)
+			default:
+				products, err = productUseCase.GetAllProducts(ctx)
+			}
+			if err != nil {
+				return err
+			}
+
+			if category != "" && active {
+				filtered := products[:0]
+				for _, product := range products {
+					if product.IsActive() {
+						filtered = append(filtered, product)
+					}
+				}
+				products = filtered
+			}
+			return c.renderProducts(products...)
+		},
+	}
+
+	cmd.Flags().StringVar(&category, "category", "", "Only list products of this category")
+	cmd.Flags().BoolVar(&active, "active", false, "Only list active products")
+	return cmd
+}
+
+func (c *cli) newProductsGetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "get <id>",
+		Short: "Show a product",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := entities.ParseID(args[0])
+			if err != nil {
+				return err
+			}
+			productUseCase, err := c.productUseCase()
+			if err != nil {
+				return err
+			}
+			product, err := productUseCase.GetProductByID(context.Background(), id)
+			if err != nil {
+				return err
+			}
+			return c.renderProducts(product)
+		},
+	}
+}
+
+func (c *cli) newProductsCreateCmd() *cobra.Command {
+	product := &entities.Product{}
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a product",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			productUseCase, err := c.productUseCase()
+			if err != nil {
+				return err
+			}
+			if err := productUseCase.CreateProduct(context.Background(), product); err != nil {
+				return err
+			}
+			c.info("✅ Product created")
+			return c.renderProducts(product)
+		},
+	}
+
+	cmd.Flags().StringVar(&product.Name, "name", "", "Product name (required)")
+	cmd.Flags().StringVar(&product.Description, "description", "", "Product description")
+	cmd.Flags().Float64Var(&product.Price, "price", 0, "Price per billing cycle (required)")
+	cmd.Flags().StringVar(&product.BillingType, "billing-type", "monthly", "Billing type: weekly, monthly or yearly")
+	cmd.Flags().StringVar(&product.Category, "category", "other", "Product category")
+	cmd.MarkFlagRequired("name")
+	cmd.MarkFlagRequired("price")
+	cmd.RegisterFlagCompletionFunc("billing-type", fixedCompletion(billingTypes...))
+	return cmd
+}
+
+func (c *cli) newProductsUpdateCmd() *cobra.Command {
+	var values entities.Product
+
+	cmd := &cobra.Command{
+		Use:   "update <id>",
+		Short: "Update the given fields of a product",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := entities.ParseID(args[0])
+			if err != nil {
+				return err
+			}
+			productUseCase, err := c.productUseCase()
+			if err != nil {
+				return err
+			}
+
+			ctx := context.Background()
+			product, err := productUseCase.GetProductByID(ctx, id)
+			if err != nil {
+				return err
+			}
+
+			flags := cmd.Flags()
+			if !anyChanged(cmd, "name", "description", "price", "billing-type", "category", "status") {
+				return errors.New("nothing to update, set at least one field flag")
+			}
+			if flags.Changed("name") {
+				product.Name = values.Name
+			}
+			if flags.Changed("description") {
+				product.Description = values.Description
+			}
+			if flags.Changed("price") {
+				product.Price = values.Price
+			}
+			if flags.Changed("billing-type") {
+				product.BillingType = values.BillingType
+			}
+			if flags.Changed("category") {
+				product.Category = values.Category
+			}
+			if flags.Changed("status") {
+				product.Status = values.Status
+			}
+
+			if err := productUseCase.UpdateProduct(ctx, product); err != nil {
+				return err
+			}
+			c.info("✅ Product updated")
+			return c.renderProducts(product)
+		},
+	}
+
+	cmd.Flags().StringVar(&values.Name, "name", "", "New name")
+	cmd.Flags().StringVar(&values.Description, "description", "", "New description")
+	cmd.Flags().Float64Var(&values.Price, "price", 0, "New price")
+	cmd.Flags().StringVar(&values.BillingType, "billing-type", "", "New billing type: weekly, monthly or yearly")
+	cmd.Flags().StringVar(&values.Category, "category", "", "New category")
+	cmd.Flags().StringVar(&values.Status, "status", "", "New status: active or inactive")
+	cmd.RegisterFlagCompletionFunc("billing-type", fixedCompletion(billingTypes...))
+	cmd.RegisterFlagCompletionFunc("status", fixedCompletion("active", "inactive"))
+	return cmd
+}
+
+func (c *cli) newProductsDeactivateCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "deactivate <id>",
+		Short: "Deactivate a product",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := entities.ParseID(args[0])
+			if err != nil {
+				return err
+			}
+			productUseCase, err := c.productUseCase()
+			if err != nil {
+				return err
+			}
+			if err := productUseCase.DeactivateProduct(context.Background(), id); err != nil {
+				return err
+			}
+			c.info("✅ Product %s deactivated", id.String())
+			return nil
+		},
+	}
+}
+
+func (c *cli) newProductsDeleteCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete <id>",
+		Short: "Delete a product",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := entities.ParseID(args[0])
+			if err != nil {
+				return err
+			}
+			productUseCase, err := c.productUseCase()
+			if err != nil {
+				return err
+			}
+			if err := productUseCase.DeleteProduct(context.Background(), id); err != nil {
+				return err
+			}
+			c.info("🗑️  Product %s deleted", id.String())
+			return nil
+		},
+	}
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/frtasoniero/subsmanager/internal/domain/entities"
+	"github.com/frtasoniero/subsmanager/internal/usecases"
+	"github.com/spf13/cobra"
+)
+
+func (c *cli) newReportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "report",
+		Short:   "Print analytics reports",
+		GroupID: "data",
+	}
+	cmd.AddCommand(c.newCohortReportCmd())
+	return cmd
+}
+
+// newCohortReportCmd prints the cohort retention report as a table or CSV
+func (c *cli) newCohortReportCmd() *cobra.Command {
+	var format, productID, category string
+	var months int
+
+	cmd := &cobra.Command{
+		Use:   "cohorts",
+		Short: "Print the monthly signup cohort retention report",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if format != "table" && format != "csv" {
+				return fmt.Errorf("unknown format: %s", format)
+			}
+
+			query := usecases.CohortQuery{
+				Category: category,
+				Months:   months,
+			}
+			if productID != "" {
+				id, err := entities.ParseID(productID)
+				if err != nil {
+					return err
+				}
+				query.ProductID = &id
+			}
+
+			r, err := c.repositories()
+			if err != nil {
+				return err
+			}
+
+			reportUseCase := usecases.NewReportUseCase(r.subscriptions)
+			report, err := reportUseCase.GetCohorts(context.Background(), query)
+			if err != nil {
+				return err
+			}
+
+			switch {
+			case c.output != outputTable:
+				return c.render(report, nil, nil)
+			case format == "csv":
+				return writeCohortsCSV(os.Stdout, report)
+			default:
+				return writeCohortsTable(os.Stdout, report)
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "table", "Table layout: table or csv (ignored with --output json|yaml)")
+	cmd.Flags().StringVar(&productID, "product-id", "", "Only include subscriptions of this product")
+	cmd.Flags().StringVar(&category, "category", "", "Only include products of this category")
+	cmd.Flags().IntVar(&months, "months", 12, "Number of months after signup to report")
+	cmd.RegisterFlagCompletionFunc("format", fixedCompletion("table", "csv"))
+	return cmd
+}
+
+// cohortRows flattens the report into a header and one row per cohort
+func cohortRows(report *usecases.CohortReport) ([]string, [][]string) {
+	header := []string{"cohort", "size"}
+	for offset := 0; offset <= report.Months; offset++ {
+		header = append(header, "m"+strconv.Itoa(offset))
+	}
+
+	rows := make([][]string, 0, len(report.Cohorts))
+	for _, cohort := range report.Cohorts {
+		row := []string{cohort.Month, strconv.Itoa(cohort.Size)}
+		for _, point := range cohort.Retention {
+			row = append(row, strconv.FormatFloat(point.Percentage, 'f', 1, 64))
+		}
+		// Months that have not happened yet are left blank
+		for len(row) < len(header) {
+			row = append(row, "")
+		}
+		rows = append(rows, row)
+	}
+
+	return header, rows
+}
+
+func writeCohortsCSV(w io.Writer, report *usecases.CohortReport) error {
+	header, rows := cohortRows(report)
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	if err := writer.WriteAll(rows); err != nil {
+		return err
+	}
+	return writer.Error()
+}
+
+func writeCohortsTable(w io.Writer, report *usecases.CohortReport) error {
+	header, rows := cohortRows(report)
+
+	writer := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	for _, row := range append([][]string{header}, rows...) {
+		for _, cell := range row {
+			fmt.Fprint(writer, cell, "\t")
+		}
+		fmt.Fprintln(writer)
+	}
+	return writer.Flush()
+}
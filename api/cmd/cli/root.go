@@ -0,0 +1,257 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/frtasoniero/subsmanager/internal/config"
+	domainrepos "github.com/frtasoniero/subsmanager/internal/domain/repositories"
+	"github.com/frtasoniero/subsmanager/internal/infrastructure/database"
+	"github.com/frtasoniero/subsmanager/internal/infrastructure/database/repositories"
+	"github.com/frtasoniero/subsmanager/internal/infrastructure/database/sqlstore"
+	"github.com/frtasoniero/subsmanager/internal/usecases"
+	"github.com/spf13/cobra"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Output formats of the --output flag
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+	outputYAML  = "yaml"
+)
+
+// cli holds the configuration and lazily opened database shared by all commands
+type cli struct {
+	cfg    *config.Config
+	output string
+	client *mongo.Client
+	db     *mongo.Database
+	sqlDB  *sqlstore.DB
+}
+
+// repos are the repositories of the storage backend selected by --storage
+type repos struct {
+	users         domainrepos.UserRepository
+	products      domainrepos.ProductRepository
+	subscriptions domainrepos.SubscriptionRepository
+}
+
+// newCLI loads the configuration shared by all commands
+func newCLI() *cli {
+	return &cli{cfg: config.Load()}
+}
+
+// newRootCmd builds the command tree
+func (c *cli) newRootCmd() *cobra.Command {
+	root := &cobra.Command{
+		Use:           "cli",
+		Short:         "Subscription manager admin tool",
+		Long:          "Administer the subscription manager database: users, products, subscriptions, reports, imports and exports.",
+		SilenceUsage:  true,
+		SilenceErrors: false,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			switch c.output {
+			case outputTable, outputJSON, outputYAML:
+			default:
+				return fmt.Errorf("unknown output format %q, expected table, json or yaml", c.output)
+			}
+			return c.checkStorage(cmd)
+		},
+	}
+
+	flags := root.PersistentFlags()
+	flags.StringVar(&c.cfg.Storage.Driver, "storage", c.cfg.Storage.Driver, "Storage backend: mongo, postgres or sqlite (defaults to $APP_STORAGE)")
+	flags.StringVar(&c.cfg.Database.URI, "mongo-uri", c.cfg.Database.URI, "MongoDB connection URI (defaults to $MONGO_URI)")
+	flags.StringVar(&c.cfg.Database.Name, "db", c.cfg.Database.Name, "MongoDB database name (defaults to $MONGO_DB_NAME)")
+	flags.StringVar(&c.cfg.Postgres.DSN, "postgres-dsn", c.cfg.Postgres.DSN, "PostgreSQL connection string (defaults to $POSTGRES_DSN)")
+	flags.StringVar(&c.cfg.SQLite.Path, "sqlite-path", c.cfg.SQLite.Path, "SQLite database file (defaults to $SQLITE_PATH)")
+	flags.StringVarP(&c.output, "output", "o", outputTable, "Output format: table, json or yaml")
+	root.RegisterFlagCompletionFunc("output", fixedCompletion(outputTable, outputJSON, outputYAML))
+	root.RegisterFlagCompletionFunc("storage", fixedCompletion(config.StorageMongo, config.StoragePostgres, config.StorageSQLite))
+
+	root.AddGroup(
+		&cobra.Group{ID: "resources", Title: "Resource Commands:"},
+		&cobra.Group{ID: "data", Title: "Data Commands:"},
+		&cobra.Group{ID: "database", Title: "Database Commands:"},
+		&cobra.Group{ID: "api", Title: "API Commands:"},
+	)
+	root.AddCommand(
+		c.newUsersCmd(),
+		c.newProductsCmd(),
+		c.newSubscriptionsCmd(),
+		c.newReportCmd(),
+		c.newImportCmd(),
+		c.newExportCmd(),
+		c.newCalendarURLCmd(),
+		c.newMigrateCmd(),
+		c.newSeedCmd(),
+		c.newMigrateStorageCmd(),
+		c.newBackupCmd(),
+		c.newRestoreCmd(),
+		c.newInitDBCmd(),
+		c.newCleanDBCmd(),
+		c.newOpenAPICmd(),
+	)
+
+	return root
+}
+
+// errMemoryStorage is returned by commands that prepare a database, which the
+// memory backend does not have
+var errMemoryStorage = errors.New("--storage memory has no database to prepare, the API seeds it with $APP_SEED_PROFILE at startup")
+
+// mongoOnly annotates commands that work on MongoDB collections directly
+var mongoOnly = map[string]string{"storage": config.StorageMongo}
+
+// checkStorage rejects commands annotated with mongoOnly, or with a parent
+// annotated so, when another storage is selected
+func (c *cli) checkStorage(cmd *cobra.Command) error {
+	for parent := cmd; parent != nil; parent = parent.Parent() {
+		if storage, ok := parent.Annotations["storage"]; ok && storage != c.cfg.Storage.Driver {
+			return fmt.Errorf("%s only supports --storage %s", cmd.CommandPath(), storage)
+		}
+	}
+	return nil
+}
+
+// dbConfig returns the database configuration after flag overrides
+func (c *cli) dbConfig() database.Config {
+	return database.Config{
+		URI:      c.cfg.Database.URI,
+		Database: c.cfg.Database.Name,
+		Timeout:  c.cfg.Database.Timeout,
+	}
+}
+
+// database connects on first use and returns the database
+func (c *cli) database() (*mongo.Database, error) {
+	if c.db != nil {
+		return c.db, nil
+	}
+
+	client, db, err := database.NewConnection(c.dbConfig())
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+	c.client = client
+	c.db = db
+	return db, nil
+}
+
+// sqlDatabase connects to the SQL database selected by --storage on first use
+func (c *cli) sqlDatabase() (*sqlstore.DB, error) {
+	if c.sqlDB != nil {
+		return c.sqlDB, nil
+	}
+
+	db, err := c.openSQL(c.cfg.Storage.Driver)
+	if err != nil {
+		return nil, err
+	}
+	c.sqlDB = db
+	return db, nil
+}
+
+// openSQL opens a new connection to the PostgreSQL or SQLite database of the
+// configuration. The caller closes it.
+func (c *cli) openSQL(storage string) (*sqlstore.DB, error) {
+	var sqlConfig sqlstore.Config
+	switch storage {
+	case config.StoragePostgres:
+		sqlConfig = sqlstore.Config{Driver: sqlstore.DriverPostgres, DSN: c.cfg.Postgres.DSN, Timeout: c.cfg.Postgres.Timeout}
+	case config.StorageSQLite:
+		sqlConfig = sqlstore.Config{Driver: sqlstore.DriverSQLite, DSN: c.cfg.SQLite.Path}
+	default:
+		return nil, fmt.Errorf("%s is not a SQL storage", storage)
+	}
+
+	db, err := sqlstore.Open(sqlConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+	return db, nil
+}
+
+// repositories opens the storage backend selected by --storage
+func (c *cli) repositories() (*repos, error) {
+	switch c.cfg.Storage.Driver {
+	case config.StorageMongo:
+		db, err := c.database()
+		if err != nil {
+			return nil, err
+		}
+		return &repos{
+			users:         repositories.NewMongoUserRepository(db),
+			products:      repositories.NewMongoProductRepository(db),
+			subscriptions: repositories.NewMongoSubscriptionRepository(db),
+		}, nil
+	case config.StoragePostgres, config.StorageSQLite:
+		db, err := c.sqlDatabase()
+		if err != nil {
+			return nil, err
+		}
+		return &repos{
+			users:         repositories.NewSQLUserRepository(db),
+			products:      repositories.NewSQLProductRepository(db),
+			subscriptions: repositories.NewSQLSubscriptionRepository(db),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported storage %q, expected %s, %s or %s",
+			c.cfg.Storage.Driver, config.StorageMongo, config.StoragePostgres, config.StorageSQLite)
+	}
+}
+
+// close closes the database connections that were opened
+func (c *cli) close() {
+	if c.client != nil {
+		database.Close(c.client)
+		c.client = nil
+		c.db = nil
+	}
+	if c.sqlDB != nil {
+		c.sqlDB.Close()
+		c.sqlDB = nil
+	}
+}
+
+func (c *cli) userUseCase() (*usecases.UserUseCase, error) {
+	r, err := c.repositories()
+	if err != nil {
+		return nil, err
+	}
+	return usecases.NewUserUseCase(r.users), nil
+}
+
+func (c *cli) productUseCase() (*usecases.ProductUseCase, error) {
+	r, err := c.repositories()
+	if err != nil {
+		return nil, err
+	}
+	return usecases.NewProductUseCase(r.products), nil
+}
+
+func (c *cli) subscriptionUseCase() (*usecases.SubscriptionUseCase, error) {
+	r, err := c.repositories()
+	if err != nil {
+		return nil, err
+	}
+	return usecases.NewSubscriptionUseCase(r.subscriptions), nil
+}
+
+// fixedCompletion completes a flag with a fixed list of values
+func fixedCompletion(values ...string) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return values, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// anyChanged returns true if at least one of the named flags was set
+func anyChanged(cmd *cobra.Command, names ...string) bool {
+	for _, name := range names {
+		if cmd.Flags().Changed(name) {
+			return true
+		}
+	}
+	return false
+}
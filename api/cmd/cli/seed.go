@@ -0,0 +1,177 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/frtasoniero/subsmanager/internal/infrastructure/database/seeds"
+	"github.com/spf13/cobra"
+)
+
+func (c *cli) newSeedCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "seed",
+		Short:   "Load seed profiles or generate synthetic data",
+		GroupID: "database",
+	}
+
+	cmd.AddCommand(
+		c.newSeedProfilesCmd(),
+		c.newSeedLoadCmd(),
+		c.newSeedGenerateCmd(),
+	)
+	return cmd
+}
+
+// addProfileFlag registers the --profile flag with completion of the built-in profiles
+func addProfileFlag(cmd *cobra.Command, profile *string) {
+	cmd.Flags().StringVar(profile, "profile", seeds.DefaultProfile, "Built-in seed profile or path of a YAML or JSON profile")
+	cmd.RegisterFlagCompletionFunc("profile", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return seeds.Profiles(), cobra.ShellCompDirectiveDefault
+	})
+}
+
+func (c *cli) newSeedProfilesCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "profiles",
+		Short: "List the built-in seed profiles",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var profiles []*seeds.Profile
+			rows := [][]string{}
+			for _, name := range seeds.Profiles() {
+				profile, err := seeds.LoadProfile(name)
+				if err != nil {
+					return err
+				}
+				profiles = append(profiles, profile)
+				rows = append(rows, []string{
+					profile.Name,
+					strconv.Itoa(len(profile.Products)),
+					strconv.Itoa(len(profile.Users)),
+					strconv.Itoa(len(profile.Subscriptions)),
+					profile.Description,
+				})
+			}
+			return c.render(profiles, []string{"NAME", "PRODUCTS", "USERS", "SUBSCRIPTIONS", "DESCRIPTION"}, rows)
+		},
+	}
+}
+
+func (c *cli) newSeedLoadCmd() *cobra.Command {
+	var clean bool
+
+	cmd := &cobra.Command{
+		Use:         "load <profile|file>",
+		Annotations: mongoOnly,
+		Short:       "Insert a seed profile",
+		Long:        "Insert a built-in seed profile, or a YAML or JSON profile file. Dates in profiles are offsets from today such as -2m, +1w or 0d.",
+		Args:        cobra.ExactArgs(1),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return seeds.Profiles(), cobra.ShellCompDirectiveDefault
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			profile, err := seeds.LoadProfile(args[0])
+			if err != nil {
+				return err
+			}
+			dataset, err := profile.Build(time.Now())
+			if err != nil {
+				return err
+			}
+			return c.insertDataset(dataset, clean)
+		},
+	}
+
+	cmd.Flags().BoolVar(&clean, "clean", false, "Remove existing users, products and subscriptions first")
+	return cmd
+}
+
+func (c *cli) newSeedGenerateCmd() *cobra.Command {
+	var users, years int
+	var seed int64
+	var now string
+	var clean, dryRun bool
+
+	cmd := &cobra.Command{
+		Use:         "generate",
+		Annotations: mongoOnly,
+		Short:       "Generate a reproducible synthetic dataset",
+		Long:        "Generate users with subscription histories across every status and billing type. The same --seed and --now always produce the same documents, IDs included.",
+		Args:        cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			reference := time.Now().UTC().Truncate(24 * time.Hour)
+			if now != "" {
+				var err error
+				if reference, err = time.Parse(cliDateLayout, now); err != nil {
+					return errors.New("--now must be formatted as YYYY-MM-DD")
+				}
+			}
+
+			dataset, err := seeds.Generate(seeds.GenerateOptions{
+				Users: users,
+				Seed:  seed,
+				Now:   reference,
+				Years: years,
+			})
+			if err != nil {
+				return err
+			}
+
+			if dryRun {
+				return c.renderCounts(dataset)
+			}
+			return c.insertDataset(dataset, clean)
+		},
+	}
+
+	cmd.Flags().IntVar(&users, "users", 1000, "Number of users to generate")
+	cmd.Flags().Int64Var(&seed, "seed", 1, "Random seed")
+	cmd.Flags().StringVar(&now, "now", "", "Reference date as YYYY-MM-DD (defaults to today)")
+	cmd.Flags().IntVar(&years, "years", 3, "Years of signup history before the reference date")
+	cmd.Flags().BoolVar(&clean, "clean", false, "Remove existing users, products and subscriptions first")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Only print what would be inserted")
+	return cmd
+}
+
+// insertDataset inserts a dataset into an empty database, or after cleaning it
+func (c *cli) insertDataset(dataset *seeds.Dataset, clean bool) error {
+	db, err := c.database()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	if clean {
+		if _, err := seeds.Clear(ctx, db); err != nil {
+			return err
+		}
+	} else {
+		empty, err := seeds.IsEmpty(ctx, db)
+		if err != nil {
+			return err
+		}
+		if !empty {
+			return errors.New("the database already contains data, use --clean to replace it")
+		}
+	}
+
+	started := time.Now()
+	if err := seeds.Insert(ctx, db, dataset); err != nil {
+		return err
+	}
+	c.info("✅ Seeded in %s", time.Since(started).Round(time.Millisecond))
+	return c.renderCounts(dataset)
+}
+
+func (c *cli) renderCounts(dataset *seeds.Dataset) error {
+	counts := dataset.Counts()
+	rows := make([][]string, 0, len(seeds.Collections))
+	for _, name := range seeds.Collections {
+		rows = append(rows, []string{name, fmt.Sprint(counts[name])})
+	}
+	return c.render(counts, []string{"COLLECTION", "DOCUMENTS"}, rows)
+}
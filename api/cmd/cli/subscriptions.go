@@ -0,0 +1,330 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/frtasoniero/subsmanager/internal/domain/entities"
+	"github.com/frtasoniero/subsmanager/internal/domain/repositories"
+	"github.com/spf13/cobra"
+)
+
+// cliDateLayout is the date format of date flags
+const cliDateLayout = "2006-01-02"
+
+func (c *cli) newSubscriptionsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "subscriptions",
+		Aliases: []string{"subscription", "subs"},
+		Short:   "Manage subscriptions",
+		GroupID: "resources",
+	}
+
+	cmd.AddCommand(
+		c.newSubscriptionsListCmd(),
+		c.newSubscriptionsGetCmd(),
+		c.newSubscriptionsCreateCmd(),
+		c.newSubscriptionsUpdateCmd(),
+		c.newSubscriptionsCancelCmd(),
+		c.newSubscriptionsPauseCmd(),
+		c.newSubscriptionsDeleteCmd(),
+	)
+	return cmd
+}
+
+func (c *cli) renderSubscriptionsWithProduct(subscriptions []*entities.SubscriptionWithProduct) error {
+	rows := make([][]string, 0, len(subscriptions))
+	for _, sub := range subscriptions {
+		rows = append(rows, []string{
+			sub.ID.String(), sub.UserID.String(), sub.ProductName, formatPrice(sub.ChargedPrice()), sub.BillingType,
+			sub.Status, formatDate(sub.StartDate), formatDate(sub.NextBilling), formatOptionalDate(sub.EndDate),
+		})
+	}
+	return c.render(subscriptions, []string{"ID", "USER", "PRODUCT", "PRICE", "BILLING", "STATUS", "STARTED", "NEXT BILLING", "ENDED"}, rows)
+}
+
+func (c *cli) renderSubscription(sub *entities.Subscription) error {
+	row := []string{
+		sub.ID.String(), sub.UserID.String(), sub.ProductID.String(), formatPrice(sub.PriceAtStart),
+		sub.Status, formatDate(sub.StartDate), formatDate(sub.NextBilling), formatOptionalDate(sub.EndDate),
+	}
+	return c.render(sub, []string{"ID", "USER", "PRODUCT", "PRICE", "STATUS", "STARTED", "NEXT BILLING", "ENDED"}, [][]string{row})
+}
+
+func (c *cli) newSubscriptionsListCmd() *cobra.Command {
+	var user, product, status, category string
+	var active bool
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List subscriptions with their product",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			filter := repositories.SubscriptionFilter{Status: status, Category: category}
+			var err error
+			if filter.UserID, err = optionalID(user); err != nil {
+				return err
+			}
+			if filter.ProductID, err = optionalID(product); err != nil {
+				return err
+			}
+			if active {
+				filter.Status = "active"
+			}
+
+			subscriptionUseCase, err := c.subscriptionUseCase()
+			if err != nil {
+				return err
+			}
+			subscriptions, err := subscriptionUseCase.FindSubscriptions(context.Background(), filter)
+			if err != nil {
+				return err
+			}
+			return c.renderSubscriptionsWithProduct(subscriptions)
+		},
+	}
+
+	cmd.Flags().StringVar(&user, "user", "", "Only list subscriptions of this user ID")
+	cmd.Flags().StringVar(&product, "product", "", "Only list subscriptions of this product ID")
+	cmd.Flags().StringVar(&status, "status", "", "Only list subscriptions with this status")
+	cmd.Flags().StringVar(&category, "category", "", "Only list subscriptions of products in this category")
+	cmd.Flags().BoolVar(&active, "active", false, "Only list active subscriptions, same as --status active")
+	cmd.MarkFlagsMutuallyExclusive("status", "active")
+	cmd.RegisterFlagCompletionFunc("status", fixedCompletion("active", "paused", "cancelled", "expired"))
+	return cmd
+}
+
+// optionalID parses the value of an optional ID flag, nil when it is empty
+func optionalID(value string) (*entities.ID, error) {
+	if value == "" {
+		return nil, nil
+	}
+	id, err := entities.ParseID(value)
+	if err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
+
+func (c *cli) newSubscriptionsGetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "get <id>",
+		Short: "Show a subscription",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := entities.ParseID(args[0])
+			if err != nil {
+				return err
+			}
+			subscriptionUseCase, err := c.subscriptionUseCase()
+			if err != nil {
+				return err
+			}
+			subscription, err := subscriptionUseCase.GetSubscriptionByID(context.Background(), id)
+			if err != nil {
+				return err
+			}
+			return c.renderSubscription(subscription)
+		},
+	}
+}
+
+func (c *cli) newSubscriptionsCreateCmd() *cobra.Command {
+	var user, product, startDate, nextBilling string
+	var price float64
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Subscribe a user to a product",
+		Long:  "Subscribe a user to a product. The price defaults to the current product price and the next billing date to one billing cycle after the start date.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			userID, err := entities.ParseID(user)
+			if err != nil {
+				return err
+			}
+			productID, err := entities.ParseID(product)
+			if err != nil {
+				return err
+			}
+
+			productUseCase, err := c.productUseCase()
+			if err != nil {
+				return err
+			}
+			subscriptionUseCase, err := c.subscriptionUseCase()
+			if err != nil {
+				return err
+			}
+
+			ctx := context.Background()
+			p, err := productUseCase.GetProductByID(ctx, productID)
+			if err != nil {
+				return err
+			}
+
+			subscription := &entities.Subscription{
+				UserID:       userID,
+				ProductID:    productID,
+				PriceAtStart: p.Price,
+				StartDate:    time.Now(),
+			}
+			if cmd.Flags().Changed("price") {
+				subscription.PriceAtStart = price
+			}
+			if startDate != "" {
+				if subscription.StartDate, err = time.Parse(cliDateLayout, startDate); err != nil {
+					return errors.New("--start-date must be formatted as YYYY-MM-DD")
+				}
+			}
+			subscription.NextBilling = entities.NextBillingDate(subscription.StartDate, p.BillingType)
+			if nextBilling != "" {
+				if subscription.NextBilling, err = time.Parse(cliDateLayout, nextBilling); err != nil {
+					return errors.New("--next-billing must be formatted as YYYY-MM-DD")
+				}
+			}
+
+			if err := subscriptionUseCase.CreateSubscription(ctx, subscription); err != nil {
+				return err
+			}
+			c.info("✅ Subscription created")
+			return c.renderSubscription(subscription)
+		},
+	}
+
+	cmd.Flags().StringVar(&user, "user", "", "User ID (required)")
+	cmd.Flags().StringVar(&product, "product", "", "Product ID (required)")
+	cmd.Flags().StringVar(&startDate, "start-date", "", "Start date as YYYY-MM-DD (defaults to today)")
+	cmd.Flags().StringVar(&nextBilling, "next-billing", "", "Next billing date as YYYY-MM-DD")
+	cmd.Flags().Float64Var(&price, "price", 0, "Price per billing cycle (defaults to the product price)")
+	cmd.MarkFlagRequired("user")
+	cmd.MarkFlagRequired("product")
+	return cmd
+}
+
+func (c *cli) newSubscriptionsUpdateCmd() *cobra.Command {
+	var status, nextBilling string
+	var price float64
+
+	cmd := &cobra.Command{
+		Use:   "update <id>",
+		Short: "Update the given fields of a subscription",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := entities.ParseID(args[0])
+			if err != nil {
+				return err
+			}
+			if !anyChanged(cmd, "status", "next-billing", "price") {
+				return errors.New("nothing to update, set --status, --next-billing or --price")
+			}
+
+			subscriptionUseCase, err := c.subscriptionUseCase()
+			if err != nil {
+				return err
+			}
+
+			ctx := context.Background()
+			subscription, err := subscriptionUseCase.GetSubscriptionByID(ctx, id)
+			if err != nil {
+				return err
+			}
+
+			if cmd.Flags().Changed("status") {
+				subscription.Status = status
+			}
+			if cmd.Flags().Changed("next-billing") {
+				if subscription.NextBilling, err = time.Parse(cliDateLayout, nextBilling); err != nil {
+					return errors.New("--next-billing must be formatted as YYYY-MM-DD")
+				}
+			}
+			if cmd.Flags().Changed("price") {
+				subscription.PriceAtStart = price
+			}
+
+			if err := subscriptionUseCase.UpdateSubscription(ctx, subscription); err != nil {
+				return err
+			}
+			c.info("✅ Subscription updated")
+			return c.renderSubscription(subscription)
+		},
+	}
+
+	cmd.Flags().StringVar(&status, "status", "", "New status: active, paused, cancelled or expired")
+	cmd.Flags().StringVar(&nextBilling, "next-billing", "", "New next billing date as YYYY-MM-DD")
+	cmd.Flags().Float64Var(&price, "price", 0, "New price per billing cycle")
+	cmd.RegisterFlagCompletionFunc("status", fixedCompletion("active", "paused", "cancelled", "expired"))
+	return cmd
+}
+
+func (c *cli) newSubscriptionsCancelCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "cancel <id>",
+		Short: "Cancel a subscription",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := entities.ParseID(args[0])
+			if err != nil {
+				return err
+			}
+			subscriptionUseCase, err := c.subscriptionUseCase()
+			if err != nil {
+				return err
+			}
+			subscription, err := subscriptionUseCase.CancelSubscription(context.Background(), id)
+			if err != nil {
+				return err
+			}
+			c.info("✅ Subscription cancelled")
+			return c.renderSubscription(subscription)
+		},
+	}
+}
+
+func (c *cli) newSubscriptionsPauseCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "pause <id>",
+		Short: "Pause an active subscription; resume it with update --status active",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := entities.ParseID(args[0])
+			if err != nil {
+				return err
+			}
+			subscriptionUseCase, err := c.subscriptionUseCase()
+			if err != nil {
+				return err
+			}
+			subscription, err := subscriptionUseCase.PauseSubscription(context.Background(), id)
+			if err != nil {
+				return err
+			}
+			c.info("⏸️  Subscription paused")
+			return c.renderSubscription(subscription)
+		},
+	}
+}
+
+func (c *cli) newSubscriptionsDeleteCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete <id>",
+		Short: "Delete a subscription",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := entities.ParseID(args[0])
+			if err != nil {
+				return err
+			}
+			subscriptionUseCase, err := c.subscriptionUseCase()
+			if err != nil {
+				return err
+			}
+			if err := subscriptionUseCase.DeleteSubscription(context.Background(), id); err != nil {
+				return err
+			}
+			c.info("🗑️  Subscription %s deleted", id.String())
+			return nil
+		},
+	}
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/frtasoniero/subsmanager/internal/config"
+	"github.com/frtasoniero/subsmanager/internal/infrastructure/database/migrate"
+	"github.com/frtasoniero/subsmanager/internal/infrastructure/database/seeds"
+	"github.com/frtasoniero/subsmanager/internal/infrastructure/database/sqlstore"
+	"github.com/frtasoniero/subsmanager/internal/infrastructure/database/transfer"
+	"github.com/spf13/cobra"
+)
+
+// transferResult is the output of the migrate-storage command
+type transferResult struct {
+	From   string         `json:"from"`
+	To     string         `json:"to"`
+	Copied map[string]int `json:"copied"`
+}
+
+func (c *cli) newMigrateStorageCmd() *cobra.Command {
+	var from, to string
+	var replace, yes bool
+
+	cmd := &cobra.Command{
+		Use:   "migrate-storage",
+		Short: "Copy every product, user and subscription to another storage backend",
+		Long: "Copy every product, user and subscription from one storage backend to another, keeping their IDs, " +
+			"for example from MongoDB to a SQLite file for single-user self-hosting and back. " +
+			"Both databases are migrated to the latest schema first. The target must be empty unless --replace is given, " +
+			"which deletes its data before the copy.",
+		GroupID: "database",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if from == to {
+				return fmt.Errorf("--from and --to are both %s", from)
+			}
+			if replace && !yes && !confirm(fmt.Sprintf("Delete every product, user and subscription of the %s storage before copying?", to), false) {
+				return errors.New("copy aborted")
+			}
+
+			ctx := context.Background()
+			source, closeSource, err := c.transferStore(ctx, from)
+			if err != nil {
+				return err
+			}
+			defer closeSource()
+			target, closeTarget, err := c.transferStore(ctx, to)
+			if err != nil {
+				return err
+			}
+			defer closeTarget()
+
+			c.info("🔄 Copying %s data to %s...", from, to)
+			dataset, err := transfer.Copy(ctx, source, target, replace)
+			if errors.Is(err, transfer.ErrTargetNotEmpty) {
+				return fmt.Errorf("the %s storage already contains data, use --replace to overwrite it", to)
+			}
+			if err != nil {
+				return err
+			}
+
+			result := transferResult{From: from, To: to, Copied: dataset.Counts()}
+			rows := make([][]string, 0, len(seeds.Collections))
+			for _, name := range seeds.Collections {
+				rows = append(rows, []string{name, strconv.Itoa(result.Copied[name])})
+			}
+			return c.render(result, []string{"COLLECTION", "COPIED"}, rows)
+		},
+	}
+
+	storages := fixedCompletion(config.StorageMongo, config.StoragePostgres, config.StorageSQLite)
+	cmd.Flags().StringVar(&from, "from", "", "Storage to copy from: mongo, postgres or sqlite (required)")
+	cmd.Flags().StringVar(&to, "to", "", "Storage to copy to: mongo, postgres or sqlite (required)")
+	cmd.Flags().BoolVar(&replace, "replace", false, "Delete the data of the target storage before copying")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Do not ask for confirmation with --replace")
+	cmd.MarkFlagRequired("from")
+	cmd.MarkFlagRequired("to")
+	cmd.RegisterFlagCompletionFunc("from", storages)
+	cmd.RegisterFlagCompletionFunc("to", storages)
+	return cmd
+}
+
+// transferStore connects to a storage backend and migrates it to the latest
+// schema, so data is read and written in the shape of this build
+func (c *cli) transferStore(ctx context.Context, storage string) (transfer.Store, func(), error) {
+	switch storage {
+	case config.StorageMongo:
+		db, err := c.database()
+		if err != nil {
+			return nil, nil, err
+		}
+		if _, err := migrate.NewMigrator(db).Up(ctx); err != nil {
+			return nil, nil, fmt.Errorf("failed to migrate %s: %w", storage, err)
+		}
+		// The connection is shared with other commands and closed by c.close
+		return transfer.NewMongoStore(db), func() {}, nil
+	case config.StoragePostgres, config.StorageSQLite:
+		db, err := c.openSQL(storage)
+		if err != nil {
+			return nil, nil, err
+		}
+		migrator, err := sqlstore.NewMigrator(db)
+		if err == nil {
+			_, err = migrator.Up(ctx)
+		}
+		if err != nil {
+			db.Close()
+			return nil, nil, fmt.Errorf("failed to migrate %s: %w", storage, err)
+		}
+		return transfer.NewSQLStore(db), func() { db.Close() }, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown storage %q, expected %s, %s or %s",
+			storage, config.StorageMongo, config.StoragePostgres, config.StorageSQLite)
+	}
+}
@@ -0,0 +1,210 @@
+package main
+
+import (
+	"context"
+	"errors"
+
+	"github.com/frtasoniero/subsmanager/internal/domain/entities"
+	"github.com/spf13/cobra"
+)
+
+func (c *cli) newUsersCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "users",
+		Aliases: []string{"user"},
+		Short:   "Manage users",
+		GroupID: "resources",
+	}
+
+	cmd.AddCommand(
+		c.newUsersListCmd(),
+		c.newUsersGetCmd(),
+		c.newUsersCreateCmd(),
+		c.newUsersUpdateCmd(),
+		c.newUsersDeactivateCmd(),
+		c.newUsersDeleteCmd(),
+	)
+	return cmd
+}
+
+func (c *cli) renderUsers(users ...*entities.User) error {
+	rows := make([][]string, 0, len(users))
+	for _, user := range users {
+		rows = append(rows, []string{user.ID.String(), user.Username, user.Email, user.Status, formatDate(user.CreatedAt)})
+	}
+
+	var value any = users
+	if len(users) == 1 {
+		value = users[0]
+	}
+	return c.render(value, []string{"ID", "USERNAME", "EMAIL", "STATUS", "CREATED"}, rows)
+}
+
+func (c *cli) newUsersListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List all users",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			userUseCase, err := c.userUseCase()
+			if err != nil {
+				return err
+			}
+			users, err := userUseCase.GetAllUsers(context.Background())
+			if err != nil {
+				return err
+			}
+			return c.renderUsers(users...)
+		},
+	}
+}
+
+func (c *cli) newUsersGetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "get <id>",
+		Short: "Show a user",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := entities.ParseID(args[0])
+			if err != nil {
+				return err
+			}
+			userUseCase, err := c.userUseCase()
+			if err != nil {
+				return err
+			}
+			user, err := userUseCase.GetUserByID(context.Background(), id)
+			if err != nil {
+				return err
+			}
+			return c.renderUsers(user)
+		},
+	}
+}
+
+func (c *cli) newUsersCreateCmd() *cobra.Command {
+	user := &entities.User{}
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a user",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			userUseCase, err := c.userUseCase()
+			if err != nil {
+				return err
+			}
+			if err := userUseCase.CreateUser(context.Background(), user); err != nil {
+				return err
+			}
+			c.info("✅ User created")
+			return c.renderUsers(user)
+		},
+	}
+
+	cmd.Flags().StringVar(&user.Username, "username", "", "Username (required)")
+	cmd.Flags().StringVar(&user.Email, "email", "", "Email address (required)")
+	cmd.Flags().StringVar(&user.Password, "password", "", "Password (required)")
+	cmd.MarkFlagRequired("username")
+	cmd.MarkFlagRequired("email")
+	cmd.MarkFlagRequired("password")
+	return cmd
+}
+
+func (c *cli) newUsersUpdateCmd() *cobra.Command {
+	var username, email, status string
+
+	cmd := &cobra.Command{
+		Use:   "update <id>",
+		Short: "Update the given fields of a user",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := entities.ParseID(args[0])
+			if err != nil {
+				return err
+			}
+			userUseCase, err := c.userUseCase()
+			if err != nil {
+				return err
+			}
+
+			ctx := context.Background()
+			user, err := userUseCase.GetUserByID(ctx, id)
+			if err != nil {
+				return err
+			}
+
+			flags := cmd.Flags()
+			if !anyChanged(cmd, "username", "email", "status") {
+				return errors.New("nothing to update, set --username, --email or --status")
+			}
+			if flags.Changed("username") {
+				user.Username = username
+			}
+			if flags.Changed("email") {
+				user.Email = email
+			}
+			if flags.Changed("status") {
+				user.Status = status
+			}
+
+			if err := userUseCase.UpdateUser(ctx, user); err != nil {
+				return err
+			}
+			c.info("✅ User updated")
+			return c.renderUsers(user)
+		},
+	}
+
+	cmd.Flags().StringVar(&username, "username", "", "New username")
+	cmd.Flags().StringVar(&email, "email", "", "New email address")
+	cmd.Flags().StringVar(&status, "status", "", "New status: active or inactive")
+	cmd.RegisterFlagCompletionFunc("status", fixedCompletion("active", "inactive"))
+	return cmd
+}
+
+func (c *cli) newUsersDeactivateCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "deactivate <id>",
+		Short: "Deactivate a user",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := entities.ParseID(args[0])
+			if err != nil {
+				return err
+			}
+			userUseCase, err := c.userUseCase()
+			if err != nil {
+				return err
+			}
+			if err := userUseCase.DeactivateUser(context.Background(), id); err != nil {
+				return err
+			}
+			c.info("✅ User %s deactivated", id.String())
+			return nil
+		},
+	}
+}
+
+func (c *cli) newUsersDeleteCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete <id>",
+		Short: "Delete a user",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := entities.ParseID(args[0])
+			if err != nil {
+				return err
+			}
+			userUseCase, err := c.userUseCase()
+			if err != nil {
+				return err
+			}
+			if err := userUseCase.DeleteUser(context.Background(), id); err != nil {
+				return err
+			}
+			c.info("🗑️  User %s deleted", id.String())
+			return nil
+		},
+	}
+}
@@ -1,24 +1,32 @@
 package app
 
 import (
+	"context"
 	"log"
 
 	"go.mongodb.org/mongo-driver/mongo"
 
 	"github.com/frtasoniero/subsmanager/internal/config"
-	"github.com/frtasoniero/subsmanager/internal/infrastructure/database"
-	"github.com/frtasoniero/subsmanager/internal/infrastructure/database/repositories"
+	"github.com/frtasoniero/subsmanager/internal/infrastructure/cache"
+	"github.com/frtasoniero/subsmanager/internal/infrastructure/events"
+	"github.com/frtasoniero/subsmanager/internal/infrastructure/rpc"
 	"github.com/frtasoniero/subsmanager/internal/infrastructure/web"
+	"github.com/frtasoniero/subsmanager/internal/infrastructure/web/graphql"
 	"github.com/frtasoniero/subsmanager/internal/infrastructure/web/handlers"
 	"github.com/frtasoniero/subsmanager/internal/usecases"
 )
 
-// App represents the application container with all dependencies
+// App represents the application container with all dependencies.
+// DB and Client are nil when APP_STORAGE=memory.
 type App struct {
 	Config   *config.Config
 	DB       *mongo.Database
 	Client   *mongo.Client
 	Handlers *web.AppHandlers
+	Services *rpc.Services
+
+	storage *storage
+	events  *events.Broker
 }
 
 // NewApp creates a new application instance with all dependencies
@@ -26,39 +34,90 @@ func NewApp() (*App, error) {
 	// Load configuration
 	cfg := config.Load()
 	log.Println("📋 Configuration loaded")
-
-	// Database connection
-	dbConfig := database.Config{
-		URI:      cfg.Database.URI,
-		Database: cfg.Database.Name,
-		Timeout:  cfg.Database.Timeout,
+	switch {
+	case cfg.CalendarSecret() == "":
+		log.Println("⚠️  CALENDAR_SECRET is not set, calendar feeds are disabled")
+	case cfg.UsesDefaultCalendarSecret():
+		log.Println("⚠️  CALENDAR_SECRET is not set, calendar feed tokens use the development secret")
 	}
 
-	client, db, err := database.NewConnection(dbConfig)
+	// Storage backend
+	store, err := newStorage(cfg)
 	if err != nil {
 		return nil, err
 	}
+	subscriptionRepo := store.subscriptions
+	productRepo := store.products
+
+	// Read-through cache, invalidated by the decorated repositories
+	var appCache *cache.Cache
+	if cfg.Cache.Size > 0 {
+		appCache = cache.New(cache.NewLRU(cfg.Cache.Size), cfg.Cache.TTL)
+		productRepo = cache.NewProductRepository(productRepo, appCache)
+		subscriptionRepo = cache.NewSubscriptionRepository(subscriptionRepo, appCache)
+		log.Printf("📦 Caching up to %d entries for %s", cfg.Cache.Size, cfg.Cache.TTL)
+	}
 
-	// Initialize repositories
-	subscriptionRepo := repositories.NewMongoSubscriptionRepository(db)
+	// Subscription writes, streamed by the gRPC API
+	broker := events.NewBroker()
+	subscriptionRepo = events.NewSubscriptionRepository(subscriptionRepo, broker)
 
 	// Initialize use cases
 	subscriptionUseCase := usecases.NewSubscriptionUseCase(subscriptionRepo)
+	productUseCase := usecases.NewProductUseCase(productRepo)
+	userUseCase := usecases.NewUserUseCase(store.users)
+	reportUseCase := usecases.NewReportUseCase(subscriptionRepo)
+	importUseCase := usecases.NewImportUseCase(subscriptionRepo, productRepo, store.users)
+	exportUseCase := usecases.NewExportUseCase(subscriptionRepo)
+	if appCache != nil {
+		exportUseCase.WithSummaryCache(cache.NewSpendingSummaries(appCache))
+	}
+	statementUseCase := usecases.NewStatementUseCase(subscriptionRepo, productRepo, importUseCase)
+	calendarUseCase := usecases.NewCalendarUseCase(subscriptionRepo, cfg.CalendarSecret(), cfg.Calendar.AlarmDays)
+	searchUseCase := usecases.NewSearchUseCase(productRepo, subscriptionRepo)
 
 	// Initialize handlers
 	subscriptionHandler := handlers.NewSubscriptionHandler(subscriptionUseCase)
+	reportHandler := handlers.NewReportHandler(reportUseCase)
+	importHandler := handlers.NewImportHandler(importUseCase)
+	exportHandler := handlers.NewExportHandler(exportUseCase).WithWriteTimeout(cfg.Server.ExportWriteTimeout)
+	calendarHandler := handlers.NewCalendarHandler(calendarUseCase)
+	statementHandler := handlers.NewStatementHandler(statementUseCase)
+	searchHandler := handlers.NewSearchHandler(searchUseCase)
+	graphqlHandler := graphql.NewHandler(subscriptionUseCase, productUseCase, userUseCase)
+
+	// Initialize gRPC services
+	subscriptionService := rpc.NewSubscriptionService(subscriptionUseCase, productUseCase, userUseCase, broker)
+	productService := rpc.NewProductService(productUseCase)
+	userService := rpc.NewUserService(userUseCase)
 
 	return &App{
-		Config: cfg,
-		DB:     db,
-		Client: client,
+		Config:  cfg,
+		DB:      store.db,
+		Client:  store.client,
+		storage: store,
+		events:  broker,
 		Handlers: &web.AppHandlers{
 			Subscription: subscriptionHandler,
+			Report:       reportHandler,
+			Import:       importHandler,
+			Export:       exportHandler,
+			Calendar:     calendarHandler,
+			Statement:    statementHandler,
+			Search:       searchHandler,
+			GraphQL:      graphqlHandler,
+		},
+		Services: &rpc.Services{
+			Subscription: subscriptionService,
+			Product:      productService,
+			User:         userService,
 		},
 	}, nil
 }
 
-// Close closes the application and cleans up resources
-func (a *App) Close() error {
-	return database.Close(a.Client)
+// Close ends the subscription event streams and closes the storage,
+// waiting for database operations in progress until ctx is done
+func (a *App) Close(ctx context.Context) error {
+	a.events.Close()
+	return a.storage.close(ctx)
 }
@@ -1,31 +1,62 @@
 package app
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"log"
+	"net"
 	"net/http"
 
+	"google.golang.org/grpc"
+
+	"github.com/frtasoniero/subsmanager/internal/infrastructure/rpc"
 	"github.com/frtasoniero/subsmanager/internal/infrastructure/web"
 	"github.com/gin-gonic/gin"
 )
 
-// Server represents the HTTP server
+// Server represents the HTTP server, with an optional gRPC server next to it
+// and an optional admin listener serving /debug/vars
 type Server struct {
-	app    *App
-	router *gin.Engine
-	port   string
+	app        *App
+	router     *gin.Engine
+	httpServer *http.Server
+	// grpcServer is nil unless GRPC_PORT is set
+	grpcServer *grpc.Server
+	grpcPort   string
+	// adminServer is nil unless ADMIN_ADDR is set
+	adminServer *http.Server
 }
 
 // NewServer creates a new HTTP server instance
 func NewServer(app *App) *Server {
-	addr := ":" + app.Config.Server.Port
+	cfg := app.Config.Server
 
-	server := &Server{
-		app:  app,
-		port: addr,
-	}
+	server := &Server{app: app}
 
 	// Initialize router
 	server.router = server.setupRouter()
+	if cfg.GRPCPort != "" {
+		server.grpcPort = ":" + cfg.GRPCPort
+		server.grpcServer = server.setupGRPC()
+	}
+
+	server.httpServer = &http.Server{
+		Addr:              ":" + cfg.Port,
+		Handler:           server.router,
+		ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+		ReadTimeout:       cfg.ReadTimeout,
+		WriteTimeout:      cfg.WriteTimeout,
+		IdleTimeout:       cfg.IdleTimeout,
+	}
+
+	if cfg.AdminAddr != "" {
+		server.adminServer = &http.Server{
+			Addr:              cfg.AdminAddr,
+			Handler:           web.AdminHandler(),
+			ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+		}
+	}
 
 	return server
 }
@@ -48,13 +79,116 @@ func (s *Server) setupRouter() *gin.Engine {
 	return r
 }
 
-// Start starts the HTTP server
+// setupGRPC configures the gRPC server with interceptors and services
+func (s *Server) setupGRPC() *grpc.Server {
+	server := rpc.NewServer()
+	rpc.RegisterServices(server, s.app.Services, s.app.Config.Server.GRPCReflection)
+	return server
+}
+
+// Run serves until ctx is done or a server fails, then shuts down within
+// the configured shutdown timeout
+func (s *Server) Run(ctx context.Context) error {
+	errs := make(chan error, 1)
+	go func() {
+		errs <- s.Start()
+	}()
+
+	var err error
+	select {
+	case <-ctx.Done():
+		log.Println("🛑 Shutdown requested")
+	case err = <-errs:
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), s.app.Config.Server.ShutdownTimeout)
+	defer cancel()
+	return errors.Join(err, s.Shutdown(shutdownCtx))
+}
+
+// Start starts the HTTP server, and the gRPC and admin servers when set. It
+// returns when one fails, or nil once Shutdown stopped them.
 func (s *Server) Start() error {
-	log.Printf("🚀 Server starting on %s", s.port)
-	return s.router.Run(s.port)
+	errs := make(chan error, 3)
+	if s.grpcServer != nil {
+		listener, err := net.Listen("tcp", s.grpcPort)
+		if err != nil {
+			return err
+		}
+		go func() {
+			log.Printf("📡 gRPC server starting on %s", s.grpcPort)
+			errs <- s.grpcServer.Serve(listener)
+		}()
+	}
+	go func() {
+		log.Printf("🚀 Server starting on %s", s.httpServer.Addr)
+		errs <- s.httpServer.ListenAndServe()
+	}()
+	if s.adminServer != nil {
+		go func() {
+			log.Printf("🔧 Admin server starting on %s", s.adminServer.Addr)
+			errs <- s.adminServer.ListenAndServe()
+		}()
+	}
+
+	if err := <-errs; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// Shutdown stops the servers and closes the application in order:
+//  1. the subscription event streams end, as they never finish on their own
+//  2. the servers stop accepting connections and drain in-flight requests
+//  3. the storage closes once nothing uses it anymore
+//
+// Requests still running when ctx is done are cut off, and the storage is
+// closed anyway. There are no background workers to stop yet.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.app.events.Close()
+
+	grpcStopped := make(chan struct{})
+	if s.grpcServer != nil {
+		go func() {
+			s.grpcServer.GracefulStop()
+			close(grpcStopped)
+		}()
+	}
+
+	var errs []error
+	if err := s.httpServer.Shutdown(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("draining HTTP requests: %w", err))
+		s.httpServer.Close()
+	}
+	if s.adminServer != nil {
+		if err := s.adminServer.Shutdown(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("draining admin requests: %w", err))
+			s.adminServer.Close()
+		}
+	}
+	if s.grpcServer != nil {
+		select {
+		case <-grpcStopped:
+		case <-ctx.Done():
+			errs = append(errs, fmt.Errorf("draining gRPC calls: %w", ctx.Err()))
+			s.grpcServer.Stop()
+		}
+	}
+	log.Println("✅ Servers stopped")
+
+	if err := s.app.Close(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("closing storage: %w", err))
+	}
+	return errors.Join(errs...)
 }
 
 // Handler returns the HTTP handler (useful for testing)
 func (s *Server) Handler() http.Handler {
 	return s.router
 }
+
+// GRPCServer returns the gRPC server (useful for testing), or nil when
+// GRPC_PORT is not set
+func (s *Server) GRPCServer() *grpc.Server {
+	return s.grpcServer
+}
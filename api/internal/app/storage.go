@@ -0,0 +1,182 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/frtasoniero/subsmanager/internal/config"
+	domainrepos "github.com/frtasoniero/subsmanager/internal/domain/repositories"
+	"github.com/frtasoniero/subsmanager/internal/infrastructure/database"
+	"github.com/frtasoniero/subsmanager/internal/infrastructure/database/migrate"
+	"github.com/frtasoniero/subsmanager/internal/infrastructure/database/repositories"
+	"github.com/frtasoniero/subsmanager/internal/infrastructure/database/seeds"
+	"github.com/frtasoniero/subsmanager/internal/infrastructure/database/sqlstore"
+)
+
+// storage groups the repositories of the configured backend
+type storage struct {
+	users         domainrepos.UserRepository
+	products      domainrepos.ProductRepository
+	subscriptions domainrepos.SubscriptionRepository
+
+	// Set for the mongo backend only
+	client *mongo.Client
+	db     *mongo.Database
+
+	// Set for the postgres and sqlite backends only
+	sqlDB *sqlstore.DB
+}
+
+// newStorage opens the backend selected by APP_STORAGE
+func newStorage(cfg *config.Config) (*storage, error) {
+	switch cfg.Storage.Driver {
+	case config.StorageMongo:
+		return newMongoStorage(cfg)
+	case config.StoragePostgres:
+		return newPostgresStorage(cfg)
+	case config.StorageSQLite:
+		return newSQLiteStorage(cfg)
+	case config.StorageMemory:
+		return newMemoryStorage(cfg)
+	default:
+		return nil, fmt.Errorf("unknown APP_STORAGE %q, expected %s, %s, %s or %s",
+			cfg.Storage.Driver, config.StorageMongo, config.StoragePostgres, config.StorageSQLite, config.StorageMemory)
+	}
+}
+
+func newMongoStorage(cfg *config.Config) (*storage, error) {
+	dbConfig := database.Config{
+		URI:      cfg.Database.URI,
+		Database: cfg.Database.Name,
+		Timeout:  cfg.Database.Timeout,
+	}
+
+	client, db, err := database.NewConnection(dbConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	// Refuse to start against a database missing migrations, indexes or validators
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Database.Timeout)
+	defer cancel()
+	if err := migrate.CheckSchema(ctx, db); err != nil {
+		database.Close(client)
+		return nil, err
+	}
+	log.Println("✅ Database schema is up to date")
+
+	return &storage{
+		users:         repositories.NewMongoUserRepository(db),
+		products:      repositories.NewMongoProductRepository(db),
+		subscriptions: repositories.NewMongoSubscriptionRepository(db),
+		client:        client,
+		db:            db,
+	}, nil
+}
+
+func newPostgresStorage(cfg *config.Config) (*storage, error) {
+	db, err := sqlstore.Open(sqlstore.Config{
+		Driver:  sqlstore.DriverPostgres,
+		DSN:     cfg.Postgres.DSN,
+		Timeout: cfg.Postgres.Timeout,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Refuse to start against a database missing migrations
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Postgres.Timeout)
+	defer cancel()
+	migrator, err := sqlstore.NewMigrator(db)
+	if err == nil {
+		err = migrator.Check(ctx)
+	}
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	log.Println("✅ Database schema is up to date")
+
+	return newSQLStorage(db), nil
+}
+
+// newSQLiteStorage opens the database file and applies pending migrations.
+// Unlike the server backends, the file belongs to this process alone, so
+// there is no separate migration step to run first.
+func newSQLiteStorage(cfg *config.Config) (*storage, error) {
+	db, err := sqlstore.Open(sqlstore.Config{
+		Driver: sqlstore.DriverSQLite,
+		DSN:    cfg.SQLite.Path,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	migrator, err := sqlstore.NewMigrator(db)
+	if err == nil {
+		var applied []*sqlstore.Migration
+		applied, err = migrator.Up(context.Background())
+		if len(applied) > 0 {
+			log.Printf("✅ Applied %d migrations to %s", len(applied), cfg.SQLite.Path)
+		}
+	}
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return newSQLStorage(db), nil
+}
+
+func newSQLStorage(db *sqlstore.DB) *storage {
+	return &storage{
+		users:         repositories.NewSQLUserRepository(db),
+		products:      repositories.NewSQLProductRepository(db),
+		subscriptions: repositories.NewSQLSubscriptionRepository(db),
+		sqlDB:         db,
+	}
+}
+
+// newMemoryStorage keeps everything in memory and loads the seed profile, so
+// the API runs without any database. Data is lost on restart.
+func newMemoryStorage(cfg *config.Config) (*storage, error) {
+	store := repositories.NewMemoryStore()
+	s := &storage{
+		users:         repositories.NewMemoryUserRepository(store),
+		products:      repositories.NewMemoryProductRepository(store),
+		subscriptions: repositories.NewMemorySubscriptionRepository(store),
+	}
+
+	profile, err := seeds.LoadProfile(cfg.Storage.SeedProfile)
+	if err != nil {
+		return nil, err
+	}
+	dataset, err := profile.Build(time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := seeds.Create(context.Background(), s.users, s.products, s.subscriptions, dataset); err != nil {
+		return nil, err
+	}
+
+	log.Printf("⚠️  Using in-memory storage seeded with the %s profile, data is lost on restart", profile.Name)
+	return s, nil
+}
+
+// close releases the database connection, if any, waiting for MongoDB
+// operations in progress until ctx is done
+func (s *storage) close(ctx context.Context) error {
+	switch {
+	case s.client != nil:
+		return database.Disconnect(ctx, s.client)
+	case s.sqlDB != nil:
+		return s.sqlDB.Close()
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,55 @@
+package entities
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrInvalidID is returned when parsing a malformed ID
+var ErrInvalidID = errors.New("invalid ID")
+
+// ID identifies an entity independently of the storage backend. IDs are 24
+// lowercase hexadecimal characters, the format of MongoDB ObjectIDs, so
+// identifiers created by any backend are valid on every other one.
+// The zero value means the ID is not assigned yet.
+type ID string
+
+// NewID returns a new ID made of the current Unix time in seconds followed by
+// eight random bytes, so IDs sort roughly by creation time
+func NewID() ID {
+	return NewIDAt(time.Now(), rand.Reader.Read)
+}
+
+// NewIDAt returns an ID with the given creation time, reading its random part
+// from read. Seed generators use it to build reproducible IDs.
+func NewIDAt(created time.Time, read func([]byte) (int, error)) ID {
+	var raw [12]byte
+	binary.BigEndian.PutUint32(raw[0:4], uint32(created.Unix()))
+	if _, err := read(raw[4:]); err != nil {
+		panic(fmt.Sprintf("entities: cannot read random bytes: %v", err))
+	}
+	return ID(hex.EncodeToString(raw[:]))
+}
+
+// ParseID validates an ID from user input
+func ParseID(value string) (ID, error) {
+	raw, err := hex.DecodeString(value)
+	if err != nil || len(raw) != 12 {
+		return "", fmt.Errorf("%w %q: expected 24 hexadecimal characters", ErrInvalidID, value)
+	}
+	return ID(hex.EncodeToString(raw)), nil
+}
+
+// IsZero returns true if the ID is not assigned
+func (id ID) IsZero() bool {
+	return id == ""
+}
+
+// String returns the hexadecimal form of the ID
+func (id ID) String() string {
+	return string(id)
+}
@@ -1,21 +1,17 @@
 package entities
 
-import (
-	"time"
-
-	"go.mongodb.org/mongo-driver/bson/primitive"
-)
+import "time"
 
 type Product struct {
-	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id"`
-	Name        string             `bson:"name" json:"name"`
-	Description string             `bson:"description" json:"description"`
-	Price       float64            `bson:"price" json:"price"`
-	BillingType string             `bson:"billing_type" json:"billing_type"`
-	Category    string             `bson:"category" json:"category"`
-	Status      string             `bson:"status" json:"status"`
-	CreatedAt   time.Time          `bson:"created_at" json:"created_at"`
-	UpdatedAt   time.Time          `bson:"updated_at" json:"updated_at"`
+	ID          ID        `bson:"_id,omitempty" json:"id"`
+	Name        string    `bson:"name" json:"name"`
+	Description string    `bson:"description" json:"description"`
+	Price       float64   `bson:"price" json:"price"`
+	BillingType string    `bson:"billing_type" json:"billing_type"`
+	Category    string    `bson:"category" json:"category"`
+	Status      string    `bson:"status" json:"status"`
+	CreatedAt   time.Time `bson:"created_at" json:"created_at"`
+	UpdatedAt   time.Time `bson:"updated_at" json:"updated_at"`
 }
 
 // IsActive returns true if the product is active
@@ -33,6 +29,11 @@ func (p *Product) IsYearly() bool {
 	return p.BillingType == "yearly"
 }
 
+// IsWeekly returns true if the product has weekly billing
+func (p *Product) IsWeekly() bool {
+	return p.BillingType == "weekly"
+}
+
 // ValidatePrice validates the product price
 func (p *Product) ValidatePrice() bool {
 	return p.Price > 0
@@ -46,5 +47,8 @@ func (p *Product) GetMonthlyPrice() float64 {
 	if p.IsYearly() {
 		return p.Price / 12
 	}
+	if p.IsWeekly() {
+		return p.Price * 52 / 12
+	}
 	return p.Price // Default to current price
 }
@@ -1,36 +1,35 @@
 package entities
 
-import (
-	"time"
-
-	"go.mongodb.org/mongo-driver/bson/primitive"
-)
+import "time"
 
 type Subscription struct {
-	ID           primitive.ObjectID `bson:"_id,omitempty" json:"id"`
-	UserID       primitive.ObjectID `bson:"user_id" json:"user_id"`
-	ProductID    primitive.ObjectID `bson:"product_id" json:"product_id"`
-	Status       string             `bson:"status" json:"status"`
-	StartDate    time.Time          `bson:"start_date" json:"start_date"`
-	EndDate      *time.Time         `bson:"end_date,omitempty" json:"end_date,omitempty"`
-	NextBilling  time.Time          `bson:"next_billing" json:"next_billing"`
-	PriceAtStart float64            `bson:"price_at_start" json:"price_at_start"`
-	CreatedAt    time.Time          `bson:"created_at" json:"created_at"`
-	UpdatedAt    time.Time          `bson:"updated_at" json:"updated_at"`
+	ID           ID         `bson:"_id,omitempty" json:"id"`
+	UserID       ID         `bson:"user_id" json:"user_id"`
+	ProductID    ID         `bson:"product_id" json:"product_id"`
+	Status       string     `bson:"status" json:"status"`
+	StartDate    time.Time  `bson:"start_date" json:"start_date"`
+	EndDate      *time.Time `bson:"end_date,omitempty" json:"end_date,omitempty"`
+	NextBilling  time.Time  `bson:"next_billing" json:"next_billing"`
+	PriceAtStart float64    `bson:"price_at_start" json:"price_at_start"`
+	CreatedAt    time.Time  `bson:"created_at" json:"created_at"`
+	UpdatedAt    time.Time  `bson:"updated_at" json:"updated_at"`
 }
 
 type SubscriptionWithProduct struct {
-	ID          primitive.ObjectID `json:"id"`
-	UserID      primitive.ObjectID `json:"user_id"`
-	ProductID   primitive.ObjectID `json:"product_id"`
-	ProductName string             `json:"product_name"`
-	Description string             `json:"description"`
-	Price       float64            `json:"price"`
-	Status      string             `json:"status"`
-	StartDate   time.Time          `json:"start_date"`
-	EndDate     *time.Time         `json:"end_date,omitempty"`
-	NextBilling time.Time          `json:"next_billing"`
-	CreatedAt   time.Time          `json:"created_at"`
+	ID           ID         `bson:"id" json:"id"`
+	UserID       ID         `bson:"user_id" json:"user_id"`
+	ProductID    ID         `bson:"product_id" json:"product_id"`
+	ProductName  string     `bson:"product_name" json:"product_name"`
+	Description  string     `bson:"description" json:"description"`
+	Price        float64    `bson:"price" json:"price"`
+	BillingType  string     `bson:"billing_type" json:"billing_type"`
+	Category     string     `bson:"category" json:"category"`
+	PriceAtStart float64    `bson:"price_at_start" json:"price_at_start"`
+	Status       string     `bson:"status" json:"status"`
+	StartDate    time.Time  `bson:"start_date" json:"start_date"`
+	EndDate      *time.Time `bson:"end_date,omitempty" json:"end_date,omitempty"`
+	NextBilling  time.Time  `bson:"next_billing" json:"next_billing"`
+	CreatedAt    time.Time  `bson:"created_at" json:"created_at"`
 }
 
 // IsActive returns true if the subscription is active
@@ -43,6 +42,11 @@ func (s *Subscription) IsCancelled() bool {
 	return s.Status == "cancelled"
 }
 
+// IsPaused returns true if the subscription is paused
+func (s *Subscription) IsPaused() bool {
+	return s.Status == "paused"
+}
+
 // IsExpired returns true if the subscription is expired
 func (s *Subscription) IsExpired() bool {
 	return s.Status == "expired" || (s.EndDate != nil && s.EndDate.Before(time.Now()))
@@ -56,17 +60,147 @@ func (s *Subscription) Cancel() {
 	s.UpdatedAt = now
 }
 
-// Renew renews the subscription for another billing cycle
-func (s *Subscription) Renew() {
+// Pause suspends billing of the subscription without ending it
+func (s *Subscription) Pause() {
+	s.Status = "paused"
+	s.UpdatedAt = time.Now()
+}
+
+// Renew renews the subscription for another billing cycle of the given billing type
+func (s *Subscription) Renew(billingType string) {
 	if s.IsActive() {
-		// Add one month to next billing (assuming monthly for simplicity)
-		s.NextBilling = s.NextBilling.AddDate(0, 1, 0)
+		s.NextBilling = NextBillingDate(s.NextBilling, billingType)
 		s.UpdatedAt = time.Now()
 	}
 }
 
+// NextBillingDate returns the billing date following current for the given
+// billing type. Unknown billing types are treated as monthly.
+func NextBillingDate(current time.Time, billingType string) time.Time {
+	switch billingType {
+	case "yearly":
+		return current.AddDate(1, 0, 0)
+	case "weekly":
+		return current.AddDate(0, 0, 7)
+	default:
+		return current.AddDate(0, 1, 0)
+	}
+}
+
+// BillingDateOnOrAfter returns the first billing date of the cycle through
+// current that is not before t, the date repeated NextBillingDate calls would
+// reach, without stepping through every cycle in between
+func BillingDateOnOrAfter(current time.Time, billingType string, t time.Time) time.Time {
+	// Month ends and February 29 roll over into the next month, so step one
+	// cycle at a time until the day of the month settles
+	for current.Before(t) && !StableBillingDay(current, billingType) {
+		current = NextBillingDate(current, billingType)
+	}
+	if !current.Before(t) {
+		return current
+	}
+
+	// From here on n cycles add the same calendar step as one call adding n
+	// of them. Skip one cycle less than the estimate and walk the rest.
+	var cycles int
+	switch billingType {
+	case "yearly":
+		cycles = t.Year() - current.Year() - 1
+	case "weekly":
+		cycles = int(t.Sub(current).Hours()/(24*7)) - 1
+	default:
+		cycles = (t.Year()-current.Year())*12 + int(t.Month()-current.Month()) - 1
+	}
+	if cycles > 0 {
+		switch billingType {
+		case "yearly":
+			current = current.AddDate(cycles, 0, 0)
+		case "weekly":
+			current = current.AddDate(0, 0, 7*cycles)
+		default:
+			current = current.AddDate(0, cycles, 0)
+		}
+	}
+	for current.Before(t) {
+		current = NextBillingDate(current, billingType)
+	}
+	return current
+}
+
+// StableBillingDay returns true if NextBillingDate keeps the day of the month
+// of current from now on, so the billing dates follow a plain calendar
+// recurrence
+func StableBillingDay(current time.Time, billingType string) bool {
+	switch billingType {
+	case "yearly":
+		return current.Month() != time.February || current.Day() != 29
+	case "weekly":
+		return true
+	default:
+		return current.Day() <= 28
+	}
+}
+
 // DaysUntilNextBilling returns the number of days until the next billing
 func (s *Subscription) DaysUntilNextBilling() int {
 	duration := time.Until(s.NextBilling)
 	return int(duration.Hours() / 24)
 }
+
+// IsActiveAt returns true if the subscription was running and billing at the
+// given time. Paused subscriptions, and expired ones without an end date, do
+// not record when billing stopped, so they are never counted.
+func (s *SubscriptionWithProduct) IsActiveAt(t time.Time) bool {
+	switch {
+	case s.StartDate.After(t):
+		return false
+	case s.Status == "paused":
+		return false
+	case s.Status == "expired" && s.EndDate == nil:
+		return false
+	}
+	return s.EndDate == nil || s.EndDate.After(t)
+}
+
+// BillsOn returns true if a billing date comes before the end date, if any.
+// The end date keeps its time of day, so a subscription cancelled in the
+// afternoon is still billed at a billing date earlier that day.
+func (s *SubscriptionWithProduct) BillsOn(date time.Time) bool {
+	return s.EndDate == nil || date.Before(*s.EndDate)
+}
+
+// LastBillingDay returns the day, in the location of NextBilling, of the last
+// billing date BillsOn accepts, or false without end date. Billing dates keep
+// the time of day of NextBilling.
+func (s *SubscriptionWithProduct) LastBillingDay() (time.Time, bool) {
+	if s.EndDate == nil {
+		return time.Time{}, false
+	}
+	end := s.EndDate.In(s.NextBilling.Location())
+	day := time.Date(end.Year(), end.Month(), end.Day(),
+		s.NextBilling.Hour(), s.NextBilling.Minute(), s.NextBilling.Second(), s.NextBilling.Nanosecond(), s.NextBilling.Location())
+	if !s.BillsOn(day) {
+		day = day.AddDate(0, 0, -1)
+	}
+	return day, true
+}
+
+// ChargedPrice returns the price the subscriber pays per billing cycle
+func (s *SubscriptionWithProduct) ChargedPrice() float64 {
+	if s.PriceAtStart > 0 {
+		return s.PriceAtStart
+	}
+	return s.Price
+}
+
+// MonthlyAmount returns the monthly equivalent of the charged price
+func (s *SubscriptionWithProduct) MonthlyAmount() float64 {
+	switch s.BillingType {
+	case "yearly":
+		return s.ChargedPrice() / 12
+	case "weekly":
+		return s.ChargedPrice() * 52 / 12
+	default:
+		return s.ChargedPrice()
+	}
+}
@@ -0,0 +1,11 @@
+package entities
+
+import "time"
+
+// BankTransaction is a single line of an uploaded bank statement.
+// Debits have a negative amount.
+type BankTransaction struct {
+	Date        time.Time `json:"date"`
+	Description string    `json:"description"`
+	Amount      float64   `json:"amount"`
+}
@@ -0,0 +1,14 @@
+package repositories
+
+import "errors"
+
+// ErrDuplicate is returned when a write violates a uniqueness constraint,
+// such as a second user with the same email
+var ErrDuplicate = errors.New("duplicate value of a unique field")
+
+// ErrInvalid is returned when the storage rejects a write its schema does not
+// allow, such as a field longer than its column
+var ErrInvalid = errors.New("value rejected by the storage schema")
+
+// ErrNotFound is returned when no entity has the requested ID or key
+var ErrNotFound = errors.New("not found")
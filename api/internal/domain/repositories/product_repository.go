@@ -4,18 +4,30 @@ import (
 	"context"
 
 	"github.com/frtasoniero/subsmanager/internal/domain/entities"
-	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // ProductRepository defines the interface for product data operations
 type ProductRepository interface {
 	Create(ctx context.Context, product *entities.Product) error
-	GetByID(ctx context.Context, id primitive.ObjectID) (*entities.Product, error)
+	GetByID(ctx context.Context, id entities.ID) (*entities.Product, error)
+	// GetByIDs returns the products with the given IDs in no particular
+	// order, leaving out the IDs no product has
+	GetByIDs(ctx context.Context, ids []entities.ID) ([]*entities.Product, error)
 	GetByName(ctx context.Context, name string) (*entities.Product, error)
 	GetByCategory(ctx context.Context, category string) ([]*entities.Product, error)
 	GetAll(ctx context.Context) ([]*entities.Product, error)
 	GetActive(ctx context.Context) ([]*entities.Product, error)
+	// Search returns up to limit products whose name, category or description
+	// match the words of text, most relevant first
+	Search(ctx context.Context, text string, limit int) ([]*ProductMatch, error)
 	Update(ctx context.Context, product *entities.Product) error
-	Delete(ctx context.Context, id primitive.ObjectID) error
+	Delete(ctx context.Context, id entities.ID) error
 	Count(ctx context.Context) (int64, error)
 }
+
+// ProductMatch is a product found by a search. Scores rank the matches of
+// one search and are not comparable across backends.
+type ProductMatch struct {
+	Product *entities.Product
+	Score   float64
+}
@@ -0,0 +1,30 @@
+package repotest
+
+import (
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fixedNow is the reference time of the fixtures, truncated to the millisecond
+// precision of BSON dates so every backend can store it exactly
+func fixedNow() time.Time {
+	return time.Date(2025, 3, 15, 12, 30, 0, 0, time.UTC)
+}
+
+// sameTime compares instants regardless of location
+func sameTime(a, b time.Time) bool {
+	return a.Truncate(time.Millisecond).Equal(b.Truncate(time.Millisecond))
+}
+
+// expectNames compares names regardless of order
+func expectNames(t *testing.T, action string, got []string, want ...string) {
+	t.Helper()
+	sorted := append([]string(nil), got...)
+	sort.Strings(sorted)
+	sort.Strings(want)
+	if strings.Join(sorted, ",") != strings.Join(want, ",") {
+		t.Errorf("%s returned %v, want %v", action, sorted, want)
+	}
+}
@@ -0,0 +1,166 @@
+package repotest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/frtasoniero/subsmanager/internal/domain/entities"
+	"github.com/frtasoniero/subsmanager/internal/domain/repositories"
+)
+
+func newProduct(name, category, status string) *entities.Product {
+	now := fixedNow()
+	return &entities.Product{
+		Name:        name,
+		Description: name + " description",
+		Price:       9.99,
+		BillingType: "monthly",
+		Category:    category,
+		Status:      status,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+func productChecks() []check {
+	return []check{
+		{"products/create and get by ID and name", func(t *testing.T, r Repositories) {
+			product := newProduct("Netflix", "streaming", "active")
+			noError(t, r.Products.Create(t.Context(), product), "Create")
+			if product.ID.IsZero() {
+				t.Fatalf("Create did not set the ID")
+			}
+
+			byID, err := r.Products.GetByID(t.Context(), product.ID)
+			noError(t, err, "GetByID")
+			if byID.Name != "Netflix" || byID.Price != 9.99 || byID.BillingType != "monthly" || byID.Category != "streaming" {
+				t.Errorf("GetByID returned %+v, want %+v", byID, product)
+			}
+
+			byName, err := r.Products.GetByName(t.Context(), "Netflix")
+			noError(t, err, "GetByName")
+			if byName.ID != product.ID {
+				t.Errorf("GetByName returned product %s, want %s", byName.ID.String(), product.ID.String())
+			}
+		}},
+		{"products/missing products are not found", func(t *testing.T, r Repositories) {
+			_, err := r.Products.GetByID(t.Context(), entities.NewID())
+			errorIs(t, err, repositories.ErrNotFound, "GetByID")
+			_, err = r.Products.GetByName(t.Context(), "Nothing")
+			errorIs(t, err, repositories.ErrNotFound, "GetByName")
+
+			missing := newProduct("Ghost", "other", "active")
+			missing.ID = entities.NewID()
+			errorIs(t, r.Products.Update(t.Context(), missing), repositories.ErrNotFound, "Update")
+			errorIs(t, r.Products.Delete(t.Context(), missing.ID), repositories.ErrNotFound, "Delete")
+		}},
+		{"products/get by IDs skips missing IDs", func(t *testing.T, r Repositories) {
+			netflix := newProduct("Netflix", "streaming", "active")
+			spotify := newProduct("Spotify", "music", "active")
+			for _, product := range []*entities.Product{netflix, spotify, newProduct("Audible", "books", "active")} {
+				noError(t, r.Products.Create(t.Context(), product), "Create")
+			}
+
+			products, err := r.Products.GetByIDs(t.Context(), []entities.ID{spotify.ID, entities.NewID(), netflix.ID, netflix.ID})
+			noError(t, err, "GetByIDs")
+			expectNames(t, "GetByIDs", productNames(products), "Netflix", "Spotify")
+
+			none, err := r.Products.GetByIDs(t.Context(), nil)
+			noError(t, err, "GetByIDs without IDs")
+			expectNames(t, "GetByIDs without IDs", productNames(none))
+		}},
+		{"products/name is unique", func(t *testing.T, r Repositories) {
+			noError(t, r.Products.Create(t.Context(), newProduct("Netflix", "streaming", "active")), "Create")
+			errorIs(t, r.Products.Create(t.Context(), newProduct("Netflix", "other", "active")), repositories.ErrDuplicate, "Create with a taken name")
+		}},
+		{"products/category and active filters", func(t *testing.T, r Repositories) {
+			for _, product := range []*entities.Product{
+				newProduct("Netflix", "streaming", "active"),
+				newProduct("Disney+", "streaming", "inactive"),
+				newProduct("Spotify", "music", "active"),
+			} {
+				noError(t, r.Products.Create(t.Context(), product), "Create")
+			}
+
+			streaming, err := r.Products.GetByCategory(t.Context(), "streaming")
+			noError(t, err, "GetByCategory")
+			expectNames(t, "GetByCategory", productNames(streaming), "Disney+", "Netflix")
+
+			active, err := r.Products.GetActive(t.Context())
+			noError(t, err, "GetActive")
+			expectNames(t, "GetActive", productNames(active), "Netflix", "Spotify")
+
+			all, err := r.Products.GetAll(t.Context())
+			noError(t, err, "GetAll")
+			expectNames(t, "GetAll", productNames(all), "Disney+", "Netflix", "Spotify")
+			expectCount(t, r.Products.Count, 3)
+		}},
+		{"products/search ranks name, category then description matches", func(t *testing.T, r Repositories) {
+			netflix := newProduct("Netflix", "streaming", "active")
+			netflix.Description = "Films and music documentaries"
+			for _, product := range []*entities.Product{
+				netflix,
+				newProduct("Spotify", "music", "active"),
+				newProduct("Music Box", "video", "active"),
+				newProduct("Gym", "fitness", "active"),
+			} {
+				noError(t, r.Products.Create(t.Context(), product), "Create")
+			}
+
+			matches, err := r.Products.Search(t.Context(), "music", 10)
+			noError(t, err, "Search")
+			if got := strings.Join(matchNames(matches), ","); got != "Music Box,Spotify,Netflix" {
+				t.Errorf("Search ranked %s, want Music Box,Spotify,Netflix", got)
+			}
+			for i := 1; i < len(matches); i++ {
+				if matches[i].Score > matches[i-1].Score {
+					t.Errorf("Search scores are not decreasing: %v then %v", matches[i-1].Score, matches[i].Score)
+				}
+			}
+
+			limited, err := r.Products.Search(t.Context(), "music", 2)
+			noError(t, err, "Search with a limit")
+			if got := strings.Join(matchNames(limited), ","); got != "Music Box,Spotify" {
+				t.Errorf("Search with a limit ranked %s, want Music Box,Spotify", got)
+			}
+
+			none, err := r.Products.Search(t.Context(), "podcast", 10)
+			noError(t, err, "Search without matches")
+			expectNames(t, "Search without matches", matchNames(none))
+		}},
+		{"products/update and delete", func(t *testing.T, r Repositories) {
+			product := newProduct("Netflix", "streaming", "active")
+			noError(t, r.Products.Create(t.Context(), product), "Create")
+
+			product.Price = 17.99
+			product.Status = "inactive"
+			noError(t, r.Products.Update(t.Context(), product), "Update")
+			updated, err := r.Products.GetByID(t.Context(), product.ID)
+			noError(t, err, "GetByID")
+			if updated.Price != 17.99 || updated.Status != "inactive" {
+				t.Errorf("Update did not persist, got price %v and status %q", updated.Price, updated.Status)
+			}
+
+			noError(t, r.Products.Delete(t.Context(), product.ID), "Delete")
+			_, err = r.Products.GetByID(t.Context(), product.ID)
+			errorIs(t, err, repositories.ErrNotFound, "GetByID after Delete")
+			expectCount(t, r.Products.Count, 0)
+		}},
+	}
+}
+
+func productNames(products []*entities.Product) []string {
+	names := make([]string, 0, len(products))
+	for _, product := range products {
+		names = append(names, product.Name)
+	}
+	return names
+}
+
+func matchNames(matches []*repositories.ProductMatch) []string {
+	names := make([]string, 0, len(matches))
+	for _, match := range matches {
+		names = append(names, match.Product.Name)
+	}
+	return names
+}
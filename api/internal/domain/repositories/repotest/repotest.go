@@ -0,0 +1,66 @@
+// Package repotest is a contract suite for implementations of the interfaces
+// in the repositories package, in the spirit of testing/fstest: every backend
+// runs the same checks, so they all behave like the MongoDB one.
+//
+// The tests of each backend call Run with their own Backend; see
+// internal/infrastructure/database/repositories/contract_test.go.
+package repotest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/frtasoniero/subsmanager/internal/domain/repositories"
+)
+
+// Repositories are the repositories of one backend, sharing their storage
+type Repositories struct {
+	Users         repositories.UserRepository
+	Products      repositories.ProductRepository
+	Subscriptions repositories.SubscriptionRepository
+}
+
+// Backend returns repositories over empty storage, releasing it with
+// t.Cleanup. It is called once per check, so checks never see each other's
+// data, and fails or skips t when the storage is not available.
+type Backend func(t *testing.T) Repositories
+
+// check is a single contract check
+type check struct {
+	name string
+	run  func(t *testing.T, r Repositories)
+}
+
+// Run runs every check against the backend as a subtest of t
+func Run(t *testing.T, backend Backend) {
+	for _, c := range checks() {
+		t.Run(c.name, func(t *testing.T) {
+			c.run(t, backend(t))
+		})
+	}
+}
+
+func checks() []check {
+	var all []check
+	all = append(all, userChecks()...)
+	all = append(all, productChecks()...)
+	all = append(all, subscriptionChecks()...)
+	all = append(all, subscriptionFindChecks()...)
+	return all
+}
+
+// noError stops the check when err is not nil
+func noError(t *testing.T, err error, action string) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("%s: unexpected error: %v", action, err)
+	}
+}
+
+// errorIs records a failure when err does not wrap target
+func errorIs(t *testing.T, err, target error, action string) {
+	t.Helper()
+	if !errors.Is(err, target) {
+		t.Errorf("%s: got error %v, want %v", action, err, target)
+	}
+}
@@ -0,0 +1,172 @@
+package repotest
+
+import (
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/frtasoniero/subsmanager/internal/domain/entities"
+	"github.com/frtasoniero/subsmanager/internal/domain/repositories"
+)
+
+// findAll finds every subscription matching filter without a limit
+func findAll(t *testing.T, r Repositories, filter repositories.SubscriptionFilter) []string {
+	t.Helper()
+	subscriptions, err := r.Subscriptions.Find(t.Context(), repositories.SubscriptionQuery{Filter: filter})
+	noError(t, err, "Find")
+	ids := make([]string, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		ids = append(ids, subscription.ID.String())
+	}
+	return ids
+}
+
+// walkPages lists every page of query, following the key of the last
+// subscription of each page
+func walkPages(t *testing.T, r Repositories, query repositories.SubscriptionQuery) []entities.ID {
+	t.Helper()
+	var ids []entities.ID
+	for pages := 0; pages < 10; pages++ {
+		page, err := r.Subscriptions.Find(t.Context(), query)
+		noError(t, err, "Find")
+		if len(page) > query.Limit {
+			t.Fatalf("Find returned %d subscriptions, more than the limit of %d", len(page), query.Limit)
+		}
+		for _, subscription := range page {
+			ids = append(ids, subscription.ID)
+		}
+		if len(page) < query.Limit {
+			return ids
+		}
+		key := repositories.KeyOf(query.Sort, page[len(page)-1])
+		query.After = &key
+	}
+	t.Fatalf("Find kept returning full pages")
+	return nil
+}
+
+func subscriptionFindChecks() []check {
+	return []check{
+		{"subscriptions/find filters", func(t *testing.T, r Repositories) {
+			f := newFixture(t, r)
+			other := newUser("other")
+			gym := newProduct("Gym", "fitness", "active")
+			gym.Price = 30
+			noError(t, r.Users.Create(t.Context(), other), "Create user")
+			noError(t, r.Products.Create(t.Context(), gym), "Create product")
+
+			now := fixedNow()
+			create := func(user *entities.User, product *entities.Product, status string, days int) string {
+				subscription := f.subscription(status, now.AddDate(0, 0, days))
+				subscription.UserID = user.ID
+				subscription.ProductID = product.ID
+				subscription.StartDate = now.AddDate(0, 0, -days)
+				noError(t, r.Subscriptions.Create(t.Context(), subscription), "Create")
+				return subscription.ID.String()
+			}
+			netflix := f.product
+			first := create(f.user, netflix, "active", 1)
+			second := create(f.user, gym, "cancelled", 10)
+			third := create(other, netflix, "active", 20)
+			orphan := f.subscription("active", now)
+			orphan.ProductID = entities.NewID()
+			noError(t, r.Subscriptions.Create(t.Context(), orphan), "Create")
+
+			price := func(p float64) *float64 { return &p }
+			day := func(days int) *time.Time {
+				date := now.AddDate(0, 0, days)
+				return &date
+			}
+			cases := []struct {
+				name   string
+				filter repositories.SubscriptionFilter
+				want   []string
+			}{
+				{"no filter", repositories.SubscriptionFilter{}, []string{first, second, third}},
+				{"user", repositories.SubscriptionFilter{UserID: &f.user.ID}, []string{first, second}},
+				{"users", repositories.SubscriptionFilter{UserIDs: []entities.ID{other.ID}}, []string{third}},
+				{"any of the users", repositories.SubscriptionFilter{UserIDs: []entities.ID{other.ID, f.user.ID}}, []string{first, second, third}},
+				{"user among the users", repositories.SubscriptionFilter{UserID: &f.user.ID, UserIDs: []entities.ID{other.ID}}, nil},
+				{"product", repositories.SubscriptionFilter{ProductID: &gym.ID}, []string{second}},
+				{"status", repositories.SubscriptionFilter{Status: "active"}, []string{first, third}},
+				{"category", repositories.SubscriptionFilter{Category: "streaming"}, []string{first, third}},
+				{"min price", repositories.SubscriptionFilter{MinPrice: price(10)}, []string{second}},
+				{"inclusive price range", repositories.SubscriptionFilter{MinPrice: price(9.99), MaxPrice: price(9.99)}, []string{first, third}},
+				{"inclusive next billing start", repositories.SubscriptionFilter{NextBilling: repositories.DateRange{From: day(1)}}, []string{first, second, third}},
+				{"exclusive next billing end", repositories.SubscriptionFilter{NextBilling: repositories.DateRange{To: day(10)}}, []string{first}},
+				{"inclusive next billing end", repositories.SubscriptionFilter{NextBilling: repositories.DateRange{To: day(10), ToInclusive: true}}, []string{first, second}},
+				{"next billing range", repositories.SubscriptionFilter{NextBilling: repositories.DateRange{From: day(2), To: day(21)}}, []string{second, third}},
+				{"start date range", repositories.SubscriptionFilter{StartDate: repositories.DateRange{From: day(-10), To: day(-1)}}, []string{second}},
+				{"exclusive created at end", repositories.SubscriptionFilter{CreatedAt: repositories.DateRange{To: day(0)}}, nil},
+				{"inclusive created at end", repositories.SubscriptionFilter{CreatedAt: repositories.DateRange{To: day(0), ToInclusive: true}}, []string{first, second, third}},
+				{"combined", repositories.SubscriptionFilter{UserID: &f.user.ID, Status: "active", Category: "streaming"}, []string{first}},
+			}
+			for _, c := range cases {
+				expectNames(t, "Find with "+c.name+" filter", findAll(t, r, c.filter), c.want...)
+			}
+		}},
+		{"subscriptions/find pages in keyset order", func(t *testing.T, r Repositories) {
+			f := newFixture(t, r)
+			gym := newProduct("Gym", "fitness", "active")
+			gym.Price = 30
+			noError(t, r.Products.Create(t.Context(), gym), "Create product")
+
+			// Ties on every sort field, so pages must break them by ID
+			now := fixedNow()
+			created := map[entities.ID]*entities.SubscriptionWithProduct{}
+			for i, days := range []int{5, 1, 1, 3, 1} {
+				subscription := f.subscription("active", now.AddDate(0, 0, days))
+				product := f.product
+				if i%2 == 1 {
+					product = gym
+				}
+				subscription.ProductID = product.ID
+				noError(t, r.Subscriptions.Create(t.Context(), subscription), "Create")
+				created[subscription.ID] = &entities.SubscriptionWithProduct{
+					ID: subscription.ID, Price: product.Price, NextBilling: subscription.NextBilling, CreatedAt: subscription.CreatedAt,
+				}
+			}
+
+			for _, field := range repositories.SortFields {
+				for _, descending := range []bool{false, true} {
+					want := make([]*entities.SubscriptionWithProduct, 0, len(created))
+					for _, subscription := range created {
+						want = append(want, subscription)
+					}
+					sort.Slice(want, func(i, j int) bool {
+						return less(repositories.KeyOf(field, want[i]), repositories.KeyOf(field, want[j])) != descending
+					})
+					wantIDs := make([]string, 0, len(want))
+					for _, subscription := range want {
+						wantIDs = append(wantIDs, subscription.ID.String())
+					}
+
+					query := repositories.SubscriptionQuery{Sort: field, Descending: descending, Limit: 2}
+					var got []string
+					for _, id := range walkPages(t, r, query) {
+						got = append(got, id.String())
+					}
+					if strings.Join(got, ",") != strings.Join(wantIDs, ",") {
+						t.Errorf("Find by %s (descending %t) returned %v, want %v", field, descending, got, wantIDs)
+					}
+				}
+			}
+		}},
+	}
+}
+
+// less orders two keys by value, then by ID
+func less(a, b repositories.SubscriptionKey) bool {
+	switch value := a.Value.(type) {
+	case time.Time:
+		if other := b.Value.(time.Time); !value.Equal(other) {
+			return value.Before(other)
+		}
+	case float64:
+		if other := b.Value.(float64); value != other {
+			return value < other
+		}
+	}
+	return a.ID.String() < b.ID.String()
+}
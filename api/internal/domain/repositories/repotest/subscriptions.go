@@ -0,0 +1,238 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/frtasoniero/subsmanager/internal/domain/entities"
+	"github.com/frtasoniero/subsmanager/internal/domain/repositories"
+)
+
+// subscriptionFixture is a user and a product to subscribe to
+type subscriptionFixture struct {
+	user    *entities.User
+	product *entities.Product
+}
+
+func newFixture(t *testing.T, r Repositories) subscriptionFixture {
+	t.Helper()
+	f := subscriptionFixture{
+		user:    newUser("subscriber"),
+		product: newProduct("Netflix", "streaming", "active"),
+	}
+	noError(t, r.Users.Create(t.Context(), f.user), "Create user")
+	noError(t, r.Products.Create(t.Context(), f.product), "Create product")
+	return f
+}
+
+func (f subscriptionFixture) subscription(status string, nextBilling time.Time) *entities.Subscription {
+	now := fixedNow()
+	return &entities.Subscription{
+		UserID:       f.user.ID,
+		ProductID:    f.product.ID,
+		Status:       status,
+		StartDate:    now.AddDate(0, -1, 0),
+		NextBilling:  nextBilling,
+		PriceAtStart: 7.99,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+}
+
+func subscriptionChecks() []check {
+	return []check{
+		{"subscriptions/create and get by ID", func(t *testing.T, r Repositories) {
+			f := newFixture(t, r)
+			subscription := f.subscription("active", fixedNow().AddDate(0, 1, 0))
+			end := fixedNow().AddDate(0, 2, 0)
+			subscription.EndDate = &end
+			noError(t, r.Subscriptions.Create(t.Context(), subscription), "Create")
+			if subscription.ID.IsZero() {
+				t.Fatalf("Create did not set the ID")
+			}
+
+			stored, err := r.Subscriptions.GetByID(t.Context(), subscription.ID)
+			noError(t, err, "GetByID")
+			if stored.UserID != f.user.ID || stored.ProductID != f.product.ID || stored.Status != "active" || stored.PriceAtStart != 7.99 {
+				t.Errorf("GetByID returned %+v, want %+v", stored, subscription)
+			}
+			if !sameTime(stored.StartDate, subscription.StartDate) || !sameTime(stored.NextBilling, subscription.NextBilling) {
+				t.Errorf("GetByID returned dates %v and %v, want %v and %v", stored.StartDate, stored.NextBilling, subscription.StartDate, subscription.NextBilling)
+			}
+			if stored.EndDate == nil || !sameTime(*stored.EndDate, end) {
+				t.Errorf("GetByID returned end date %v, want %v", stored.EndDate, end)
+			}
+		}},
+		{"subscriptions/missing subscriptions are not found", func(t *testing.T, r Repositories) {
+			_, err := r.Subscriptions.GetByID(t.Context(), entities.NewID())
+			errorIs(t, err, repositories.ErrNotFound, "GetByID")
+
+			f := newFixture(t, r)
+			missing := f.subscription("active", fixedNow())
+			missing.ID = entities.NewID()
+			errorIs(t, r.Subscriptions.Update(t.Context(), missing), repositories.ErrNotFound, "Update")
+			errorIs(t, r.Subscriptions.Delete(t.Context(), missing.ID), repositories.ErrNotFound, "Delete")
+		}},
+		{"subscriptions/join with the product", func(t *testing.T, r Repositories) {
+			f := newFixture(t, r)
+			subscription := f.subscription("active", fixedNow().AddDate(0, 1, 0))
+			noError(t, r.Subscriptions.Create(t.Context(), subscription), "Create")
+
+			joined, err := r.Subscriptions.GetByUserID(t.Context(), f.user.ID)
+			noError(t, err, "GetByUserID")
+			if len(joined) != 1 {
+				t.Fatalf("GetByUserID returned %d subscriptions, want 1", len(joined))
+			}
+			got := joined[0]
+			if got.ID != subscription.ID || got.UserID != f.user.ID || got.ProductID != f.product.ID {
+				t.Errorf("joined IDs are %s/%s/%s, want %s/%s/%s", got.ID.String(), got.UserID.String(), got.ProductID.String(),
+					subscription.ID.String(), f.user.ID.String(), f.product.ID.String())
+			}
+			if got.ProductName != f.product.Name || got.Description != f.product.Description || got.Price != f.product.Price ||
+				got.BillingType != f.product.BillingType || got.Category != f.product.Category {
+				t.Errorf("joined product fields are %+v, want those of %+v", got, f.product)
+			}
+			if got.PriceAtStart != 7.99 || got.Status != "active" || !sameTime(got.NextBilling, subscription.NextBilling) {
+				t.Errorf("joined subscription fields are %+v, want those of %+v", got, subscription)
+			}
+
+			other, err := r.Subscriptions.GetByUserID(t.Context(), entities.NewID())
+			noError(t, err, "GetByUserID")
+			if len(other) != 0 {
+				t.Errorf("GetByUserID of another user returned %d subscriptions, want 0", len(other))
+			}
+		}},
+		{"subscriptions/join leaves out missing products", func(t *testing.T, r Repositories) {
+			f := newFixture(t, r)
+			noError(t, r.Subscriptions.Create(t.Context(), f.subscription("active", fixedNow())), "Create")
+			orphan := f.subscription("active", fixedNow())
+			orphan.ProductID = entities.NewID()
+			noError(t, r.Subscriptions.Create(t.Context(), orphan), "Create")
+
+			all, err := r.Subscriptions.GetAll(t.Context())
+			noError(t, err, "GetAll")
+			if len(all) != 1 {
+				t.Errorf("GetAll returned %d subscriptions, want 1 without the orphan", len(all))
+			}
+			expectCount(t, r.Subscriptions.Count, 2)
+
+			byProduct, err := r.Subscriptions.GetByProductID(t.Context(), orphan.ProductID)
+			noError(t, err, "GetByProductID")
+			if len(byProduct) != 1 || byProduct[0].ID != orphan.ID {
+				t.Errorf("GetByProductID returned %d subscriptions, want the orphan", len(byProduct))
+			}
+		}},
+		{"subscriptions/active filter", func(t *testing.T, r Repositories) {
+			f := newFixture(t, r)
+			for _, status := range []string{"active", "cancelled", "expired", "active"} {
+				noError(t, r.Subscriptions.Create(t.Context(), f.subscription(status, fixedNow())), "Create")
+			}
+
+			active, err := r.Subscriptions.GetActive(t.Context())
+			noError(t, err, "GetActive")
+			if len(active) != 2 {
+				t.Errorf("GetActive returned %d subscriptions, want 2", len(active))
+			}
+			for _, subscription := range active {
+				if subscription.Status != "active" {
+					t.Errorf("GetActive returned a %s subscription", subscription.Status)
+				}
+			}
+		}},
+		{"subscriptions/expiring boundaries", func(t *testing.T, r Repositories) {
+			f := newFixture(t, r)
+			now := time.Now()
+			cases := []struct {
+				status      string
+				nextBilling time.Time
+				expiring    bool
+			}{
+				{"active", now.AddDate(0, 0, -3), true},                  // overdue
+				{"active", now.Add(time.Hour), true},                     // today
+				{"active", now.AddDate(0, 0, 7).Add(-time.Minute), true}, // just inside the window
+				{"active", now.AddDate(0, 0, 7).Add(time.Minute), false}, // just outside the window
+				{"active", now.AddDate(0, 1, 0), false},
+				{"cancelled", now.Add(time.Hour), false},
+			}
+			want := map[entities.ID]bool{}
+			for _, c := range cases {
+				subscription := f.subscription(c.status, c.nextBilling)
+				noError(t, r.Subscriptions.Create(t.Context(), subscription), "Create")
+				if c.expiring {
+					want[subscription.ID] = true
+				}
+			}
+
+			expiring, err := r.Subscriptions.GetExpiring(t.Context(), 7)
+			noError(t, err, "GetExpiring")
+			if len(expiring) != len(want) {
+				t.Errorf("GetExpiring(7) returned %d subscriptions, want %d", len(expiring), len(want))
+			}
+			for _, subscription := range expiring {
+				if !want[subscription.ID] {
+					t.Errorf("GetExpiring(7) returned subscription with next billing %v", subscription.NextBilling)
+				}
+			}
+		}},
+		{"subscriptions/stream visits every joined subscription and stops on error", func(t *testing.T, r Repositories) {
+			f := newFixture(t, r)
+			for i := 0; i < 3; i++ {
+				noError(t, r.Subscriptions.Create(t.Context(), f.subscription("active", fixedNow())), "Create")
+			}
+
+			visited := 0
+			err := r.Subscriptions.Stream(t.Context(), func(s *entities.SubscriptionWithProduct) error {
+				if s.ProductName != f.product.Name {
+					t.Errorf("Stream returned product name %q, want %q", s.ProductName, f.product.Name)
+				}
+				visited++
+				return nil
+			})
+			noError(t, err, "Stream")
+			if visited != 3 {
+				t.Errorf("Stream visited %d subscriptions, want 3", visited)
+			}
+
+			stop := errors.New("stop")
+			visited = 0
+			err = r.Subscriptions.Stream(t.Context(), func(*entities.SubscriptionWithProduct) error {
+				visited++
+				return stop
+			})
+			errorIs(t, err, stop, "Stream returning an error")
+			if visited != 1 {
+				t.Errorf("Stream kept going after an error, visited %d", visited)
+			}
+		}},
+		{"subscriptions/update and delete", func(t *testing.T, r Repositories) {
+			f := newFixture(t, r)
+			subscription := f.subscription("active", fixedNow())
+			noError(t, r.Subscriptions.Create(t.Context(), subscription), "Create")
+
+			subscription.Cancel()
+			subscription.UpdatedAt = subscription.UpdatedAt.Truncate(time.Millisecond)
+			noError(t, r.Subscriptions.Update(t.Context(), subscription), "Update")
+			updated, err := r.Subscriptions.GetByID(t.Context(), subscription.ID)
+			noError(t, err, "GetByID")
+			if updated.Status != "cancelled" || updated.EndDate == nil {
+				t.Errorf("Update did not persist the cancellation, got %+v", updated)
+			}
+
+			noError(t, r.Subscriptions.Delete(t.Context(), subscription.ID), "Delete")
+			_, err = r.Subscriptions.GetByID(t.Context(), subscription.ID)
+			errorIs(t, err, repositories.ErrNotFound, "GetByID after Delete")
+			expectCount(t, r.Subscriptions.Count, 0)
+		}},
+	}
+}
+
+func expectCount(t *testing.T, count func(context.Context) (int64, error), want int64) {
+	t.Helper()
+	got, err := count(t.Context())
+	noError(t, err, "Count")
+	if got != want {
+		t.Errorf("Count returned %d, want %d", got, want)
+	}
+}
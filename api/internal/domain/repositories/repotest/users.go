@@ -0,0 +1,116 @@
+package repotest
+
+import (
+	"testing"
+
+	"github.com/frtasoniero/subsmanager/internal/domain/entities"
+	"github.com/frtasoniero/subsmanager/internal/domain/repositories"
+)
+
+func newUser(username string) *entities.User {
+	now := fixedNow()
+	return &entities.User{
+		Username:  username,
+		Email:     username + "@example.com",
+		Password:  "secret",
+		Status:    "active",
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+func userChecks() []check {
+	return []check{
+		{"users/create assigns an ID", func(t *testing.T, r Repositories) {
+			user := newUser("alice")
+			noError(t, r.Users.Create(t.Context(), user), "Create")
+			if user.ID.IsZero() {
+				t.Errorf("Create did not set the ID")
+			}
+		}},
+		{"users/get by ID, email and username", func(t *testing.T, r Repositories) {
+			user := newUser("alice")
+			noError(t, r.Users.Create(t.Context(), user), "Create")
+
+			byID, err := r.Users.GetByID(t.Context(), user.ID)
+			noError(t, err, "GetByID")
+			if byID.Username != "alice" || byID.Email != "alice@example.com" || !sameTime(byID.CreatedAt, user.CreatedAt) {
+				t.Errorf("GetByID returned %+v, want %+v", byID, user)
+			}
+
+			byEmail, err := r.Users.GetByEmail(t.Context(), "alice@example.com")
+			noError(t, err, "GetByEmail")
+			if byEmail.ID != user.ID {
+				t.Errorf("GetByEmail returned user %s, want %s", byEmail.ID.String(), user.ID.String())
+			}
+
+			byUsername, err := r.Users.GetByUsername(t.Context(), "alice")
+			noError(t, err, "GetByUsername")
+			if byUsername.ID != user.ID {
+				t.Errorf("GetByUsername returned user %s, want %s", byUsername.ID.String(), user.ID.String())
+			}
+		}},
+		{"users/any email the domain accepts is stored", func(t *testing.T, r Repositories) {
+			user := newUser("alice")
+			user.Email = "alice at example"
+			if !user.ValidateEmail() {
+				t.Fatalf("ValidateEmail rejects %q", user.Email)
+			}
+			noError(t, r.Users.Create(t.Context(), user), "Create")
+		}},
+		{"users/missing users are not found", func(t *testing.T, r Repositories) {
+			_, err := r.Users.GetByID(t.Context(), entities.NewID())
+			errorIs(t, err, repositories.ErrNotFound, "GetByID")
+			_, err = r.Users.GetByEmail(t.Context(), "nobody@example.com")
+			errorIs(t, err, repositories.ErrNotFound, "GetByEmail")
+			_, err = r.Users.GetByUsername(t.Context(), "nobody")
+			errorIs(t, err, repositories.ErrNotFound, "GetByUsername")
+
+			missing := newUser("ghost")
+			missing.ID = entities.NewID()
+			errorIs(t, r.Users.Update(t.Context(), missing), repositories.ErrNotFound, "Update")
+			errorIs(t, r.Users.Delete(t.Context(), missing.ID), repositories.ErrNotFound, "Delete")
+		}},
+		{"users/email and username are unique", func(t *testing.T, r Repositories) {
+			noError(t, r.Users.Create(t.Context(), newUser("alice")), "Create")
+
+			sameEmail := newUser("alice2")
+			sameEmail.Email = "alice@example.com"
+			errorIs(t, r.Users.Create(t.Context(), sameEmail), repositories.ErrDuplicate, "Create with a taken email")
+
+			sameUsername := newUser("alice")
+			sameUsername.Email = "other@example.com"
+			errorIs(t, r.Users.Create(t.Context(), sameUsername), repositories.ErrDuplicate, "Create with a taken username")
+
+			bob := newUser("bob")
+			noError(t, r.Users.Create(t.Context(), bob), "Create")
+			bob.Email = "alice@example.com"
+			errorIs(t, r.Users.Update(t.Context(), bob), repositories.ErrDuplicate, "Update to a taken email")
+		}},
+		{"users/update, list, count and delete", func(t *testing.T, r Repositories) {
+			alice, bob := newUser("alice"), newUser("bob")
+			noError(t, r.Users.Create(t.Context(), alice), "Create")
+			noError(t, r.Users.Create(t.Context(), bob), "Create")
+
+			alice.Status = "inactive"
+			noError(t, r.Users.Update(t.Context(), alice), "Update")
+			updated, err := r.Users.GetByID(t.Context(), alice.ID)
+			noError(t, err, "GetByID")
+			if updated.Status != "inactive" {
+				t.Errorf("Update did not persist the status, got %q", updated.Status)
+			}
+
+			all, err := r.Users.GetAll(t.Context())
+			noError(t, err, "GetAll")
+			if len(all) != 2 {
+				t.Errorf("GetAll returned %d users, want 2", len(all))
+			}
+			expectCount(t, r.Users.Count, 2)
+
+			noError(t, r.Users.Delete(t.Context(), bob.ID), "Delete")
+			_, err = r.Users.GetByID(t.Context(), bob.ID)
+			errorIs(t, err, repositories.ErrNotFound, "GetByID after Delete")
+			expectCount(t, r.Users.Count, 1)
+		}},
+	}
+}
@@ -0,0 +1,89 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/frtasoniero/subsmanager/internal/domain/entities"
+)
+
+// Sort fields of a SubscriptionQuery
+const (
+	SortNextBilling = "next_billing"
+	SortPrice       = "price"
+	SortCreatedAt   = "created_at"
+)
+
+// SortFields are the fields subscriptions can be sorted by
+var SortFields = []string{SortNextBilling, SortPrice, SortCreatedAt}
+
+// DateRange bounds a date. Nil bounds are open. From is inclusive and To
+// exclusive, unless ToInclusive is set.
+type DateRange struct {
+	From        *time.Time
+	To          *time.Time
+	ToInclusive bool
+}
+
+// Contains reports whether t is within the range
+func (r DateRange) Contains(t time.Time) bool {
+	switch {
+	case r.From != nil && t.Before(*r.From):
+		return false
+	case r.To != nil && r.ToInclusive:
+		return !t.After(*r.To)
+	case r.To != nil:
+		return t.Before(*r.To)
+	}
+	return true
+}
+
+// SubscriptionFilter narrows a subscription query. Zero fields match every
+// subscription. Category and prices are those of the product.
+type SubscriptionFilter struct {
+	UserID *entities.ID
+	// UserIDs matches the subscriptions of any of these users
+	UserIDs     []entities.ID
+	ProductID   *entities.ID
+	Status      string
+	Category    string
+	MinPrice    *float64
+	MaxPrice    *float64
+	NextBilling DateRange
+	StartDate   DateRange
+	CreatedAt   DateRange
+}
+
+// SubscriptionQuery selects subscriptions in keyset order: by the Sort
+// field, then by ID to break ties
+type SubscriptionQuery struct {
+	Filter SubscriptionFilter
+	// Sort is one of the Sort constants, SortCreatedAt when empty
+	Sort       string
+	Descending bool
+	// After is the position of the last subscription of the previous page,
+	// nil for the first page
+	After *SubscriptionKey
+	// Limit is the maximum number of subscriptions, zero for no limit
+	Limit int
+}
+
+// SubscriptionKey is the position of a subscription in a sort order
+type SubscriptionKey struct {
+	// Value is a time.Time for next_billing and created_at, a float64 for price
+	Value any
+	ID    entities.ID
+}
+
+// KeyOf returns the position of a subscription in the order of sort
+func KeyOf(sort string, subscription *entities.SubscriptionWithProduct) SubscriptionKey {
+	key := SubscriptionKey{ID: subscription.ID}
+	switch sort {
+	case SortNextBilling:
+		key.Value = subscription.NextBilling
+	case SortPrice:
+		key.Value = subscription.Price
+	default:
+		key.Value = subscription.CreatedAt
+	}
+	return key
+}
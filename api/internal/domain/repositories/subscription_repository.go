@@ -4,19 +4,26 @@ import (
 	"context"
 
 	"github.com/frtasoniero/subsmanager/internal/domain/entities"
-	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // SubscriptionRepository defines the interface for subscription data operations
 type SubscriptionRepository interface {
 	Create(ctx context.Context, subscription *entities.Subscription) error
-	GetByID(ctx context.Context, id primitive.ObjectID) (*entities.Subscription, error)
-	GetByUserID(ctx context.Context, userID primitive.ObjectID) ([]*entities.SubscriptionWithProduct, error)
-	GetByProductID(ctx context.Context, productID primitive.ObjectID) ([]*entities.Subscription, error)
+	GetByID(ctx context.Context, id entities.ID) (*entities.Subscription, error)
+	GetByUserID(ctx context.Context, userID entities.ID) ([]*entities.SubscriptionWithProduct, error)
+	GetByProductID(ctx context.Context, productID entities.ID) ([]*entities.Subscription, error)
 	GetAll(ctx context.Context) ([]*entities.SubscriptionWithProduct, error)
+	// Find returns the subscriptions matching query.Filter with their product,
+	// following query.After in the order of query.Sort. GetAll, GetByUserID,
+	// GetActive and GetExpiring are shorthands for it. Subscriptions whose
+	// product is gone are left out.
+	Find(ctx context.Context, query SubscriptionQuery) ([]*entities.SubscriptionWithProduct, error)
+	// Stream calls fn for every subscription without loading them all in memory.
+	// It stops at the first error returned by fn.
+	Stream(ctx context.Context, fn func(*entities.SubscriptionWithProduct) error) error
 	GetActive(ctx context.Context) ([]*entities.SubscriptionWithProduct, error)
 	GetExpiring(ctx context.Context, days int) ([]*entities.SubscriptionWithProduct, error)
 	Update(ctx context.Context, subscription *entities.Subscription) error
-	Delete(ctx context.Context, id primitive.ObjectID) error
+	Delete(ctx context.Context, id entities.ID) error
 	Count(ctx context.Context) (int64, error)
 }
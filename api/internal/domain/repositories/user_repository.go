@@ -4,17 +4,16 @@ import (
 	"context"
 
 	"github.com/frtasoniero/subsmanager/internal/domain/entities"
-	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // UserRepository defines the interface for user data operations
 type UserRepository interface {
 	Create(ctx context.Context, user *entities.User) error
-	GetByID(ctx context.Context, id primitive.ObjectID) (*entities.User, error)
+	GetByID(ctx context.Context, id entities.ID) (*entities.User, error)
 	GetByEmail(ctx context.Context, email string) (*entities.User, error)
 	GetByUsername(ctx context.Context, username string) (*entities.User, error)
 	GetAll(ctx context.Context) ([]*entities.User, error)
 	Update(ctx context.Context, user *entities.User) error
-	Delete(ctx context.Context, id primitive.ObjectID) error
+	Delete(ctx context.Context, id entities.ID) error
 	Count(ctx context.Context) (int64, error)
 }
@@ -0,0 +1,113 @@
+// Package cache keeps read-mostly data between requests. Cache decorates the
+// repositories and use cases that read such data and invalidates it when
+// they write.
+//
+// Entries are JSON-encoded and stored in a Backend, so the in-process LRU can
+// be swapped for a Redis-compatible store shared by several API instances.
+// Hits, misses and invalidations are published with expvar under "cache".
+//
+// Writes invalidate after they succeed. A read racing a write can still cache
+// the previous value, which then lives until its TTL ends.
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"expvar"
+	"log"
+	"time"
+)
+
+// Namespaces group entries invalidated together
+const (
+	NamespaceProducts = "products"
+	NamespaceSpending = "spending"
+)
+
+// Backend stores encoded entries. Implementations must be safe for
+// concurrent use.
+type Backend interface {
+	// Get returns the value of key, false when it is missing or expired
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	// Set stores value under key for ttl, zero meaning no expiry
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// DeletePrefix removes every key starting with prefix. A Redis-compatible
+	// backend can implement it with SCAN MATCH and DEL.
+	DeletePrefix(ctx context.Context, prefix string) error
+}
+
+// stats holds the counters of every namespace. They are published once up
+// front, so concurrent first hits never race to create them.
+var stats = publishStats(NamespaceProducts, NamespaceSpending)
+
+func publishStats(namespaces ...string) map[string]*expvar.Map {
+	published := expvar.NewMap("cache")
+	counters := make(map[string]*expvar.Map, len(namespaces))
+	for _, namespace := range namespaces {
+		counters[namespace] = new(expvar.Map).Init()
+		published.Set(namespace, counters[namespace])
+	}
+	return counters
+}
+
+// Cache reads through a Backend and counts its hits and misses
+type Cache struct {
+	backend Backend
+	ttl     time.Duration
+}
+
+// New creates a cache storing entries in backend for ttl
+func New(backend Backend, ttl time.Duration) *Cache {
+	return &Cache{
+		backend: backend,
+		ttl:     ttl,
+	}
+}
+
+// Invalidate removes every entry of the namespaces
+func (c *Cache) Invalidate(ctx context.Context, namespaces ...string) {
+	for _, namespace := range namespaces {
+		if err := c.backend.DeletePrefix(ctx, namespace+":"); err != nil {
+			log.Printf("⚠️  Failed to invalidate the %s cache: %v", namespace, err)
+		}
+		count(namespace, "invalidations")
+	}
+}
+
+// load returns the cached value of key, or computes and caches it. Backend
+// failures are logged and fall back to compute, so a cache outage only makes
+// reads slower.
+func load[T any](ctx context.Context, c *Cache, namespace, key string, compute func() (T, error)) (T, error) {
+	key = namespace + ":" + key
+	data, ok, err := c.backend.Get(ctx, key)
+	if err != nil {
+		log.Printf("⚠️  Failed to read %s from the cache: %v", key, err)
+	}
+	if ok {
+		var value T
+		if err := json.Unmarshal(data, &value); err == nil {
+			count(namespace, "hits")
+			return value, nil
+		}
+	}
+	count(namespace, "misses")
+
+	value, err := compute()
+	if err != nil {
+		return value, err
+	}
+	if data, err = json.Marshal(value); err == nil {
+		err = c.backend.Set(ctx, key, data, c.ttl)
+	}
+	if err != nil {
+		log.Printf("⚠️  Failed to cache %s: %v", key, err)
+	}
+	return value, nil
+}
+
+// count increments a counter of a namespace declared above
+func count(namespace, counter string) {
+	if counters, ok := stats[namespace]; ok {
+		counters.Add(counter, 1)
+	}
+}
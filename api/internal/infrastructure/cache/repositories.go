@@ -0,0 +1,102 @@
+package cache
+
+import (
+	"context"
+
+	"github.com/frtasoniero/subsmanager/internal/domain/entities"
+	"github.com/frtasoniero/subsmanager/internal/domain/repositories"
+)
+
+// ProductRepository caches product reads. Every write, including
+// deactivating a product through Update, invalidates the cached products
+// and the spending summaries, which depend on product prices and
+// categories. Search and Count are not cached.
+type ProductRepository struct {
+	repositories.ProductRepository
+	cache *Cache
+}
+
+func NewProductRepository(products repositories.ProductRepository, cache *Cache) *ProductRepository {
+	return &ProductRepository{
+		ProductRepository: products,
+		cache:             cache,
+	}
+}
+
+func (r *ProductRepository) Create(ctx context.Context, product *entities.Product) error {
+	defer r.invalidate(ctx)
+	return r.ProductRepository.Create(ctx, product)
+}
+
+func (r *ProductRepository) GetByID(ctx context.Context, id entities.ID) (*entities.Product, error) {
+	return load(ctx, r.cache, NamespaceProducts, "id:"+id.String(), func() (*entities.Product, error) {
+		return r.ProductRepository.GetByID(ctx, id)
+	})
+}
+
+func (r *ProductRepository) GetByName(ctx context.Context, name string) (*entities.Product, error) {
+	return load(ctx, r.cache, NamespaceProducts, "name:"+name, func() (*entities.Product, error) {
+		return r.ProductRepository.GetByName(ctx, name)
+	})
+}
+
+func (r *ProductRepository) GetByCategory(ctx context.Context, category string) ([]*entities.Product, error) {
+	return load(ctx, r.cache, NamespaceProducts, "category:"+category, func() ([]*entities.Product, error) {
+		return r.ProductRepository.GetByCategory(ctx, category)
+	})
+}
+
+func (r *ProductRepository) GetAll(ctx context.Context) ([]*entities.Product, error) {
+	return load(ctx, r.cache, NamespaceProducts, "all", func() ([]*entities.Product, error) {
+		return r.ProductRepository.GetAll(ctx)
+	})
+}
+
+func (r *ProductRepository) GetActive(ctx context.Context) ([]*entities.Product, error) {
+	return load(ctx, r.cache, NamespaceProducts, "active", func() ([]*entities.Product, error) {
+		return r.ProductRepository.GetActive(ctx)
+	})
+}
+
+func (r *ProductRepository) Update(ctx context.Context, product *entities.Product) error {
+	defer r.invalidate(ctx)
+	return r.ProductRepository.Update(ctx, product)
+}
+
+func (r *ProductRepository) Delete(ctx context.Context, id entities.ID) error {
+	defer r.invalidate(ctx)
+	return r.ProductRepository.Delete(ctx, id)
+}
+
+func (r *ProductRepository) invalidate(ctx context.Context) {
+	r.cache.Invalidate(ctx, NamespaceProducts, NamespaceSpending)
+}
+
+// SubscriptionRepository invalidates the spending summaries on every
+// subscription write. Subscription reads are not cached.
+type SubscriptionRepository struct {
+	repositories.SubscriptionRepository
+	cache *Cache
+}
+
+func NewSubscriptionRepository(subscriptions repositories.SubscriptionRepository, cache *Cache) *SubscriptionRepository {
+	return &SubscriptionRepository{
+		SubscriptionRepository: subscriptions,
+		cache:                  cache,
+	}
+}
+
+func (r *SubscriptionRepository) Create(ctx context.Context, subscription *entities.Subscription) error {
+	defer r.cache.Invalidate(ctx, NamespaceSpending)
+	return r.SubscriptionRepository.Create(ctx, subscription)
+}
+
+func (r *SubscriptionRepository) Update(ctx context.Context, subscription *entities.Subscription) error {
+	defer r.cache.Invalidate(ctx, NamespaceSpending)
+	return r.SubscriptionRepository.Update(ctx, subscription)
+}
+
+func (r *SubscriptionRepository) Delete(ctx context.Context, id entities.ID) error {
+	defer r.cache.Invalidate(ctx, NamespaceSpending)
+	return r.SubscriptionRepository.Delete(ctx, id)
+}
@@ -0,0 +1,24 @@
+package cache
+
+import (
+	"context"
+
+	"github.com/frtasoniero/subsmanager/internal/usecases"
+)
+
+// SpendingSummaries caches the spending summaries of the export use case.
+// They are invalidated by the ProductRepository and SubscriptionRepository
+// decorators sharing the same Cache.
+type SpendingSummaries struct {
+	cache *Cache
+}
+
+func NewSpendingSummaries(cache *Cache) *SpendingSummaries {
+	return &SpendingSummaries{cache: cache}
+}
+
+func (s *SpendingSummaries) Summaries(ctx context.Context, compute func(ctx context.Context) ([]usecases.SpendingSummary, error)) ([]usecases.SpendingSummary, error) {
+	return load(ctx, s.cache, NamespaceSpending, "summaries", func() ([]usecases.SpendingSummary, error) {
+		return compute(ctx)
+	})
+}
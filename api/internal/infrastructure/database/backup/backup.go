@@ -0,0 +1,190 @@
+// Package backup dumps the database to a compressed archive and restores it.
+//
+// An archive is a gzipped tar file. Its first entry, manifest.json, records the
+// archive format, the schema migration version of the source database and the
+// document count of each collection. Every collection follows as
+// <name>.ndjson, one canonical extended JSON document per line, so types such
+// as ObjectIDs and dates survive the round trip.
+package backup
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/frtasoniero/subsmanager/internal/infrastructure/database/migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// FormatVersion is the version of the archive layout written by this package
+const FormatVersion = 1
+
+const manifestName = "manifest.json"
+
+// Manifest describes the content of an archive
+type Manifest struct {
+	FormatVersion int                  `json:"format_version"`
+	SchemaVersion int64                `json:"schema_version"`
+	Database      string               `json:"database"`
+	CreatedAt     time.Time            `json:"created_at"`
+	Collections   []CollectionManifest `json:"collections"`
+}
+
+// CollectionManifest is the entry of a single collection in the manifest
+type CollectionManifest struct {
+	Name      string `json:"name"`
+	Documents int64  `json:"documents"`
+}
+
+// skipped lists the collections that belong to the migration tooling. Their
+// state is described by SchemaVersion instead of being copied.
+var skipped = map[string]bool{
+	migrate.LedgerCollection: true,
+	migrate.LockCollection:   true,
+}
+
+// Collections returns the data collections of a database in name order,
+// leaving out the staging collections of a restore
+func Collections(ctx context.Context, db *mongo.Database) ([]string, error) {
+	names, err := db.ListCollectionNames(ctx, bson.M{"type": "collection"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list collections: %w", err)
+	}
+
+	collections := make([]string, 0, len(names))
+	for _, name := range names {
+		if skipped[name] || strings.HasPrefix(name, "system.") || strings.HasPrefix(name, stagingPrefix) {
+			continue
+		}
+		collections = append(collections, name)
+	}
+	sort.Strings(collections)
+	return collections, nil
+}
+
+// Write dumps every data collection of db into an archive written to w
+func Write(ctx context.Context, db *mongo.Database, w io.Writer) (*Manifest, error) {
+	schemaVersion, err := migrate.NewMigrator(db).Current(ctx)
+	if err != nil {
+		return nil, err
+	}
+	names, err := Collections(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &Manifest{
+		FormatVersion: FormatVersion,
+		SchemaVersion: schemaVersion,
+		Database:      db.Name(),
+		CreatedAt:     time.Now().UTC(),
+		Collections:   []CollectionManifest{},
+	}
+
+	// Collections are dumped to temporary files first: tar needs each entry
+	// size up front and the manifest, which comes first, needs the counts
+	dumps := make([]*os.File, 0, len(names))
+	defer func() {
+		for _, dump := range dumps {
+			dump.Close()
+			os.Remove(dump.Name())
+		}
+	}()
+
+	for _, name := range names {
+		dump, err := os.CreateTemp("", "subsmanager-backup-*.ndjson")
+		if err != nil {
+			return nil, err
+		}
+		dumps = append(dumps, dump)
+
+		count, err := dumpCollection(ctx, db.Collection(name), dump)
+		if err != nil {
+			return nil, fmt.Errorf("failed to dump %s: %w", name, err)
+		}
+		manifest.Collections = append(manifest.Collections, CollectionManifest{Name: name, Documents: count})
+	}
+
+	gz := gzip.NewWriter(w)
+	archive := tar.NewWriter(gz)
+
+	manifestData, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := writeEntry(archive, manifestName, int64(len(manifestData)), strings.NewReader(string(manifestData))); err != nil {
+		return nil, err
+	}
+
+	for i, dump := range dumps {
+		size, err := dump.Seek(0, io.SeekEnd)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := dump.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+		if err := writeEntry(archive, names[i]+".ndjson", size, dump); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+func dumpCollection(ctx context.Context, collection *mongo.Collection, w io.Writer) (int64, error) {
+	cursor, err := collection.Find(ctx, bson.M{})
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	buffered := bufio.NewWriter(w)
+	var count int64
+	for cursor.Next(ctx) {
+		line, err := bson.MarshalExtJSON(cursor.Current, true, false)
+		if err != nil {
+			return count, err
+		}
+		if _, err := buffered.Write(line); err != nil {
+			return count, err
+		}
+		if err := buffered.WriteByte('\n'); err != nil {
+			return count, err
+		}
+		count++
+	}
+	if err := cursor.Err(); err != nil {
+		return count, err
+	}
+	return count, buffered.Flush()
+}
+
+func writeEntry(archive *tar.Writer, name string, size int64, content io.Reader) error {
+	header := &tar.Header{
+		Name:    name,
+		Mode:    0o644,
+		Size:    size,
+		ModTime: time.Now(),
+	}
+	if err := archive.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err := io.Copy(archive, content)
+	return err
+}
@@ -0,0 +1,302 @@
+package backup
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/frtasoniero/subsmanager/internal/infrastructure/database/migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// restoreBatchSize is the number of documents inserted per request
+const restoreBatchSize = 1000
+
+// maxDocumentLine bounds a single extended JSON line; MongoDB documents are at most 16MB
+const maxDocumentLine = 64 << 20
+
+var (
+	// ErrInvalidArchive is returned when the archive cannot be read
+	ErrInvalidArchive = errors.New("invalid backup archive")
+	// ErrSchemaMismatch is returned when the archive was taken at another schema version
+	ErrSchemaMismatch = errors.New("backup schema version does not match the migrations")
+)
+
+// RestoreOptions selects what a restore writes
+type RestoreOptions struct {
+	// Collections limits the restore to these collections, all when empty
+	Collections []string
+}
+
+// RestoreResult reports the restored collections
+type RestoreResult struct {
+	Manifest    *Manifest            `json:"manifest"`
+	Collections []CollectionManifest `json:"collections"`
+}
+
+// stagingPrefix names the collections a restore fills before swapping them in
+// for the restored ones
+const stagingPrefix = "restore_"
+
+// ReadManifest reads only the manifest of an archive
+func ReadManifest(r io.Reader) (*Manifest, error) {
+	archive, gz, err := openArchive(r)
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+	return readManifest(archive)
+}
+
+// Restore migrates db to the latest schema and replaces the content of the
+// archived collections. The archive must have been taken at the schema version
+// of the migrations in this build, otherwise nothing is written.
+//
+// The whole archive is read once before any write, so a truncated archive or
+// an invalid document leaves db unchanged. Each collection is then restored
+// into a staging collection, and the staging collections replace the restored
+// ones only once all of them are filled.
+func Restore(ctx context.Context, db *mongo.Database, r io.ReadSeeker, opts RestoreOptions) (*RestoreResult, error) {
+	manifest, selected, err := verify(r, opts.Collections)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	// Collections, indexes and validators must exist before documents go in
+	if _, err := migrate.NewMigrator(db).Up(ctx); err != nil {
+		return nil, fmt.Errorf("failed to migrate the target database: %w", err)
+	}
+
+	archive, gz, err := openArchive(r)
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+	if _, err := readManifest(archive); err != nil {
+		return nil, err
+	}
+
+	// Staging collections left by a failed restore are dropped, and the
+	// swapped ones no longer exist
+	var staged []string
+	defer func() {
+		for _, name := range staged {
+			db.Collection(stagingPrefix + name).Drop(context.WithoutCancel(ctx))
+		}
+	}()
+
+	result := &RestoreResult{Manifest: manifest, Collections: []CollectionManifest{}}
+	for {
+		header, err := archive.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidArchive, err)
+		}
+		name := strings.TrimSuffix(header.Name, ".ndjson")
+		if !selected[name] {
+			continue
+		}
+
+		staging := stagingPrefix + name
+		if err := db.Collection(staging).Drop(ctx); err != nil {
+			return nil, fmt.Errorf("failed to drop %s: %w", staging, err)
+		}
+		staged = append(staged, name)
+		if err := migrate.CreateLike(ctx, db, name, staging); err != nil {
+			return nil, err
+		}
+		count, err := insertDocuments(ctx, db.Collection(staging), archive)
+		if err != nil {
+			return nil, fmt.Errorf("failed to restore %s: %w", name, err)
+		}
+		result.Collections = append(result.Collections, CollectionManifest{Name: name, Documents: count})
+	}
+
+	// Every collection is staged, swap them in
+	admin := db.Client().Database("admin")
+	for len(staged) > 0 {
+		name := staged[0]
+		err := admin.RunCommand(ctx, bson.D{
+			{Key: "renameCollection", Value: db.Name() + "." + stagingPrefix + name},
+			{Key: "to", Value: db.Name() + "." + name},
+			{Key: "dropTarget", Value: true},
+		}).Err()
+		if err != nil {
+			return nil, fmt.Errorf("failed to replace %s: %w", name, err)
+		}
+		staged = staged[1:]
+	}
+	return result, nil
+}
+
+// verify reads the whole archive without writing anything. It checks the
+// schema version, that every selected collection has an entry whose
+// documents all parse and match the count of the manifest, and the gzip
+// checksum. It returns the manifest and the collections to restore.
+func verify(r io.Reader, only []string) (*Manifest, map[string]bool, error) {
+	archive, gz, err := openArchive(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer gz.Close()
+
+	manifest, err := readManifest(archive)
+	if err != nil {
+		return nil, nil, err
+	}
+	if manifest.SchemaVersion != migrate.Latest() {
+		return nil, nil, fmt.Errorf("%w: archive is at version %d, migrations are at version %d",
+			ErrSchemaMismatch, manifest.SchemaVersion, migrate.Latest())
+	}
+
+	documents := make(map[string]int64, len(manifest.Collections))
+	for _, collection := range manifest.Collections {
+		documents[collection.Name] = collection.Documents
+	}
+	selected := make(map[string]bool, len(manifest.Collections))
+	for _, name := range only {
+		if _, ok := documents[name]; !ok {
+			return nil, nil, fmt.Errorf("%w: collection %q is not in the archive", ErrInvalidArchive, name)
+		}
+		selected[name] = true
+	}
+	if len(only) == 0 {
+		for name := range documents {
+			selected[name] = true
+		}
+	}
+
+	found := make(map[string]bool, len(selected))
+	for {
+		header, err := archive.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: %v", ErrInvalidArchive, err)
+		}
+		name := strings.TrimSuffix(header.Name, ".ndjson")
+		if !selected[name] {
+			continue
+		}
+		if found[name] {
+			return nil, nil, fmt.Errorf("%w: collection %q is archived twice", ErrInvalidArchive, name)
+		}
+		found[name] = true
+
+		count, err := readDocuments(archive, func(bson.D) error { return nil })
+		if err != nil {
+			return nil, nil, fmt.Errorf("collection %s: %w", name, err)
+		}
+		if count != documents[name] {
+			return nil, nil, fmt.Errorf("%w: collection %q has %d documents, the manifest lists %d",
+				ErrInvalidArchive, name, count, documents[name])
+		}
+	}
+	for name := range selected {
+		if !found[name] {
+			return nil, nil, fmt.Errorf("%w: collection %q is in the manifest but has no %s.ndjson entry", ErrInvalidArchive, name, name)
+		}
+	}
+
+	// Reading the rest of the gzip stream checks its checksum
+	if _, err := io.Copy(io.Discard, gz); err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidArchive, err)
+	}
+	return manifest, selected, nil
+}
+
+// insertDocuments inserts the documents of an archive entry in batches
+func insertDocuments(ctx context.Context, collection *mongo.Collection, r io.Reader) (int64, error) {
+	var count int64
+	batch := make([]any, 0, restoreBatchSize)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		// Documents are restored as they were, even if a validator now rejects them
+		if _, err := collection.InsertMany(ctx, batch, options.InsertMany().SetBypassDocumentValidation(true)); err != nil {
+			return err
+		}
+		count += int64(len(batch))
+		batch = batch[:0]
+		return nil
+	}
+
+	if _, err := readDocuments(r, func(document bson.D) error {
+		batch = append(batch, document)
+		if len(batch) == restoreBatchSize {
+			return flush()
+		}
+		return nil
+	}); err != nil {
+		return count, err
+	}
+	return count, flush()
+}
+
+// readDocuments parses the extended JSON lines of an archive entry and calls
+// fn with each document. It returns the number of documents.
+func readDocuments(r io.Reader, fn func(bson.D) error) (int64, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxDocumentLine)
+
+	var count int64
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var document bson.D
+		if err := bson.UnmarshalExtJSON(scanner.Bytes(), true, &document); err != nil {
+			return count, fmt.Errorf("%w: document %d: %v", ErrInvalidArchive, count+1, err)
+		}
+		count++
+		if err := fn(document); err != nil {
+			return count, err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return count, fmt.Errorf("%w: %v", ErrInvalidArchive, err)
+	}
+	return count, nil
+}
+
+func openArchive(r io.Reader) (*tar.Reader, *gzip.Reader, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidArchive, err)
+	}
+	return tar.NewReader(gz), gz, nil
+}
+
+func readManifest(archive *tar.Reader) (*Manifest, error) {
+	header, err := archive.Next()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidArchive, err)
+	}
+	if header.Name != manifestName {
+		return nil, fmt.Errorf("%w: the first entry must be %s", ErrInvalidArchive, manifestName)
+	}
+
+	var manifest Manifest
+	if err := json.NewDecoder(archive).Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("%w: invalid manifest: %v", ErrInvalidArchive, err)
+	}
+	if manifest.FormatVersion != FormatVersion {
+		return nil, fmt.Errorf("%w: unsupported format version %d", ErrInvalidArchive, manifest.FormatVersion)
+	}
+	return &manifest, nil
+}
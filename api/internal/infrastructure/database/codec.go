@@ -0,0 +1,66 @@
+package database
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/frtasoniero/subsmanager/internal/domain/entities"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var idType = reflect.TypeOf(entities.ID(""))
+
+// Registry returns the BSON registry of every connection. It stores
+// entities.ID values as ObjectIDs, so documents keep their native _id type
+// while the domain stays independent of the MongoDB driver.
+func Registry() *bsoncodec.Registry {
+	registry := bson.NewRegistry()
+	registry.RegisterTypeEncoder(idType, bsoncodec.ValueEncoderFunc(encodeID))
+	registry.RegisterTypeDecoder(idType, bsoncodec.ValueDecoderFunc(decodeID))
+	return registry
+}
+
+func encodeID(ec bsoncodec.EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
+	if !val.IsValid() || val.Type() != idType {
+		return bsoncodec.ValueEncoderError{Name: "encodeID", Types: []reflect.Type{idType}, Received: val}
+	}
+	if val.String() == "" {
+		return vw.WriteNull()
+	}
+	id, err := primitive.ObjectIDFromHex(val.String())
+	if err != nil {
+		return fmt.Errorf("cannot encode ID %q: %w", val.String(), err)
+	}
+	return vw.WriteObjectID(id)
+}
+
+func decodeID(dc bsoncodec.DecodeContext, vr bsonrw.ValueReader, val reflect.Value) error {
+	if !val.CanSet() || val.Type() != idType {
+		return bsoncodec.ValueDecoderError{Name: "decodeID", Types: []reflect.Type{idType}, Received: val}
+	}
+
+	switch vr.Type() {
+	case bsontype.ObjectID:
+		id, err := vr.ReadObjectID()
+		if err != nil {
+			return err
+		}
+		val.SetString(id.Hex())
+	case bsontype.String:
+		id, err := vr.ReadString()
+		if err != nil {
+			return err
+		}
+		val.SetString(id)
+	case bsontype.Null:
+		val.SetString("")
+		return vr.ReadNull()
+	default:
+		return fmt.Errorf("cannot decode %v into an ID", vr.Type())
+	}
+	return nil
+}
@@ -20,7 +20,7 @@ type Config struct {
 func NewConnection(config Config) (*mongo.Client, *mongo.Database, error) {
 	log.Printf("🔄 Connecting to MongoDB at %s...", config.URI)
 
-	client, err := mongo.Connect(context.TODO(), options.Client().ApplyURI(config.URI))
+	client, err := mongo.Connect(context.TODO(), options.Client().ApplyURI(config.URI).SetRegistry(Registry()))
 	if err != nil {
 		log.Printf("❌ Failed to connect to MongoDB: %v", err)
 		return nil, nil, err
@@ -42,12 +42,18 @@ func NewConnection(config Config) (*mongo.Client, *mongo.Database, error) {
 	return client, db, nil
 }
 
-// Close closes the MongoDB connection
+// Close closes the MongoDB connection, waiting up to 5 seconds for
+// operations in progress
 func Close(client *mongo.Client) error {
-	log.Println("🔄 Closing MongoDB connection...")
-
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
+	return Disconnect(ctx, client)
+}
+
+// Disconnect closes the MongoDB connection, waiting for operations in
+// progress until ctx is done
+func Disconnect(ctx context.Context, client *mongo.Client) error {
+	log.Println("🔄 Closing MongoDB connection...")
 
 	if err := client.Disconnect(ctx); err != nil {
 		log.Printf("❌ Error closing MongoDB connection: %v", err)
@@ -0,0 +1,39 @@
+package migrate
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func init() {
+	// Reverting would drop the collections with every document in them, so
+	// this migration is irreversible. Drop the database by hand to start over.
+	register(1, "create_collections", createCollectionsUp, nil)
+}
+
+var baseCollections = []string{"users", "products", "subscriptions"}
+
+// createCollectionsUp creates the collections explicitly, which later
+// migrations need in order to attach validators
+func createCollectionsUp(ctx context.Context, db *mongo.Database) error {
+	existing, err := db.ListCollectionNames(ctx, bson.M{})
+	if err != nil {
+		return err
+	}
+	exists := make(map[string]bool, len(existing))
+	for _, name := range existing {
+		exists[name] = true
+	}
+
+	for _, name := range baseCollections {
+		if exists[name] {
+			continue
+		}
+		if err := db.CreateCollection(ctx, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
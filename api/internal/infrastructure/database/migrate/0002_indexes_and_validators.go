@@ -0,0 +1,112 @@
+package migrate
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func init() {
+	register(2, "indexes_and_validators", indexesAndValidatorsUp, indexesAndValidatorsDown)
+}
+
+var (
+	usersIndexes = []Index{
+		{Name: "email_unique", Keys: bson.D{{Key: "email", Value: 1}}, Unique: true},
+		{Name: "username_unique", Keys: bson.D{{Key: "username", Value: 1}}, Unique: true},
+	}
+	productsIndexes = []Index{
+		{Name: "name_unique", Keys: bson.D{{Key: "name", Value: 1}}, Unique: true},
+		{Name: "category", Keys: bson.D{{Key: "category", Value: 1}}},
+	}
+	subscriptionsIndexes = []Index{
+		{Name: "user_id", Keys: bson.D{{Key: "user_id", Value: 1}}},
+		{Name: "product_id", Keys: bson.D{{Key: "product_id", Value: 1}}},
+		{Name: "status_next_billing", Keys: bson.D{{Key: "status", Value: 1}, {Key: "next_billing", Value: 1}}},
+	}
+)
+
+// The validators of this migration. Later migrations replacing one revert to
+// it from here.
+var (
+	usersValidator = jsonSchema(
+		[]string{"username", "email", "status", "created_at"},
+		bson.M{
+			"username":   bson.M{"bsonType": "string", "minLength": 1},
+			"email":      bson.M{"bsonType": "string", "pattern": `^[^@\s]+@[^@\s]+$`, "maxLength": 255},
+			"status":     bson.M{"enum": bson.A{"active", "inactive"}},
+			"created_at": bson.M{"bsonType": "date"},
+			"updated_at": bson.M{"bsonType": "date"},
+		},
+	)
+	productsValidator = jsonSchema(
+		[]string{"name", "price", "billing_type", "status"},
+		bson.M{
+			"name":         bson.M{"bsonType": "string", "minLength": 1},
+			"price":        bson.M{"bsonType": "number", "minimum": 0},
+			"billing_type": bson.M{"enum": bson.A{"weekly", "monthly", "yearly"}},
+			"category":     bson.M{"bsonType": "string"},
+			"status":       bson.M{"enum": bson.A{"active", "inactive"}},
+			"created_at":   bson.M{"bsonType": "date"},
+			"updated_at":   bson.M{"bsonType": "date"},
+		},
+	)
+	subscriptionsValidator = jsonSchema(
+		[]string{"user_id", "product_id", "status", "start_date", "next_billing"},
+		bson.M{
+			"user_id":        bson.M{"bsonType": "objectId"},
+			"product_id":     bson.M{"bsonType": "objectId"},
+			"status":         bson.M{"enum": bson.A{"active", "cancelled", "expired"}},
+			"start_date":     bson.M{"bsonType": "date"},
+			"end_date":       bson.M{"bsonType": bson.A{"date", "null"}},
+			"next_billing":   bson.M{"bsonType": "date"},
+			"price_at_start": bson.M{"bsonType": "number", "minimum": 0},
+			"created_at":     bson.M{"bsonType": "date"},
+			"updated_at":     bson.M{"bsonType": "date"},
+		},
+	)
+)
+
+func indexesAndValidatorsUp(ctx context.Context, db *mongo.Database) error {
+	for _, collection := range []struct {
+		name      string
+		indexes   []Index
+		validator bson.M
+	}{
+		{"users", usersIndexes, usersValidator},
+		{"products", productsIndexes, productsValidator},
+		{"subscriptions", subscriptionsIndexes, subscriptionsValidator},
+	} {
+		if err := setValidator(ctx, db, collection.name, collection.validator); err != nil {
+			return err
+		}
+		if err := createIndexes(ctx, db, collection.name, collection.indexes...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func indexesAndValidatorsDown(ctx context.Context, db *mongo.Database) error {
+	for _, collection := range []struct {
+		name    string
+		indexes []Index
+	}{
+		{"users", usersIndexes},
+		{"products", productsIndexes},
+		{"subscriptions", subscriptionsIndexes},
+	} {
+		if err := removeValidator(ctx, db, collection.name); err != nil {
+			return err
+		}
+		names := make([]string, len(collection.indexes))
+		for i, index := range collection.indexes {
+			names[i] = index.Name
+		}
+		if err := dropIndexes(ctx, db, collection.name, names...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+package migrate
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func init() {
+	register(3, "pagination_indexes", paginationIndexesUp, paginationIndexesDown)
+}
+
+// paginationIndexes back the keyset pagination of subscription listings
+var paginationIndexes = []Index{
+	{Name: "next_billing_id", Keys: bson.D{{Key: "next_billing", Value: 1}, {Key: "_id", Value: 1}}},
+	{Name: "created_at_id", Keys: bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}},
+}
+
+func paginationIndexesUp(ctx context.Context, db *mongo.Database) error {
+	return createIndexes(ctx, db, "subscriptions", paginationIndexes...)
+}
+
+func paginationIndexesDown(ctx context.Context, db *mongo.Database) error {
+	return dropIndexes(ctx, db, "subscriptions", "next_billing_id", "created_at_id")
+}
@@ -0,0 +1,27 @@
+package migrate
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func init() {
+	register(4, "product_text_index", productTextIndexUp, productTextIndexDown)
+}
+
+// productTextIndex backs the search of products by name, category and description
+var productTextIndex = Index{
+	Name:    "text_search",
+	Keys:    bson.D{{Key: "name", Value: "text"}, {Key: "category", Value: "text"}, {Key: "description", Value: "text"}},
+	Weights: bson.D{{Key: "name", Value: 10}, {Key: "category", Value: 5}, {Key: "description", Value: 1}},
+}
+
+func productTextIndexUp(ctx context.Context, db *mongo.Database) error {
+	return createIndexes(ctx, db, "products", productTextIndex)
+}
+
+func productTextIndexDown(ctx context.Context, db *mongo.Database) error {
+	return dropIndexes(ctx, db, "products", productTextIndex.Name)
+}
@@ -0,0 +1,37 @@
+package migrate
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func init() {
+	register(5, "paused_status", pausedStatusUp, pausedStatusDown)
+}
+
+// pausedStatusUp allows the paused status in the subscriptions validator
+func pausedStatusUp(ctx context.Context, db *mongo.Database) error {
+	return setValidator(ctx, db, "subscriptions", jsonSchema(
+		[]string{"user_id", "product_id", "status", "start_date", "next_billing"},
+		bson.M{
+			"user_id":        bson.M{"bsonType": "objectId"},
+			"product_id":     bson.M{"bsonType": "objectId"},
+			"status":         bson.M{"enum": bson.A{"active", "paused", "cancelled", "expired"}},
+			"start_date":     bson.M{"bsonType": "date"},
+			"end_date":       bson.M{"bsonType": bson.A{"date", "null"}},
+			"next_billing":   bson.M{"bsonType": "date"},
+			"price_at_start": bson.M{"bsonType": "number", "minimum": 0},
+			"created_at":     bson.M{"bsonType": "date"},
+			"updated_at":     bson.M{"bsonType": "date"},
+		},
+	))
+}
+
+// pausedStatusDown restores the subscriptions validator of migration 2.
+// Subscriptions paused in the meantime keep their status, and validation is
+// moderate, so they can still be updated.
+func pausedStatusDown(ctx context.Context, db *mongo.Database) error {
+	return setValidator(ctx, db, "subscriptions", subscriptionsValidator)
+}
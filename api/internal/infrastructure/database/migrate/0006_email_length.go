@@ -0,0 +1,34 @@
+package migrate
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func init() {
+	register(6, "email_length", emailLengthUp, emailLengthDown)
+}
+
+// emailLengthUp checks user emails like User.ValidateEmail and the SQL
+// backends, by length only, instead of the pattern of migration 2
+func emailLengthUp(ctx context.Context, db *mongo.Database) error {
+	return setValidator(ctx, db, "users", jsonSchema(
+		[]string{"username", "email", "status", "created_at"},
+		bson.M{
+			"username":   bson.M{"bsonType": "string", "minLength": 1},
+			"email":      bson.M{"bsonType": "string", "minLength": 1, "maxLength": 255},
+			"status":     bson.M{"enum": bson.A{"active", "inactive"}},
+			"created_at": bson.M{"bsonType": "date"},
+			"updated_at": bson.M{"bsonType": "date"},
+		},
+	))
+}
+
+// emailLengthDown restores the users validator of migration 2. Users created
+// in the meantime keep their email, and validation is moderate, so they can
+// still be updated.
+func emailLengthDown(ctx context.Context, db *mongo.Database) error {
+	return setValidator(ctx, db, "users", usersValidator)
+}
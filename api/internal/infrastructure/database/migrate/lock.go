@@ -0,0 +1,145 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// LockCollection holds the single document guarding migrations
+const LockCollection = "schema_migrations_lock"
+
+// lockTTL is how long a lock is honoured without being renewed. A process
+// that crashed while migrating leaves its lock behind; it is taken over once
+// expired. A running migration renews it every lockRenewal.
+const (
+	lockTTL     = 10 * time.Minute
+	lockRenewal = lockTTL / 5
+)
+
+var (
+	// ErrLocked is returned when another process is migrating the database
+	ErrLocked = errors.New("migrations are locked by another process")
+	// ErrLockLost is returned when the lock expired before the migrations ended
+	ErrLockLost = errors.New("migration lock lost")
+)
+
+// Lock prevents two processes from migrating the same database concurrently.
+// It relies on the uniqueness of _id, so acquiring is a single atomic insert.
+type Lock struct {
+	collection *mongo.Collection
+	owner      string
+}
+
+type lockDocument struct {
+	ID        string    `bson:"_id"`
+	Owner     string    `bson:"owner"`
+	LockedAt  time.Time `bson:"locked_at"`
+	ExpiresAt time.Time `bson:"expires_at"`
+}
+
+// NewLock creates a lock owned by the current process
+func NewLock(db *mongo.Database) *Lock {
+	hostname, _ := os.Hostname()
+	return &Lock{
+		collection: db.Collection(LockCollection),
+		owner:      fmt.Sprintf("%s/%d/%s", hostname, os.Getpid(), primitive.NewObjectID().Hex()),
+	}
+}
+
+// Acquire takes the lock or returns ErrLocked with the current holder
+func (l *Lock) Acquire(ctx context.Context) error {
+	now := time.Now().UTC()
+
+	// Clear a lock left behind by a crashed process
+	if _, err := l.collection.DeleteOne(ctx, bson.M{"_id": "lock", "expires_at": bson.M{"$lt": now}}); err != nil {
+		return fmt.Errorf("failed to check migration lock: %w", err)
+	}
+
+	_, err := l.collection.InsertOne(ctx, lockDocument{
+		ID:        "lock",
+		Owner:     l.owner,
+		LockedAt:  now,
+		ExpiresAt: now.Add(lockTTL),
+	})
+	if mongo.IsDuplicateKeyError(err) {
+		var holder lockDocument
+		if err := l.collection.FindOne(ctx, bson.M{"_id": "lock"}).Decode(&holder); err != nil {
+			return ErrLocked
+		}
+		return fmt.Errorf("%w: held by %s since %s", ErrLocked, holder.Owner, holder.LockedAt.Format(time.RFC3339))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+	return nil
+}
+
+// Release frees the lock if it is still held by this process
+func (l *Lock) Release(ctx context.Context) error {
+	_, err := l.collection.DeleteOne(ctx, bson.M{"_id": "lock", "owner": l.owner})
+	return err
+}
+
+// Renew extends the lock by lockTTL. It returns ErrLockLost if this process no
+// longer holds it.
+func (l *Lock) Renew(ctx context.Context) error {
+	result, err := l.collection.UpdateOne(ctx,
+		bson.M{"_id": "lock", "owner": l.owner},
+		bson.M{"$set": bson.M{"expires_at": time.Now().UTC().Add(lockTTL)}},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to renew migration lock: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("%w: it expired and was taken over", ErrLockLost)
+	}
+	return nil
+}
+
+// KeepAlive renews the lock until ctx is done or stop is called. Failed
+// renewals are retried; once the lock is lost, or could not be renewed
+// before it expired, cancel is called with ErrLockLost.
+func (l *Lock) KeepAlive(ctx context.Context, cancel context.CancelCauseFunc) (stop func()) {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(lockRenewal)
+		defer ticker.Stop()
+
+		renewed := time.Now()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+
+			err := l.Renew(ctx)
+			switch {
+			case err == nil:
+				renewed = time.Now()
+			case errors.Is(err, ErrLockLost):
+				cancel(err)
+				return
+			case time.Since(renewed) >= lockTTL:
+				cancel(fmt.Errorf("%w: not renewed for %s: %w", ErrLockLost, lockTTL, err))
+				return
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
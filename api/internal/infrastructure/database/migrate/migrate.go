@@ -0,0 +1,340 @@
+// Package migrate applies versioned schema migrations to the MongoDB database.
+//
+// Each migration lives in its own file named after its version, for example
+// 0001_create_collections.go, and registers itself from an init function.
+// Applied versions are recorded in the schema_migrations collection together
+// with a checksum of the migration file, so editing a migration after it was
+// applied is detected instead of silently diverging between environments.
+package migrate
+
+import (
+	"context"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// LedgerCollection records the applied migrations
+const LedgerCollection = "schema_migrations"
+
+// sources holds the migration files, used to compute their checksums
+//
+//go:embed [0-9]*.go
+var sources embed.FS
+
+var (
+	// ErrChecksumMismatch is returned when an applied migration file was modified
+	ErrChecksumMismatch = errors.New("applied migration was modified")
+	// ErrUnknownVersion is returned when a version is neither defined nor applied
+	ErrUnknownVersion = errors.New("unknown migration version")
+	// ErrIrreversible is returned when reverting a migration without Down
+	ErrIrreversible = errors.New("migration cannot be reverted")
+)
+
+// Func changes the database in one direction
+type Func func(ctx context.Context, db *mongo.Database) error
+
+// Migration is a single versioned schema change. A nil Down makes it
+// irreversible.
+type Migration struct {
+	Version  int64
+	Name     string
+	Checksum string
+	Up       Func
+	Down     Func
+}
+
+// Record is an applied migration as stored in the ledger
+type Record struct {
+	Version   int64     `bson:"_id" json:"version"`
+	Name      string    `bson:"name" json:"name"`
+	Checksum  string    `bson:"checksum" json:"checksum"`
+	AppliedAt time.Time `bson:"applied_at" json:"applied_at"`
+}
+
+// Status describes a migration and whether it was applied
+type Status struct {
+	Version   int64      `json:"version"`
+	Name      string     `json:"name"`
+	Applied   bool       `json:"applied"`
+	AppliedAt *time.Time `json:"applied_at,omitempty"`
+	// Modified is set when the file changed since it was applied
+	Modified bool `json:"modified"`
+	// Missing is set when the ledger has a version no file defines
+	Missing bool `json:"missing"`
+}
+
+var registry = map[int64]*Migration{}
+
+// register adds the migration defined by the calling file. The checksum is
+// computed from the file content, so register must be called from the file
+// that defines the migration.
+func register(version int64, name string, up, down Func) {
+	if _, exists := registry[version]; exists {
+		panic(fmt.Sprintf("migrate: duplicate migration version %d", version))
+	}
+
+	_, file, _, ok := runtime.Caller(1)
+	if !ok {
+		panic("migrate: cannot locate migration file")
+	}
+	content, err := sources.ReadFile(filepath.Base(file))
+	if err != nil {
+		panic(fmt.Sprintf("migrate: migration %d is not embedded: %v", version, err))
+	}
+	sum := sha256.Sum256(content)
+
+	registry[version] = &Migration{
+		Version:  version,
+		Name:     name,
+		Checksum: hex.EncodeToString(sum[:]),
+		Up:       up,
+		Down:     down,
+	}
+}
+
+// Migrations returns every defined migration ordered by version
+func Migrations() []*Migration {
+	migrations := make([]*Migration, 0, len(registry))
+	for _, migration := range registry {
+		migrations = append(migrations, migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations
+}
+
+// Latest returns the highest defined version, or 0 when there is none
+func Latest() int64 {
+	var latest int64
+	for version := range registry {
+		if version > latest {
+			latest = version
+		}
+	}
+	return latest
+}
+
+// Migrator applies and reverts migrations on a database
+type Migrator struct {
+	db         *mongo.Database
+	ledger     *mongo.Collection
+	migrations []*Migration
+	lock       *Lock
+}
+
+// NewMigrator creates a migrator for every defined migration
+func NewMigrator(db *mongo.Database) *Migrator {
+	return &Migrator{
+		db:         db,
+		ledger:     db.Collection(LedgerCollection),
+		migrations: Migrations(),
+		lock:       NewLock(db),
+	}
+}
+
+// Applied returns the ledger ordered by version
+func (m *Migrator) Applied(ctx context.Context) ([]Record, error) {
+	cursor, err := m.ledger.Find(ctx, bson.M{}, options.Find().SetSort(bson.M{"_id": 1}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", LedgerCollection, err)
+	}
+	defer cursor.Close(ctx)
+
+	records := []Record{}
+	if err := cursor.All(ctx, &records); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", LedgerCollection, err)
+	}
+	return records, nil
+}
+
+// Current returns the highest applied version, or 0 on a fresh database
+func (m *Migrator) Current(ctx context.Context) (int64, error) {
+	records, err := m.Applied(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if len(records) == 0 {
+		return 0, nil
+	}
+	return records[len(records)-1].Version, nil
+}
+
+// Status lists defined and applied migrations ordered by version
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	records, err := m.Applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+	applied := make(map[int64]Record, len(records))
+	for _, record := range records {
+		applied[record.Version] = record
+	}
+
+	statuses := []Status{}
+	for _, migration := range m.migrations {
+		status := Status{Version: migration.Version, Name: migration.Name}
+		if record, ok := applied[migration.Version]; ok {
+			appliedAt := record.AppliedAt
+			status.Applied = true
+			status.AppliedAt = &appliedAt
+			status.Modified = record.Checksum != migration.Checksum
+			delete(applied, migration.Version)
+		}
+		statuses = append(statuses, status)
+	}
+	for _, record := range applied {
+		appliedAt := record.AppliedAt
+		statuses = append(statuses, Status{
+			Version:   record.Version,
+			Name:      record.Name,
+			Applied:   true,
+			AppliedAt: &appliedAt,
+			Missing:   true,
+		})
+	}
+
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Version < statuses[j].Version })
+	return statuses, nil
+}
+
+// Up applies every pending migration and returns the applied ones
+func (m *Migrator) Up(ctx context.Context) ([]*Migration, error) {
+	return m.To(ctx, Latest())
+}
+
+// Down reverts the given number of most recent migrations
+func (m *Migrator) Down(ctx context.Context, steps int) ([]*Migration, error) {
+	if steps < 1 {
+		return nil, errors.New("steps must be at least 1")
+	}
+
+	records, err := m.Applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	target := int64(0)
+	if steps < len(records) {
+		target = records[len(records)-steps-1].Version
+	}
+	return m.To(ctx, target)
+}
+
+// To migrates up or down until version is the last applied migration.
+// Version 0 reverts every migration, unless one of them is irreversible.
+func (m *Migrator) To(ctx context.Context, version int64) ([]*Migration, error) {
+	if version != 0 && registry[version] == nil {
+		return nil, fmt.Errorf("%w: %d", ErrUnknownVersion, version)
+	}
+
+	if err := m.lock.Acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer m.lock.Release(context.WithoutCancel(ctx))
+
+	// Migrations stop if the lock is lost, as another process may take it over
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	stop := m.lock.KeepAlive(ctx, cancel)
+	defer stop()
+
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, status := range statuses {
+		if status.Modified {
+			return nil, fmt.Errorf("%w: %d_%s", ErrChecksumMismatch, status.Version, status.Name)
+		}
+	}
+
+	// Check every migration to revert first, so none is reverted when one of
+	// them cannot be
+	var reverts []*Migration
+	for i := len(statuses) - 1; i >= 0; i-- {
+		status := statuses[i]
+		if !status.Applied || status.Version <= version {
+			continue
+		}
+		if status.Missing {
+			return nil, fmt.Errorf("%w: %d is applied but not defined, cannot revert it", ErrUnknownVersion, status.Version)
+		}
+		migration := registry[status.Version]
+		if migration.Down == nil {
+			return nil, fmt.Errorf("%w: %d_%s", ErrIrreversible, migration.Version, migration.Name)
+		}
+		reverts = append(reverts, migration)
+	}
+
+	var done []*Migration
+
+	// Revert applied migrations above the target, newest first
+	for _, migration := range reverts {
+		if err := m.revert(ctx, migration); err != nil {
+			return done, withCause(ctx, err)
+		}
+		done = append(done, migration)
+	}
+
+	// Apply pending migrations up to the target, oldest first
+	for _, status := range statuses {
+		if status.Applied || status.Version > version {
+			continue
+		}
+		migration := registry[status.Version]
+		if err := m.apply(ctx, migration); err != nil {
+			return done, withCause(ctx, err)
+		}
+		done = append(done, migration)
+	}
+
+	return done, nil
+}
+
+func (m *Migrator) apply(ctx context.Context, migration *Migration) error {
+	if err := migration.Up(ctx, m.db); err != nil {
+		return fmt.Errorf("migration %d_%s failed: %w", migration.Version, migration.Name, err)
+	}
+	_, err := m.ledger.InsertOne(ctx, Record{
+		Version:   migration.Version,
+		Name:      migration.Name,
+		Checksum:  migration.Checksum,
+		AppliedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		return fmt.Errorf("migration %d_%s was applied but not recorded: %w", migration.Version, migration.Name, err)
+	}
+	return nil
+}
+
+func (m *Migrator) revert(ctx context.Context, migration *Migration) error {
+	if err := migration.Down(ctx, m.db); err != nil {
+		return fmt.Errorf("reverting migration %d_%s failed: %w", migration.Version, migration.Name, err)
+	}
+	if _, err := m.ledger.DeleteOne(ctx, bson.M{"_id": migration.Version}); err != nil {
+		return fmt.Errorf("migration %d_%s was reverted but is still recorded: %w", migration.Version, migration.Name, err)
+	}
+	return nil
+}
+
+// withCause returns the reason ctx was cancelled, such as a lost lock, instead
+// of the bare context error err wraps
+func withCause(ctx context.Context, err error) error {
+	if cause := context.Cause(ctx); cause != nil && cause != ctx.Err() {
+		return fmt.Errorf("%w: %w", err, cause)
+	}
+	return err
+}
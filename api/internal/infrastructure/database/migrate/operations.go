@@ -0,0 +1,80 @@
+package migrate
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// The operations below are shared by the migration files. Applied migrations
+// keep calling them, so they must keep doing the same thing; a new behavior
+// needs a new operation.
+
+// setValidator replaces the validator of a collection. Validation is moderate:
+// documents that were already invalid can still be updated, every new
+// document must be valid.
+func setValidator(ctx context.Context, db *mongo.Database, collection string, validator bson.M) error {
+	err := db.RunCommand(ctx, bson.D{
+		{Key: "collMod", Value: collection},
+		{Key: "validator", Value: validator},
+		{Key: "validationLevel", Value: "moderate"},
+		{Key: "validationAction", Value: "error"},
+	}).Err()
+	if err != nil {
+		return fmt.Errorf("failed to set the %s validator: %w", collection, err)
+	}
+	return nil
+}
+
+// removeValidator turns validation off for a collection
+func removeValidator(ctx context.Context, db *mongo.Database, collection string) error {
+	err := db.RunCommand(ctx, bson.D{
+		{Key: "collMod", Value: collection},
+		{Key: "validator", Value: bson.M{}},
+		{Key: "validationLevel", Value: "off"},
+	}).Err()
+	if err != nil {
+		return fmt.Errorf("failed to remove the %s validator: %w", collection, err)
+	}
+	return nil
+}
+
+// createIndexes creates the given indexes of a collection. Indexes that
+// already exist with the same definition are left as they are.
+func createIndexes(ctx context.Context, db *mongo.Database, collection string, indexes ...Index) error {
+	models := make([]mongo.IndexModel, 0, len(indexes))
+	for _, index := range indexes {
+		opts := options.Index().SetName(index.Name)
+		if index.Unique {
+			opts.SetUnique(true)
+		}
+		if len(index.Weights) > 0 {
+			opts.SetWeights(index.Weights)
+		}
+		models = append(models, mongo.IndexModel{Keys: index.Keys, Options: opts})
+	}
+	if _, err := db.Collection(collection).Indexes().CreateMany(ctx, models); err != nil {
+		return fmt.Errorf("failed to create the %s indexes: %w", collection, err)
+	}
+	return nil
+}
+
+// dropIndexes drops the named indexes of a collection that exist
+func dropIndexes(ctx context.Context, db *mongo.Database, collection string, names ...string) error {
+	existing, err := listIndexes(ctx, db.Collection(collection))
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		if _, ok := existing[name]; !ok {
+			continue
+		}
+		if _, err := db.Collection(collection).Indexes().DropOne(ctx, name); err != nil {
+			return fmt.Errorf("failed to drop the %s index %s: %w", collection, name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,317 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ErrSchemaOutdated is returned by CheckSchema when the database does not match
+// the migrations and declarations of this build
+var ErrSchemaOutdated = errors.New("database schema is outdated, run `cli migrate up`")
+
+// Index is a declared index of a collection
+type Index struct {
+	Name   string
+	Keys   bson.D
+	Unique bool
+	// Weights sets the relevance of the fields of a text index
+	Weights bson.D
+}
+
+// storedKeys returns the keys the server lists for the index. The fields of
+// a text index are listed as the _fts and _ftsx pseudo-fields.
+func (i Index) storedKeys() bson.D {
+	for _, key := range i.Keys {
+		if key.Value == "text" {
+			return bson.D{{Key: "_fts", Value: "text"}, {Key: "_ftsx", Value: 1}}
+		}
+	}
+	return i.Keys
+}
+
+// CollectionSchema declares the indexes and the $jsonSchema validator of a collection
+type CollectionSchema struct {
+	Name      string
+	Indexes   []Index
+	Validator bson.M
+}
+
+// Schemas declares the state every collection is in once all migrations are
+// applied. CheckSchema compares the database with it at startup. Migrations
+// never read it: each one spells out its own indexes and validators, so an
+// applied migration keeps doing what its checksum was recorded for. A
+// migration changing the schema updates this declaration too.
+var Schemas = []CollectionSchema{
+	{
+		Name: "users",
+		Indexes: []Index{
+			{Name: "email_unique", Keys: bson.D{{Key: "email", Value: 1}}, Unique: true},
+			{Name: "username_unique", Keys: bson.D{{Key: "username", Value: 1}}, Unique: true},
+		},
+		Validator: jsonSchema(
+			[]string{"username", "email", "status", "created_at"},
+			bson.M{
+				"username":   bson.M{"bsonType": "string", "minLength": 1},
+				"email":      bson.M{"bsonType": "string", "minLength": 1, "maxLength": 255},
+				"status":     bson.M{"enum": bson.A{"active", "inactive"}},
+				"created_at": bson.M{"bsonType": "date"},
+				"updated_at": bson.M{"bsonType": "date"},
+			},
+		),
+	},
+	{
+		Name: "products",
+		Indexes: []Index{
+			{Name: "name_unique", Keys: bson.D{{Key: "name", Value: 1}}, Unique: true},
+			{Name: "category", Keys: bson.D{{Key: "category", Value: 1}}},
+			{
+				Name:    "text_search",
+				Keys:    bson.D{{Key: "name", Value: "text"}, {Key: "category", Value: "text"}, {Key: "description", Value: "text"}},
+				Weights: bson.D{{Key: "name", Value: 10}, {Key: "category", Value: 5}, {Key: "description", Value: 1}},
+			},
+		},
+		Validator: jsonSchema(
+			[]string{"name", "price", "billing_type", "status"},
+			bson.M{
+				"name":         bson.M{"bsonType": "string", "minLength": 1},
+				"price":        bson.M{"bsonType": "number", "minimum": 0},
+				"billing_type": bson.M{"enum": bson.A{"weekly", "monthly", "yearly"}},
+				"category":     bson.M{"bsonType": "string"},
+				"status":       bson.M{"enum": bson.A{"active", "inactive"}},
+				"created_at":   bson.M{"bsonType": "date"},
+				"updated_at":   bson.M{"bsonType": "date"},
+			},
+		),
+	},
+	{
+		Name: "subscriptions",
+		Indexes: []Index{
+			{Name: "user_id", Keys: bson.D{{Key: "user_id", Value: 1}}},
+			{Name: "product_id", Keys: bson.D{{Key: "product_id", Value: 1}}},
+			{Name: "status_next_billing", Keys: bson.D{{Key: "status", Value: 1}, {Key: "next_billing", Value: 1}}},
+			{Name: "next_billing_id", Keys: bson.D{{Key: "next_billing", Value: 1}, {Key: "_id", Value: 1}}},
+			{Name: "created_at_id", Keys: bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}},
+		},
+		Validator: jsonSchema(
+			[]string{"user_id", "product_id", "status", "start_date", "next_billing"},
+			bson.M{
+				"user_id":        bson.M{"bsonType": "objectId"},
+				"product_id":     bson.M{"bsonType": "objectId"},
+				"status":         bson.M{"enum": bson.A{"active", "paused", "cancelled", "expired"}},
+				"start_date":     bson.M{"bsonType": "date"},
+				"end_date":       bson.M{"bsonType": bson.A{"date", "null"}},
+				"next_billing":   bson.M{"bsonType": "date"},
+				"price_at_start": bson.M{"bsonType": "number", "minimum": 0},
+				"created_at":     bson.M{"bsonType": "date"},
+				"updated_at":     bson.M{"bsonType": "date"},
+			},
+		),
+	},
+}
+
+func jsonSchema(required []string, properties bson.M) bson.M {
+	return bson.M{
+		"$jsonSchema": bson.M{
+			"bsonType":   "object",
+			"required":   required,
+			"properties": properties,
+		},
+	}
+}
+
+// CreateLike creates collection with the validator and indexes Schemas
+// declares for the collection name, or bare when it declares none. Restores
+// fill such a collection before swapping it in for name.
+func CreateLike(ctx context.Context, db *mongo.Database, name, collection string) error {
+	if err := db.CreateCollection(ctx, collection); err != nil {
+		return fmt.Errorf("failed to create %s: %w", collection, err)
+	}
+	for _, schema := range Schemas {
+		if schema.Name != name {
+			continue
+		}
+		if err := setValidator(ctx, db, collection, schema.Validator); err != nil {
+			return err
+		}
+		return createIndexes(ctx, db, collection, schema.Indexes...)
+	}
+	return nil
+}
+
+// CheckSchema verifies that every migration is applied and that the indexes,
+// with their options, and the validators match Schemas. It is called at startup so the API does
+// not serve requests against a database it was not built for.
+func CheckSchema(ctx context.Context, db *mongo.Database) error {
+	statuses, err := NewMigrator(db).Status(ctx)
+	if err != nil {
+		return err
+	}
+	var problems []string
+	for _, status := range statuses {
+		switch {
+		case status.Missing:
+			problems = append(problems, fmt.Sprintf("migration %d_%s is applied but unknown to this build", status.Version, status.Name))
+		case status.Modified:
+			problems = append(problems, fmt.Sprintf("migration %d_%s was modified after it was applied", status.Version, status.Name))
+		case !status.Applied:
+			problems = append(problems, fmt.Sprintf("migration %d_%s is pending", status.Version, status.Name))
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %v", ErrSchemaOutdated, problems)
+	}
+
+	for _, schema := range Schemas {
+		existing, err := listIndexes(ctx, db.Collection(schema.Name))
+		if err != nil {
+			return err
+		}
+		for _, index := range schema.Indexes {
+			stored, ok := existing[index.Name]
+			if !ok {
+				problems = append(problems, fmt.Sprintf("index %s.%s is missing", schema.Name, index.Name))
+				continue
+			}
+			if expected := index.storedKeys(); !reflect.DeepEqual(stored.Keys, expected) {
+				problems = append(problems, fmt.Sprintf("index %s.%s has keys %v, expected %v", schema.Name, index.Name, stored.Keys, expected))
+			}
+			if stored.Unique != index.Unique {
+				problems = append(problems, fmt.Sprintf("index %s.%s has unique %t, expected %t", schema.Name, index.Name, stored.Unique, index.Unique))
+			}
+			if expected := normalizeDocument(index.Weights); len(expected) > 0 && !reflect.DeepEqual(stored.Weights, expected) {
+				problems = append(problems, fmt.Sprintf("index %s.%s has weights %v, expected %v", schema.Name, index.Name, stored.Weights, expected))
+			}
+		}
+
+		specs, err := db.ListCollectionSpecifications(ctx, bson.M{"name": schema.Name})
+		if err != nil {
+			return err
+		}
+		var validator bson.Raw
+		if len(specs) > 0 && specs[0].Options != nil {
+			validator, _ = specs[0].Options.Lookup("validator").DocumentOK()
+		}
+		if len(validator) == 0 {
+			problems = append(problems, fmt.Sprintf("collection %s has no validator", schema.Name))
+			continue
+		}
+		if same, err := sameDocument(validator, schema.Validator); err != nil {
+			return err
+		} else if !same {
+			problems = append(problems, fmt.Sprintf("collection %s has another validator than declared", schema.Name))
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %v", ErrSchemaOutdated, problems)
+	}
+	return nil
+}
+
+// storedIndex is an index as listed by the server
+type storedIndex struct {
+	Keys    bson.D
+	Unique  bool
+	Weights map[string]any
+}
+
+// listIndexes returns every index of a collection by name
+func listIndexes(ctx context.Context, collection *mongo.Collection) (map[string]storedIndex, error) {
+	cursor, err := collection.Indexes().List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the %s indexes: %w", collection.Name(), err)
+	}
+	defer cursor.Close(ctx)
+
+	var indexes []struct {
+		Name    string `bson:"name"`
+		Key     bson.D `bson:"key"`
+		Unique  bool   `bson:"unique"`
+		Weights bson.D `bson:"weights"`
+	}
+	if err := cursor.All(ctx, &indexes); err != nil {
+		return nil, err
+	}
+
+	stored := make(map[string]storedIndex, len(indexes))
+	for _, index := range indexes {
+		// Keys are decoded as int32 while declarations use int
+		keys := make(bson.D, 0, len(index.Key))
+		for _, key := range index.Key {
+			keys = append(keys, bson.E{Key: key.Key, Value: normalizeValue(key.Value)})
+		}
+		stored[index.Name] = storedIndex{
+			Keys:    keys,
+			Unique:  index.Unique,
+			Weights: normalizeDocument(index.Weights),
+		}
+	}
+	return stored, nil
+}
+
+// sameDocument returns true if a stored document holds the same fields and
+// values as a declared one, whatever the order of the fields and the integer
+// types of the values
+func sameDocument(stored bson.Raw, declared bson.M) (bool, error) {
+	encoded, err := bson.Marshal(declared)
+	if err != nil {
+		return false, err
+	}
+	var storedValue, declaredValue bson.D
+	if err := bson.Unmarshal(stored, &storedValue); err != nil {
+		return false, err
+	}
+	if err := bson.Unmarshal(encoded, &declaredValue); err != nil {
+		return false, err
+	}
+	return reflect.DeepEqual(normalizeValue(storedValue), normalizeValue(declaredValue)), nil
+}
+
+// normalizeDocument converts a document to a map of normalized values, or nil
+// when it is empty
+func normalizeDocument(document bson.D) map[string]any {
+	if len(document) == 0 {
+		return nil
+	}
+	return normalizeValue(document).(map[string]any)
+}
+
+// normalizeValue converts documents to maps and every number to an int when
+// it has no fraction, so values compare equal however they were encoded
+func normalizeValue(value any) any {
+	switch v := value.(type) {
+	case bson.D:
+		normalized := make(map[string]any, len(v))
+		for _, element := range v {
+			normalized[element.Key] = normalizeValue(element.Value)
+		}
+		return normalized
+	case bson.M:
+		normalized := make(map[string]any, len(v))
+		for key, element := range v {
+			normalized[key] = normalizeValue(element)
+		}
+		return normalized
+	case bson.A:
+		normalized := make([]any, len(v))
+		for i, element := range v {
+			normalized[i] = normalizeValue(element)
+		}
+		return normalized
+	case int32:
+		return int(v)
+	case int64:
+		return int(v)
+	case float64:
+		if v == float64(int(v)) {
+			return int(v)
+		}
+		return v
+	default:
+		return value
+	}
+}
@@ -0,0 +1,163 @@
+package repositories_test
+
+import (
+	"context"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/frtasoniero/subsmanager/internal/config"
+	"github.com/frtasoniero/subsmanager/internal/domain/repositories/repotest"
+	"github.com/frtasoniero/subsmanager/internal/infrastructure/database"
+	"github.com/frtasoniero/subsmanager/internal/infrastructure/database/migrate"
+	"github.com/frtasoniero/subsmanager/internal/infrastructure/database/repositories"
+	"github.com/frtasoniero/subsmanager/internal/infrastructure/database/seeds"
+	"github.com/frtasoniero/subsmanager/internal/infrastructure/database/sqlstore"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// connectTimeout bounds the probe of a database server, so the suite skips
+// quickly when none is running
+const connectTimeout = 2 * time.Second
+
+// contractSchema is the PostgreSQL schema the contract suite runs in
+const contractSchema = "subs_contract"
+
+func TestMemoryContract(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) repotest.Repositories {
+		store := repositories.NewMemoryStore()
+		return repotest.Repositories{
+			Users:         repositories.NewMemoryUserRepository(store),
+			Products:      repositories.NewMemoryProductRepository(store),
+			Subscriptions: repositories.NewMemorySubscriptionRepository(store),
+		}
+	})
+}
+
+// TestSQLiteContract migrates a database in a temporary file and empties its
+// tables before every check
+func TestSQLiteContract(t *testing.T) {
+	db, err := sqlstore.Open(sqlstore.Config{
+		Driver: sqlstore.DriverSQLite,
+		DSN:    filepath.Join(t.TempDir(), "contract.db"),
+	})
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	migrateSQL(t, db)
+
+	repotest.Run(t, sqlBackend(db, `DELETE FROM subscriptions`, `DELETE FROM users`, `DELETE FROM products`))
+}
+
+// TestPostgresContract migrates a scratch schema of POSTGRES_DSN and empties
+// its tables before every check. It is skipped when no server is reachable.
+func TestPostgresContract(t *testing.T) {
+	cfg := config.Load()
+	admin, err := sqlstore.Open(sqlstore.Config{Driver: sqlstore.DriverPostgres, DSN: cfg.Postgres.DSN, Timeout: connectTimeout})
+	if err != nil {
+		t.Skipf("PostgreSQL is not reachable: %v", err)
+	}
+	t.Cleanup(func() { admin.Close() })
+
+	ctx := context.Background()
+	if _, err := admin.ExecContext(ctx, `DROP SCHEMA IF EXISTS `+contractSchema+` CASCADE; CREATE SCHEMA `+contractSchema); err != nil {
+		t.Fatalf("reset schema %s: %v", contractSchema, err)
+	}
+	t.Cleanup(func() { admin.ExecContext(ctx, `DROP SCHEMA IF EXISTS `+contractSchema+` CASCADE`) })
+
+	db, err := sqlstore.Open(sqlstore.Config{
+		Driver:  sqlstore.DriverPostgres,
+		DSN:     withSearchPath(cfg.Postgres.DSN, contractSchema),
+		Timeout: cfg.Postgres.Timeout,
+	})
+	if err != nil {
+		t.Fatalf("open schema %s: %v", contractSchema, err)
+	}
+	t.Cleanup(func() { db.Close() })
+	migrateSQL(t, db)
+
+	repotest.Run(t, sqlBackend(db, `TRUNCATE users, products, subscriptions`))
+}
+
+// TestMongoContract migrates a scratch database named after MONGO_DB_NAME
+// with a _contract suffix, so unique indexes are in place, and empties its
+// collections before every check. It is skipped when no server is reachable.
+func TestMongoContract(t *testing.T) {
+	cfg := config.Load()
+	client, db, err := database.NewConnection(database.Config{
+		URI:      cfg.Database.URI,
+		Database: cfg.Database.Name + "_contract",
+		Timeout:  connectTimeout,
+	})
+	if err != nil {
+		t.Skipf("MongoDB is not reachable: %v", err)
+	}
+	t.Cleanup(func() { database.Close(client) })
+
+	ctx := context.Background()
+	if err := db.Drop(ctx); err != nil {
+		t.Fatalf("reset %s: %v", db.Name(), err)
+	}
+	t.Cleanup(func() { db.Drop(ctx) })
+	if _, err := migrate.NewMigrator(db).Up(ctx); err != nil {
+		t.Fatalf("migrate %s: %v", db.Name(), err)
+	}
+
+	repotest.Run(t, func(t *testing.T) repotest.Repositories {
+		for _, name := range seeds.Collections {
+			if _, err := db.Collection(name).DeleteMany(t.Context(), bson.M{}); err != nil {
+				t.Fatalf("empty %s: %v", name, err)
+			}
+		}
+		return repotest.Repositories{
+			Users:         repositories.NewMongoUserRepository(db),
+			Products:      repositories.NewMongoProductRepository(db),
+			Subscriptions: repositories.NewMongoSubscriptionRepository(db),
+		}
+	})
+}
+
+func migrateSQL(t *testing.T, db *sqlstore.DB) {
+	t.Helper()
+	migrator, err := sqlstore.NewMigrator(db)
+	if err != nil {
+		t.Fatalf("migrator: %v", err)
+	}
+	if _, err := migrator.Up(context.Background()); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+}
+
+// sqlBackend runs the reset statements before returning the repositories
+func sqlBackend(db *sqlstore.DB, reset ...string) repotest.Backend {
+	return func(t *testing.T) repotest.Repositories {
+		for _, statement := range reset {
+			if _, err := db.ExecContext(t.Context(), statement); err != nil {
+				t.Fatalf("%s: %v", statement, err)
+			}
+		}
+		return repotest.Repositories{
+			Users:         repositories.NewSQLUserRepository(db),
+			Products:      repositories.NewSQLProductRepository(db),
+			Subscriptions: repositories.NewSQLSubscriptionRepository(db),
+		}
+	}
+}
+
+// withSearchPath sets the schema of a PostgreSQL URL or keyword/value DSN
+func withSearchPath(dsn, schema string) string {
+	if !strings.Contains(dsn, "://") {
+		return dsn + " search_path=" + schema
+	}
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return dsn
+	}
+	query := u.Query()
+	query.Set("search_path", schema)
+	u.RawQuery = query.Encode()
+	return u.String()
+}
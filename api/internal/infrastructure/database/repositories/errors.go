@@ -0,0 +1,65 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/frtasoniero/subsmanager/internal/domain/entities"
+	"github.com/frtasoniero/subsmanager/internal/domain/repositories"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// documentValidationFailure is the MongoDB error code of a write rejected by
+// the $jsonSchema validator of a collection
+const documentValidationFailure = 121
+
+// newID returns id, or a new ID when id is not set. Every backend assigns IDs
+// itself, so they have the same format whatever the storage.
+func newID(id entities.ID) entities.ID {
+	if id.IsZero() {
+		return entities.NewID()
+	}
+	return id
+}
+
+// mapWriteError converts MongoDB write errors to the domain repository errors
+func mapWriteError(err error) error {
+	if mongo.IsDuplicateKeyError(err) {
+		return fmt.Errorf("%w: %v", repositories.ErrDuplicate, err)
+	}
+	var serverErr mongo.ServerError
+	if errors.As(err, &serverErr) && serverErr.HasErrorCode(documentValidationFailure) {
+		return fmt.Errorf("%w: %v", repositories.ErrInvalid, err)
+	}
+	return err
+}
+
+// mapReadError converts a missing document to repositories.ErrNotFound
+func mapReadError(err error) error {
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return repositories.ErrNotFound
+	}
+	return err
+}
+
+// updateResult returns repositories.ErrNotFound when an update matched no document
+func updateResult(result *mongo.UpdateResult, err error) error {
+	if err != nil {
+		return mapWriteError(err)
+	}
+	if result.MatchedCount == 0 {
+		return repositories.ErrNotFound
+	}
+	return nil
+}
+
+// deleteResult returns repositories.ErrNotFound when a delete matched no document
+func deleteResult(result *mongo.DeleteResult, err error) error {
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return repositories.ErrNotFound
+	}
+	return nil
+}
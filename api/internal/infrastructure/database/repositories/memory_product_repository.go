@@ -0,0 +1,114 @@
+package repositories
+
+import (
+	"context"
+	"slices"
+
+	"github.com/frtasoniero/subsmanager/internal/domain/entities"
+	"github.com/frtasoniero/subsmanager/internal/domain/repositories"
+	"github.com/frtasoniero/subsmanager/pkg/textsearch"
+)
+
+type MemoryProductRepository struct {
+	store *MemoryStore
+}
+
+func NewMemoryProductRepository(store *MemoryStore) *MemoryProductRepository {
+	return &MemoryProductRepository{
+		store: store,
+	}
+}
+
+func (r *MemoryProductRepository) Create(ctx context.Context, product *entities.Product) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	id := newID(product.ID)
+	if _, exists := r.store.products.get(id); exists || r.conflicts(product, id) {
+		return repositories.ErrDuplicate
+	}
+	product.ID = id
+	r.store.products.put(id, *product)
+	return nil
+}
+
+func (r *MemoryProductRepository) GetByID(ctx context.Context, id entities.ID) (*entities.Product, error) {
+	return r.findOne(func(p *entities.Product) bool { return p.ID == id })
+}
+
+func (r *MemoryProductRepository) GetByIDs(ctx context.Context, ids []entities.ID) ([]*entities.Product, error) {
+	return r.find(func(p *entities.Product) bool { return slices.Contains(ids, p.ID) })
+}
+
+func (r *MemoryProductRepository) GetByName(ctx context.Context, name string) (*entities.Product, error) {
+	return r.findOne(func(p *entities.Product) bool { return p.Name == name })
+}
+
+func (r *MemoryProductRepository) GetByCategory(ctx context.Context, category string) ([]*entities.Product, error) {
+	return r.find(func(p *entities.Product) bool { return p.Category == category })
+}
+
+func (r *MemoryProductRepository) GetAll(ctx context.Context) ([]*entities.Product, error) {
+	return r.find(nil)
+}
+
+func (r *MemoryProductRepository) GetActive(ctx context.Context) ([]*entities.Product, error) {
+	return r.find(func(p *entities.Product) bool { return p.Status == "active" })
+}
+
+func (r *MemoryProductRepository) Search(ctx context.Context, text string, limit int) ([]*repositories.ProductMatch, error) {
+	products, _ := r.find(nil)
+	return rankProducts(products, textsearch.Terms(text), limit), nil
+}
+
+func (r *MemoryProductRepository) Update(ctx context.Context, product *entities.Product) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, exists := r.store.products.get(product.ID); !exists {
+		return repositories.ErrNotFound
+	}
+	if r.conflicts(product, product.ID) {
+		return repositories.ErrDuplicate
+	}
+	r.store.products.put(product.ID, *product)
+	return nil
+}
+
+func (r *MemoryProductRepository) Delete(ctx context.Context, id entities.ID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if !r.store.products.delete(id) {
+		return repositories.ErrNotFound
+	}
+	return nil
+}
+
+func (r *MemoryProductRepository) Count(ctx context.Context) (int64, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	return r.store.products.count(), nil
+}
+
+// conflicts reports whether another product has the same name, mirroring the
+// unique index of the products collection
+func (r *MemoryProductRepository) conflicts(product *entities.Product, id entities.ID) bool {
+	return len(r.store.products.filter(func(p *entities.Product) bool {
+		return p.ID != id && p.Name == product.Name
+	})) > 0
+}
+
+func (r *MemoryProductRepository) findOne(match func(*entities.Product) bool) (*entities.Product, error) {
+	products, _ := r.find(match)
+	if len(products) == 0 {
+		return nil, repositories.ErrNotFound
+	}
+	return products[0], nil
+}
+
+func (r *MemoryProductRepository) find(match func(*entities.Product) bool) ([]*entities.Product, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	return r.store.products.filter(match), nil
+}
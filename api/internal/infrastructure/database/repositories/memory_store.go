@@ -0,0 +1,93 @@
+package repositories
+
+import (
+	"sync"
+	"time"
+
+	"github.com/frtasoniero/subsmanager/internal/domain/entities"
+)
+
+// MemoryStore holds the data of the in-memory repositories. The repositories
+// of one store share a lock, so joins see a consistent state and the unique
+// constraints of the MongoDB indexes can be enforced.
+type MemoryStore struct {
+	mu            sync.RWMutex
+	users         *memoryTable[entities.User]
+	products      *memoryTable[entities.Product]
+	subscriptions *memoryTable[entities.Subscription]
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		users:         newMemoryTable[entities.User](),
+		products:      newMemoryTable[entities.Product](),
+		subscriptions: newMemoryTable[entities.Subscription](),
+	}
+}
+
+// memoryTable keeps copies of the stored entities in insertion order, the
+// order in which MongoDB returns documents without a sort
+type memoryTable[T any] struct {
+	rows  map[entities.ID]*T
+	order []entities.ID
+}
+
+func newMemoryTable[T any]() *memoryTable[T] {
+	return &memoryTable[T]{rows: make(map[entities.ID]*T)}
+}
+
+func (t *memoryTable[T]) get(id entities.ID) (T, bool) {
+	row, ok := t.rows[id]
+	if !ok {
+		var zero T
+		return zero, false
+	}
+	return *row, true
+}
+
+func (t *memoryTable[T]) put(id entities.ID, value T) {
+	if _, exists := t.rows[id]; !exists {
+		t.order = append(t.order, id)
+	}
+	t.rows[id] = &value
+}
+
+func (t *memoryTable[T]) delete(id entities.ID) bool {
+	if _, exists := t.rows[id]; !exists {
+		return false
+	}
+	delete(t.rows, id)
+	for i, existing := range t.order {
+		if existing == id {
+			t.order = append(t.order[:i], t.order[i+1:]...)
+			break
+		}
+	}
+	return true
+}
+
+// filter returns copies of the rows matching keep, in insertion order
+func (t *memoryTable[T]) filter(keep func(*T) bool) []*T {
+	var rows []*T
+	for _, id := range t.order {
+		row := t.rows[id]
+		if keep == nil || keep(row) {
+			value := *row
+			rows = append(rows, &value)
+		}
+	}
+	return rows
+}
+
+func (t *memoryTable[T]) count() int64 {
+	return int64(len(t.rows))
+}
+
+// cloneTime copies an optional time so stored entities do not share it with callers
+func cloneTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	value := *t
+	return &value
+}
@@ -0,0 +1,208 @@
+package repositories
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/frtasoniero/subsmanager/internal/domain/entities"
+	"github.com/frtasoniero/subsmanager/internal/domain/repositories"
+)
+
+type MemorySubscriptionRepository struct {
+	store *MemoryStore
+}
+
+func NewMemorySubscriptionRepository(store *MemoryStore) *MemorySubscriptionRepository {
+	return &MemorySubscriptionRepository{
+		store: store,
+	}
+}
+
+func (r *MemorySubscriptionRepository) Create(ctx context.Context, subscription *entities.Subscription) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	id := newID(subscription.ID)
+	if _, exists := r.store.subscriptions.get(id); exists {
+		return repositories.ErrDuplicate
+	}
+	subscription.ID = id
+	r.store.subscriptions.put(id, cloneSubscription(subscription))
+	return nil
+}
+
+func (r *MemorySubscriptionRepository) GetByID(ctx context.Context, id entities.ID) (*entities.Subscription, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	subscription, ok := r.store.subscriptions.get(id)
+	if !ok {
+		return nil, repositories.ErrNotFound
+	}
+	subscription = cloneSubscription(&subscription)
+	return &subscription, nil
+}
+
+func (r *MemorySubscriptionRepository) GetByUserID(ctx context.Context, userID entities.ID) ([]*entities.SubscriptionWithProduct, error) {
+	return r.Find(ctx, repositories.SubscriptionQuery{Filter: repositories.SubscriptionFilter{UserID: &userID}})
+}
+
+func (r *MemorySubscriptionRepository) GetByProductID(ctx context.Context, productID entities.ID) ([]*entities.Subscription, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	subscriptions := r.store.subscriptions.filter(func(s *entities.Subscription) bool { return s.ProductID == productID })
+	for i, subscription := range subscriptions {
+		clone := cloneSubscription(subscription)
+		subscriptions[i] = &clone
+	}
+	return subscriptions, nil
+}
+
+func (r *MemorySubscriptionRepository) GetAll(ctx context.Context) ([]*entities.SubscriptionWithProduct, error) {
+	return r.Find(ctx, repositories.SubscriptionQuery{})
+}
+
+func (r *MemorySubscriptionRepository) Find(ctx context.Context, query repositories.SubscriptionQuery) ([]*entities.SubscriptionWithProduct, error) {
+	filter := query.Filter
+	var page []*entities.SubscriptionWithProduct
+	for _, subscription := range r.join() {
+		switch {
+		case filter.UserID != nil && subscription.UserID != *filter.UserID,
+			len(filter.UserIDs) > 0 && !slices.Contains(filter.UserIDs, subscription.UserID),
+			filter.ProductID != nil && subscription.ProductID != *filter.ProductID,
+			filter.Status != "" && subscription.Status != filter.Status,
+			filter.Category != "" && subscription.Category != filter.Category,
+			filter.MinPrice != nil && subscription.Price < *filter.MinPrice,
+			filter.MaxPrice != nil && subscription.Price > *filter.MaxPrice,
+			!filter.NextBilling.Contains(subscription.NextBilling),
+			!filter.StartDate.Contains(subscription.StartDate),
+			!filter.CreatedAt.Contains(subscription.CreatedAt),
+			query.After != nil && compareKeys(repositories.KeyOf(query.Sort, subscription), *query.After, query.Descending) <= 0:
+			continue
+		}
+		page = append(page, subscription)
+	}
+
+	sort.Slice(page, func(i, j int) bool {
+		return compareKeys(repositories.KeyOf(query.Sort, page[i]), repositories.KeyOf(query.Sort, page[j]), query.Descending) < 0
+	})
+	if query.Limit > 0 && len(page) > query.Limit {
+		page = page[:query.Limit]
+	}
+	return page, nil
+}
+
+// Stream works on a snapshot, so fn may call the repositories
+func (r *MemorySubscriptionRepository) Stream(ctx context.Context, fn func(*entities.SubscriptionWithProduct) error) error {
+	for _, subscription := range r.join() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := fn(subscription); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *MemorySubscriptionRepository) GetActive(ctx context.Context) ([]*entities.SubscriptionWithProduct, error) {
+	return r.Find(ctx, repositories.SubscriptionQuery{Filter: repositories.SubscriptionFilter{Status: "active"}})
+}
+
+func (r *MemorySubscriptionRepository) GetExpiring(ctx context.Context, days int) ([]*entities.SubscriptionWithProduct, error) {
+	return r.Find(ctx, expiringQuery(days))
+}
+
+func (r *MemorySubscriptionRepository) Update(ctx context.Context, subscription *entities.Subscription) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, exists := r.store.subscriptions.get(subscription.ID); !exists {
+		return repositories.ErrNotFound
+	}
+	r.store.subscriptions.put(subscription.ID, cloneSubscription(subscription))
+	return nil
+}
+
+func (r *MemorySubscriptionRepository) Delete(ctx context.Context, id entities.ID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if !r.store.subscriptions.delete(id) {
+		return repositories.ErrNotFound
+	}
+	return nil
+}
+
+func (r *MemorySubscriptionRepository) Count(ctx context.Context) (int64, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	return r.store.subscriptions.count(), nil
+}
+
+// join returns the subscriptions with their product. Like the $lookup and
+// $unwind stages of the Mongo repository, subscriptions whose product does
+// not exist are left out.
+func (r *MemorySubscriptionRepository) join() []*entities.SubscriptionWithProduct {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var joined []*entities.SubscriptionWithProduct
+	for _, subscription := range r.store.subscriptions.filter(nil) {
+		product, ok := r.store.products.get(subscription.ProductID)
+		if !ok {
+			continue
+		}
+		joined = append(joined, &entities.SubscriptionWithProduct{
+			ID:           subscription.ID,
+			UserID:       subscription.UserID,
+			ProductID:    subscription.ProductID,
+			ProductName:  product.Name,
+			Description:  product.Description,
+			Price:        product.Price,
+			BillingType:  product.BillingType,
+			Category:     product.Category,
+			PriceAtStart: subscription.PriceAtStart,
+			Status:       subscription.Status,
+			StartDate:    subscription.StartDate,
+			EndDate:      cloneTime(subscription.EndDate),
+			NextBilling:  subscription.NextBilling,
+			CreatedAt:    subscription.CreatedAt,
+		})
+	}
+	return joined
+}
+
+// compareKeys orders two subscription keys, reversed when descending
+func compareKeys(a, b repositories.SubscriptionKey, descending bool) int {
+	var order int
+	switch value := a.Value.(type) {
+	case time.Time:
+		order = value.Compare(b.Value.(time.Time))
+	case float64:
+		switch other := b.Value.(float64); {
+		case value < other:
+			order = -1
+		case value > other:
+			order = 1
+		}
+	}
+	if order == 0 {
+		order = strings.Compare(a.ID.String(), b.ID.String())
+	}
+	if descending {
+		return -order
+	}
+	return order
+}
+
+// cloneSubscription copies a subscription including its optional end date
+func cloneSubscription(subscription *entities.Subscription) entities.Subscription {
+	clone := *subscription
+	clone.EndDate = cloneTime(subscription.EndDate)
+	return clone
+}
@@ -0,0 +1,98 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/frtasoniero/subsmanager/internal/domain/entities"
+	"github.com/frtasoniero/subsmanager/internal/domain/repositories"
+)
+
+type MemoryUserRepository struct {
+	store *MemoryStore
+}
+
+func NewMemoryUserRepository(store *MemoryStore) *MemoryUserRepository {
+	return &MemoryUserRepository{
+		store: store,
+	}
+}
+
+func (r *MemoryUserRepository) Create(ctx context.Context, user *entities.User) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	id := newID(user.ID)
+	if _, exists := r.store.users.get(id); exists || r.conflicts(user, id) {
+		return repositories.ErrDuplicate
+	}
+	user.ID = id
+	r.store.users.put(id, *user)
+	return nil
+}
+
+func (r *MemoryUserRepository) GetByID(ctx context.Context, id entities.ID) (*entities.User, error) {
+	return r.findOne(func(u *entities.User) bool { return u.ID == id })
+}
+
+func (r *MemoryUserRepository) GetByEmail(ctx context.Context, email string) (*entities.User, error) {
+	return r.findOne(func(u *entities.User) bool { return u.Email == email })
+}
+
+func (r *MemoryUserRepository) GetByUsername(ctx context.Context, username string) (*entities.User, error) {
+	return r.findOne(func(u *entities.User) bool { return u.Username == username })
+}
+
+func (r *MemoryUserRepository) GetAll(ctx context.Context) ([]*entities.User, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	return r.store.users.filter(nil), nil
+}
+
+func (r *MemoryUserRepository) Update(ctx context.Context, user *entities.User) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, exists := r.store.users.get(user.ID); !exists {
+		return repositories.ErrNotFound
+	}
+	if r.conflicts(user, user.ID) {
+		return repositories.ErrDuplicate
+	}
+	r.store.users.put(user.ID, *user)
+	return nil
+}
+
+func (r *MemoryUserRepository) Delete(ctx context.Context, id entities.ID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if !r.store.users.delete(id) {
+		return repositories.ErrNotFound
+	}
+	return nil
+}
+
+func (r *MemoryUserRepository) Count(ctx context.Context) (int64, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	return r.store.users.count(), nil
+}
+
+// conflicts reports whether another user has the same email or username,
+// mirroring the unique indexes of the users collection
+func (r *MemoryUserRepository) conflicts(user *entities.User, id entities.ID) bool {
+	return len(r.store.users.filter(func(u *entities.User) bool {
+		return u.ID != id && (u.Email == user.Email || u.Username == user.Username)
+	})) > 0
+}
+
+func (r *MemoryUserRepository) findOne(match func(*entities.User) bool) (*entities.User, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	users := r.store.users.filter(match)
+	if len(users) == 0 {
+		return nil, repositories.ErrNotFound
+	}
+	return users[0], nil
+}
@@ -0,0 +1,124 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/frtasoniero/subsmanager/internal/domain/entities"
+	"github.com/frtasoniero/subsmanager/internal/domain/repositories"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type MongoProductRepository struct {
+	collection *mongo.Collection
+}
+
+func NewMongoProductRepository(db *mongo.Database) *MongoProductRepository {
+	return &MongoProductRepository{
+		collection: db.Collection("products"),
+	}
+}
+
+func (r *MongoProductRepository) Create(ctx context.Context, product *entities.Product) error {
+	document := *product
+	document.ID = newID(product.ID)
+	if _, err := r.collection.InsertOne(ctx, &document); err != nil {
+		return mapWriteError(err)
+	}
+	product.ID = document.ID
+	return nil
+}
+
+func (r *MongoProductRepository) GetByID(ctx context.Context, id entities.ID) (*entities.Product, error) {
+	return r.findOne(ctx, bson.M{"_id": id})
+}
+
+func (r *MongoProductRepository) GetByIDs(ctx context.Context, ids []entities.ID) ([]*entities.Product, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	return r.find(ctx, bson.M{"_id": bson.M{"$in": ids}})
+}
+
+func (r *MongoProductRepository) GetByName(ctx context.Context, name string) (*entities.Product, error) {
+	return r.findOne(ctx, bson.M{"name": name})
+}
+
+func (r *MongoProductRepository) GetByCategory(ctx context.Context, category string) ([]*entities.Product, error) {
+	return r.find(ctx, bson.M{"category": category})
+}
+
+func (r *MongoProductRepository) GetAll(ctx context.Context) ([]*entities.Product, error) {
+	return r.find(ctx, bson.M{})
+}
+
+func (r *MongoProductRepository) GetActive(ctx context.Context) ([]*entities.Product, error) {
+	return r.find(ctx, bson.M{"status": "active"})
+}
+
+// Search uses the text index of the collection, which stems the words of the
+// query and ranks the products by its weighted text score
+func (r *MongoProductRepository) Search(ctx context.Context, text string, limit int) ([]*repositories.ProductMatch, error) {
+	score := bson.M{"$meta": "textScore"}
+	opts := options.Find().
+		SetProjection(bson.M{"score": score}).
+		SetSort(bson.D{{Key: "score", Value: score}, {Key: "name", Value: 1}}).
+		SetLimit(int64(limit))
+	cursor, err := r.collection.Find(ctx, bson.M{"$text": bson.M{"$search": text}}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var documents []struct {
+		entities.Product `bson:",inline"`
+		Score            float64 `bson:"score"`
+	}
+	if err = cursor.All(ctx, &documents); err != nil {
+		return nil, err
+	}
+
+	matches := make([]*repositories.ProductMatch, 0, len(documents))
+	for _, document := range documents {
+		product := document.Product
+		matches = append(matches, &repositories.ProductMatch{Product: &product, Score: document.Score})
+	}
+	return matches, nil
+}
+
+func (r *MongoProductRepository) Update(ctx context.Context, product *entities.Product) error {
+	return updateResult(r.collection.UpdateOne(ctx, bson.M{"_id": product.ID}, bson.M{"$set": product}))
+}
+
+func (r *MongoProductRepository) Delete(ctx context.Context, id entities.ID) error {
+	return deleteResult(r.collection.DeleteOne(ctx, bson.M{"_id": id}))
+}
+
+func (r *MongoProductRepository) Count(ctx context.Context) (int64, error) {
+	return r.collection.CountDocuments(ctx, bson.M{})
+}
+
+func (r *MongoProductRepository) findOne(ctx context.Context, filter bson.M) (*entities.Product, error) {
+	var product entities.Product
+	err := r.collection.FindOne(ctx, filter).Decode(&product)
+	if err != nil {
+		return nil, mapReadError(err)
+	}
+	return &product, nil
+}
+
+func (r *MongoProductRepository) find(ctx context.Context, filter bson.M) ([]*entities.Product, error) {
+	cursor, err := r.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var products []*entities.Product
+	if err = cursor.All(ctx, &products); err != nil {
+		return nil, err
+	}
+
+	return products, nil
+}
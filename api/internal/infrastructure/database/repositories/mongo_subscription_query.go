@@ -0,0 +1,142 @@
+package repositories
+
+import (
+	"github.com/frtasoniero/subsmanager/internal/domain/repositories"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// subscriptionWithProductProjection shapes a subscription joined with its
+// product like entities.SubscriptionWithProduct
+var subscriptionWithProductProjection = bson.M{
+	"id":             "$_id",
+	"user_id":        1,
+	"product_id":     1,
+	"product_name":   "$product.name",
+	"description":    "$product.description",
+	"price":          "$product.price",
+	"billing_type":   "$product.billing_type",
+	"category":       "$product.category",
+	"price_at_start": 1,
+	"status":         1,
+	"start_date":     1,
+	"end_date":       1,
+	"next_billing":   1,
+	"created_at":     1,
+}
+
+// subscriptionPipeline builds the aggregation of a subscription query. It
+// filters and sorts subscriptions before the product lookup when it can, so
+// the next_billing and created_at indexes are used. $lookup and $unwind keep
+// the order of their input, and $unwind drops subscriptions whose product is
+// gone.
+func subscriptionPipeline(query repositories.SubscriptionQuery) []bson.M {
+	filter := query.Filter
+	subscriptionMatch := bson.M{}
+	user := bson.M{}
+	if filter.UserID != nil {
+		user["$eq"] = *filter.UserID
+	}
+	if len(filter.UserIDs) > 0 {
+		user["$in"] = filter.UserIDs
+	}
+	if len(user) > 0 {
+		subscriptionMatch["user_id"] = user
+	}
+	if filter.ProductID != nil {
+		subscriptionMatch["product_id"] = *filter.ProductID
+	}
+	if filter.Status != "" {
+		subscriptionMatch["status"] = filter.Status
+	}
+	matchDates(subscriptionMatch, "next_billing", filter.NextBilling)
+	matchDates(subscriptionMatch, "start_date", filter.StartDate)
+	matchDates(subscriptionMatch, "created_at", filter.CreatedAt)
+
+	productMatch := bson.M{}
+	if filter.Category != "" {
+		productMatch["product.category"] = filter.Category
+	}
+	price := bson.M{}
+	if filter.MinPrice != nil {
+		price["$gte"] = *filter.MinPrice
+	}
+	if filter.MaxPrice != nil {
+		price["$lte"] = *filter.MaxPrice
+	}
+	if len(price) > 0 {
+		productMatch["product.price"] = price
+	}
+
+	field, direction, comparison := "created_at", 1, "$gt"
+	switch query.Sort {
+	case repositories.SortNextBilling:
+		field = "next_billing"
+	case repositories.SortPrice:
+		field = "product.price"
+	}
+	if query.Descending {
+		direction, comparison = -1, "$lt"
+	}
+	sortStage := bson.M{"$sort": bson.D{{Key: field, Value: direction}, {Key: "_id", Value: direction}}}
+
+	// The keyset condition and the sort go where the sorted field is available
+	keyset := subscriptionMatch
+	if query.Sort == repositories.SortPrice {
+		keyset = productMatch
+	}
+	if query.After != nil {
+		keyset["$or"] = bson.A{
+			bson.M{field: bson.M{comparison: query.After.Value}},
+			bson.M{field: query.After.Value, "_id": bson.M{comparison: query.After.ID}},
+		}
+	}
+
+	var pipeline []bson.M
+	if len(subscriptionMatch) > 0 {
+		pipeline = append(pipeline, bson.M{"$match": subscriptionMatch})
+	}
+	if query.Sort != repositories.SortPrice {
+		pipeline = append(pipeline, sortStage)
+	}
+	pipeline = append(pipeline,
+		bson.M{
+			"$lookup": bson.M{
+				"from":         "products",
+				"localField":   "product_id",
+				"foreignField": "_id",
+				"as":           "product",
+			},
+		},
+		bson.M{
+			"$unwind": "$product",
+		},
+	)
+	if len(productMatch) > 0 {
+		pipeline = append(pipeline, bson.M{"$match": productMatch})
+	}
+	if query.Sort == repositories.SortPrice {
+		pipeline = append(pipeline, sortStage)
+	}
+	if query.Limit > 0 {
+		pipeline = append(pipeline, bson.M{"$limit": query.Limit})
+	}
+	return append(pipeline, bson.M{"$project": subscriptionWithProductProjection})
+}
+
+// matchDates adds the condition of a date range on field to match
+func matchDates(match bson.M, field string, dates repositories.DateRange) {
+	condition := bson.M{}
+	if dates.From != nil {
+		condition["$gte"] = *dates.From
+	}
+	if dates.To != nil {
+		if dates.ToInclusive {
+			condition["$lte"] = *dates.To
+		} else {
+			condition["$lt"] = *dates.To
+		}
+	}
+	if len(condition) > 0 {
+		match[field] = condition
+	}
+}
@@ -2,11 +2,10 @@ package repositories
 
 import (
 	"context"
-	"time"
 
 	"github.com/frtasoniero/subsmanager/internal/domain/entities"
+	"github.com/frtasoniero/subsmanager/internal/domain/repositories"
 	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
@@ -23,50 +22,30 @@ func NewMongoSubscriptionRepository(db *mongo.Database) *MongoSubscriptionReposi
 }
 
 func (r *MongoSubscriptionRepository) Create(ctx context.Context, subscription *entities.Subscription) error {
-	_, err := r.collection.InsertOne(ctx, subscription)
-	return err
+	document := *subscription
+	document.ID = newID(subscription.ID)
+	if _, err := r.collection.InsertOne(ctx, &document); err != nil {
+		return mapWriteError(err)
+	}
+	subscription.ID = document.ID
+	return nil
 }
 
-func (r *MongoSubscriptionRepository) GetByID(ctx context.Context, id primitive.ObjectID) (*entities.Subscription, error) {
+func (r *MongoSubscriptionRepository) GetByID(ctx context.Context, id entities.ID) (*entities.Subscription, error) {
 	var subscription entities.Subscription
 	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&subscription)
 	if err != nil {
-		return nil, err
+		return nil, mapReadError(err)
 	}
 	return &subscription, nil
 }
 
 func (r *MongoSubscriptionRepository) GetAll(ctx context.Context) ([]*entities.SubscriptionWithProduct, error) {
-	pipeline := []bson.M{
-		{
-			"$lookup": bson.M{
-				"from":         "products",
-				"localField":   "product_id",
-				"foreignField": "_id",
-				"as":           "product",
-			},
-		},
-		{
-			"$unwind": "$product",
-		},
-		{
-			"$project": bson.M{
-				"id":           "$_id",
-				"user_id":      1,
-				"product_id":   1,
-				"product_name": "$product.name",
-				"description":  "$product.description",
-				"price":        "$product.price",
-				"status":       1,
-				"start_date":   1,
-				"end_date":     1,
-				"next_billing": 1,
-				"created_at":   1,
-			},
-		},
-	}
+	return r.Find(ctx, repositories.SubscriptionQuery{})
+}
 
-	cursor, err := r.collection.Aggregate(ctx, pipeline)
+func (r *MongoSubscriptionRepository) Find(ctx context.Context, query repositories.SubscriptionQuery) ([]*entities.SubscriptionWithProduct, error) {
+	cursor, err := r.collection.Aggregate(ctx, subscriptionPipeline(query))
 	if err != nil {
 		return nil, err
 	}
@@ -80,64 +59,39 @@ func (r *MongoSubscriptionRepository) GetAll(ctx context.Context) ([]*entities.S
 	return subscriptions, nil
 }
 
-func (r *MongoSubscriptionRepository) GetByUserID(ctx context.Context, userID primitive.ObjectID) ([]*entities.SubscriptionWithProduct, error) {
-	pipeline := []bson.M{
-		{
-			"$match": bson.M{"user_id": userID},
-		},
-		{
-			"$lookup": bson.M{
-				"from":         "products",
-				"localField":   "product_id",
-				"foreignField": "_id",
-				"as":           "product",
-			},
-		},
-		{
-			"$unwind": "$product",
-		},
-		{
-			"$project": bson.M{
-				"id":           "$_id",
-				"user_id":      1,
-				"product_id":   1,
-				"product_name": "$product.name",
-				"description":  "$product.description",
-				"price":        "$product.price",
-				"status":       1,
-				"start_date":   1,
-				"end_date":     1,
-				"next_billing": 1,
-				"created_at":   1,
-			},
-		},
-	}
-
-	cursor, err := r.collection.Aggregate(ctx, pipeline)
+func (r *MongoSubscriptionRepository) Stream(ctx context.Context, fn func(*entities.SubscriptionWithProduct) error) error {
+	cursor, err := r.collection.Aggregate(ctx, subscriptionPipeline(repositories.SubscriptionQuery{}))
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer cursor.Close(ctx)
 
-	var subscriptions []*entities.SubscriptionWithProduct
-	if err = cursor.All(ctx, &subscriptions); err != nil {
-		return nil, err
+	for cursor.Next(ctx) {
+		var subscription entities.SubscriptionWithProduct
+		if err := cursor.Decode(&subscription); err != nil {
+			return err
+		}
+		if err := fn(&subscription); err != nil {
+			return err
+		}
 	}
 
-	return subscriptions, nil
+	return cursor.Err()
+}
+
+func (r *MongoSubscriptionRepository) GetByUserID(ctx context.Context, userID entities.ID) ([]*entities.SubscriptionWithProduct, error) {
+	return r.Find(ctx, repositories.SubscriptionQuery{Filter: repositories.SubscriptionFilter{UserID: &userID}})
 }
 
 func (r *MongoSubscriptionRepository) Update(ctx context.Context, subscription *entities.Subscription) error {
-	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": subscription.ID}, bson.M{"$set": subscription})
-	return err
+	return updateResult(r.collection.UpdateOne(ctx, bson.M{"_id": subscription.ID}, bson.M{"$set": subscription}))
 }
 
-func (r *MongoSubscriptionRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
-	_, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
-	return err
+func (r *MongoSubscriptionRepository) Delete(ctx context.Context, id entities.ID) error {
+	return deleteResult(r.collection.DeleteOne(ctx, bson.M{"_id": id}))
 }
 
-func (r *MongoSubscriptionRepository) GetByProductID(ctx context.Context, productID primitive.ObjectID) ([]*entities.Subscription, error) {
+func (r *MongoSubscriptionRepository) GetByProductID(ctx context.Context, productID entities.ID) ([]*entities.Subscription, error) {
 	cursor, err := r.collection.Find(ctx, bson.M{"product_id": productID})
 	if err != nil {
 		return nil, err
@@ -153,105 +107,11 @@ func (r *MongoSubscriptionRepository) GetByProductID(ctx context.Context, produc
 }
 
 func (r *MongoSubscriptionRepository) GetActive(ctx context.Context) ([]*entities.SubscriptionWithProduct, error) {
-	pipeline := []bson.M{
-		{
-			"$match": bson.M{"status": "active"},
-		},
-		{
-			"$lookup": bson.M{
-				"from":         "products",
-				"localField":   "product_id",
-				"foreignField": "_id",
-				"as":           "product",
-			},
-		},
-		{
-			"$unwind": "$product",
-		},
-		{
-			"$project": bson.M{
-				"id":           "$_id",
-				"user_id":      1,
-				"product_id":   1,
-				"product_name": "$product.name",
-				"description":  "$product.description",
-				"price":        "$product.price",
-				"status":       1,
-				"start_date":   1,
-				"end_date":     1,
-				"next_billing": 1,
-				"created_at":   1,
-			},
-		},
-	}
-
-	cursor, err := r.collection.Aggregate(ctx, pipeline)
-	if err != nil {
-		return nil, err
-	}
-	defer cursor.Close(ctx)
-
-	var subscriptions []*entities.SubscriptionWithProduct
-	if err = cursor.All(ctx, &subscriptions); err != nil {
-		return nil, err
-	}
-
-	return subscriptions, nil
+	return r.Find(ctx, repositories.SubscriptionQuery{Filter: repositories.SubscriptionFilter{Status: "active"}})
 }
 
 func (r *MongoSubscriptionRepository) GetExpiring(ctx context.Context, days int) ([]*entities.SubscriptionWithProduct, error) {
-	// Calculate the date threshold
-	threshold := time.Now().AddDate(0, 0, days)
-
-	pipeline := []bson.M{
-		{
-			"$match": bson.M{
-				"status": "active",
-				"next_billing": bson.M{
-					"$lte": threshold,
-				},
-			},
-		},
-		{
-			"$lookup": bson.M{
-				"from":         "products",
-				"localField":   "product_id",
-				"foreignField": "_id",
-				"as":           "product",
-			},
-		},
-		{
-			"$unwind": "$product",
-		},
-		{
-			"$project": bson.M{
-				"id":           "$_id",
-				"user_id":      1,
-				"product_id":   1,
-				"product_name": "$product.name",
-				"description":  "$product.description",
-				"price":        "$product.price",
-				"status":       1,
-				"start_date":   1,
-				"end_date":     1,
-				"next_billing": 1,
-				"created_at":   1,
-			},
-		},
-	}
-
-	cursor, err := r.collection.Aggregate(ctx, pipeline)
-	if err != nil {
-		return nil, err
-	}
-	defer cursor.Close(ctx)
-
-	var subscriptions []*entities.SubscriptionWithProduct
-	if err = cursor.All(ctx, &subscriptions); err != nil {
-		return nil, err
-	}
-
-	return subscriptions, nil
+	return r.Find(ctx, expiringQuery(days))
 }
 
 func (r *MongoSubscriptionRepository) Count(ctx context.Context) (int64, error) {
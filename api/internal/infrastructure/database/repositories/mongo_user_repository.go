@@ -0,0 +1,77 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/frtasoniero/subsmanager/internal/domain/entities"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type MongoUserRepository struct {
+	collection *mongo.Collection
+}
+
+func NewMongoUserRepository(db *mongo.Database) *MongoUserRepository {
+	return &MongoUserRepository{
+		collection: db.Collection("users"),
+	}
+}
+
+func (r *MongoUserRepository) Create(ctx context.Context, user *entities.User) error {
+	document := *user
+	document.ID = newID(user.ID)
+	if _, err := r.collection.InsertOne(ctx, &document); err != nil {
+		return mapWriteError(err)
+	}
+	user.ID = document.ID
+	return nil
+}
+
+func (r *MongoUserRepository) GetByID(ctx context.Context, id entities.ID) (*entities.User, error) {
+	return r.findOne(ctx, bson.M{"_id": id})
+}
+
+func (r *MongoUserRepository) GetByEmail(ctx context.Context, email string) (*entities.User, error) {
+	return r.findOne(ctx, bson.M{"email": email})
+}
+
+func (r *MongoUserRepository) GetByUsername(ctx context.Context, username string) (*entities.User, error) {
+	return r.findOne(ctx, bson.M{"username": username})
+}
+
+func (r *MongoUserRepository) GetAll(ctx context.Context) ([]*entities.User, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var users []*entities.User
+	if err = cursor.All(ctx, &users); err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+func (r *MongoUserRepository) Update(ctx context.Context, user *entities.User) error {
+	return updateResult(r.collection.UpdateOne(ctx, bson.M{"_id": user.ID}, bson.M{"$set": user}))
+}
+
+func (r *MongoUserRepository) Delete(ctx context.Context, id entities.ID) error {
+	return deleteResult(r.collection.DeleteOne(ctx, bson.M{"_id": id}))
+}
+
+func (r *MongoUserRepository) Count(ctx context.Context) (int64, error) {
+	return r.collection.CountDocuments(ctx, bson.M{})
+}
+
+func (r *MongoUserRepository) findOne(ctx context.Context, filter bson.M) (*entities.User, error) {
+	var user entities.User
+	err := r.collection.FindOne(ctx, filter).Decode(&user)
+	if err != nil {
+		return nil, mapReadError(err)
+	}
+	return &user, nil
+}
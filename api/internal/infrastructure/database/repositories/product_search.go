@@ -0,0 +1,47 @@
+package repositories
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/frtasoniero/subsmanager/internal/domain/entities"
+	"github.com/frtasoniero/subsmanager/internal/domain/repositories"
+	"github.com/frtasoniero/subsmanager/pkg/textsearch"
+)
+
+// Weights of the product fields in searches. They match the weights of the
+// text index of the products collection.
+const (
+	productNameWeight        = 10
+	productCategoryWeight    = 5
+	productDescriptionWeight = 1
+)
+
+// rankProducts scores products against the terms of a search, for the
+// backends without a text index, and keeps the best limit matches
+func rankProducts(products []*entities.Product, terms []string, limit int) []*repositories.ProductMatch {
+	var matches []*repositories.ProductMatch
+	for _, product := range products {
+		score := textsearch.Score(terms,
+			textsearch.Field{Text: product.Name, Weight: productNameWeight},
+			textsearch.Field{Text: product.Category, Weight: productCategoryWeight},
+			textsearch.Field{Text: product.Description, Weight: productDescriptionWeight},
+		)
+		if score > 0 {
+			matches = append(matches, &repositories.ProductMatch{Product: product, Score: score})
+		}
+	}
+	slices.SortStableFunc(matches, func(a, b *repositories.ProductMatch) int {
+		if a.Score != b.Score {
+			if a.Score > b.Score {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a.Product.Name, b.Product.Name)
+	})
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches
+}
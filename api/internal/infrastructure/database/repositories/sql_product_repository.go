@@ -0,0 +1,149 @@
+package repositories
+
+import (
+	"context"
+	"strings"
+
+	"github.com/frtasoniero/subsmanager/internal/domain/entities"
+	"github.com/frtasoniero/subsmanager/internal/domain/repositories"
+	"github.com/frtasoniero/subsmanager/internal/infrastructure/database/sqlstore"
+	"github.com/frtasoniero/subsmanager/pkg/textsearch"
+)
+
+const productColumns = `id, name, description, price, billing_type, category, status, created_at, updated_at`
+
+type SQLProductRepository struct {
+	db sqlstore.Handle
+}
+
+func NewSQLProductRepository(db sqlstore.Handle) *SQLProductRepository {
+	return &SQLProductRepository{db: db}
+}
+
+func (r *SQLProductRepository) Create(ctx context.Context, product *entities.Product) error {
+	id := newID(product.ID)
+	_, err := r.db.ExecContext(ctx, r.db.Rebind(`INSERT INTO products (`+productColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`),
+		append([]any{id.String()}, r.values(product)...)...)
+	if err != nil {
+		return sqlWriteError(r.db, err)
+	}
+	product.ID = id
+	return nil
+}
+
+func (r *SQLProductRepository) GetByID(ctx context.Context, id entities.ID) (*entities.Product, error) {
+	return r.findOne(ctx, `id = ?`, id.String())
+}
+
+func (r *SQLProductRepository) GetByIDs(ctx context.Context, ids []entities.ID) ([]*entities.Product, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	args := make([]any, len(ids))
+	for i, id := range ids {
+		args[i] = id.String()
+	}
+	return r.find(ctx, `WHERE id IN (?`+strings.Repeat(`, ?`, len(ids)-1)+`)`, args...)
+}
+
+func (r *SQLProductRepository) GetByName(ctx context.Context, name string) (*entities.Product, error) {
+	return r.findOne(ctx, `name = ?`, name)
+}
+
+func (r *SQLProductRepository) GetByCategory(ctx context.Context, category string) ([]*entities.Product, error) {
+	return r.find(ctx, `WHERE category = ?`, category)
+}
+
+func (r *SQLProductRepository) GetAll(ctx context.Context) ([]*entities.Product, error) {
+	return r.find(ctx, ``)
+}
+
+func (r *SQLProductRepository) GetActive(ctx context.Context) ([]*entities.Product, error) {
+	return r.find(ctx, `WHERE status = ?`, "active")
+}
+
+// Search narrows the products down in SQL to those containing a term stem and
+// ranks them like the memory backend
+func (r *SQLProductRepository) Search(ctx context.Context, text string, limit int) ([]*repositories.ProductMatch, error) {
+	terms := textsearch.Terms(text)
+	if len(terms) == 0 {
+		return nil, nil
+	}
+	var conditions []string
+	var args []any
+	for _, term := range terms {
+		pattern := "%" + textsearch.Stem(term) + "%"
+		conditions = append(conditions, `LOWER(name) LIKE ? OR LOWER(category) LIKE ? OR LOWER(description) LIKE ?`)
+		args = append(args, pattern, pattern, pattern)
+	}
+	products, err := r.find(ctx, `WHERE `+strings.Join(conditions, ` OR `), args...)
+	if err != nil {
+		return nil, err
+	}
+	return rankProducts(products, terms, limit), nil
+}
+
+func (r *SQLProductRepository) Update(ctx context.Context, product *entities.Product) error {
+	query := `UPDATE products SET name = ?, description = ?, price = ?, billing_type = ?, category = ?, status = ?,
+		created_at = ?, updated_at = ? WHERE id = ?`
+	result, err := r.db.ExecContext(ctx, r.db.Rebind(query), append(r.values(product), product.ID.String())...)
+	return sqlAffected(r.db, result, err)
+}
+
+func (r *SQLProductRepository) Delete(ctx context.Context, id entities.ID) error {
+	result, err := r.db.ExecContext(ctx, r.db.Rebind(`DELETE FROM products WHERE id = ?`), id.String())
+	return sqlAffected(r.db, result, err)
+}
+
+func (r *SQLProductRepository) Count(ctx context.Context) (int64, error) {
+	var count int64
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM products`).Scan(&count)
+	return count, err
+}
+
+// values returns the columns of a product after its ID, in the order of productColumns
+func (r *SQLProductRepository) values(product *entities.Product) []any {
+	return []any{
+		product.Name, product.Description, product.Price, product.BillingType, product.Category, product.Status,
+		r.db.Time(product.CreatedAt), r.db.Time(product.UpdatedAt),
+	}
+}
+
+func (r *SQLProductRepository) findOne(ctx context.Context, where string, args ...any) (*entities.Product, error) {
+	row := r.db.QueryRowContext(ctx, r.db.Rebind(`SELECT `+productColumns+` FROM products WHERE `+where), args...)
+	product, err := scanProduct(row)
+	if err != nil {
+		return nil, sqlReadError(err)
+	}
+	return product, nil
+}
+
+func (r *SQLProductRepository) find(ctx context.Context, where string, args ...any) ([]*entities.Product, error) {
+	rows, err := r.db.QueryContext(ctx, r.db.Rebind(`SELECT `+productColumns+` FROM products `+where+` ORDER BY created_at, id`), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var products []*entities.Product
+	for rows.Next() {
+		product, err := scanProduct(rows)
+		if err != nil {
+			return nil, err
+		}
+		products = append(products, product)
+	}
+	return products, rows.Err()
+}
+
+func scanProduct(row scanner) (*entities.Product, error) {
+	var product entities.Product
+	err := row.Scan(&product.ID, &product.Name, &product.Description, &product.Price, &product.BillingType,
+		&product.Category, &product.Status, &product.CreatedAt, &product.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	product.CreatedAt = sqlTime(product.CreatedAt)
+	product.UpdatedAt = sqlTime(product.UpdatedAt)
+	return &product, nil
+}
@@ -0,0 +1,61 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/frtasoniero/subsmanager/internal/domain/repositories"
+	"github.com/frtasoniero/subsmanager/internal/infrastructure/database/sqlstore"
+)
+
+// scanner is implemented by *sql.Row and *sql.Rows
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+// sqlWriteError converts a violated unique constraint to repositories.ErrDuplicate
+func sqlWriteError(db sqlstore.Handle, err error) error {
+	if db.IsUniqueViolation(err) {
+		return fmt.Errorf("%w: %v", repositories.ErrDuplicate, err)
+	}
+	return err
+}
+
+// sqlReadError converts a missing row to repositories.ErrNotFound
+func sqlReadError(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return repositories.ErrNotFound
+	}
+	return err
+}
+
+// sqlAffected returns repositories.ErrNotFound when a statement changed no row
+func sqlAffected(db sqlstore.Handle, result sql.Result, err error) error {
+	if err != nil {
+		return sqlWriteError(db, err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return repositories.ErrNotFound
+	}
+	return nil
+}
+
+// sqlTime returns t in UTC, as MongoDB returns dates
+func sqlTime(t time.Time) time.Time {
+	return t.UTC()
+}
+
+// sqlNullTime converts an optional time read from a nullable column
+func sqlNullTime(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	value := t.Time.UTC()
+	return &value
+}
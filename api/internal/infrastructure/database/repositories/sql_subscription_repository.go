@@ -0,0 +1,278 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/frtasoniero/subsmanager/internal/domain/entities"
+	"github.com/frtasoniero/subsmanager/internal/domain/repositories"
+	"github.com/frtasoniero/subsmanager/internal/infrastructure/database/sqlstore"
+)
+
+const subscriptionColumns = `id, user_id, product_id, status, start_date, end_date, next_billing, price_at_start, created_at, updated_at`
+
+// subscriptionWithProductQuery joins subscriptions with their product. The
+// inner join leaves out subscriptions whose product is gone, like the
+// $unwind stage of the MongoDB pipelines.
+const subscriptionWithProductQuery = `SELECT s.id, s.user_id, s.product_id, p.name, p.description, p.price, p.billing_type, p.category,
+	s.price_at_start, s.status, s.start_date, s.end_date, s.next_billing, s.created_at
+FROM subscriptions s JOIN products p ON p.id = s.product_id`
+
+// subscriptionSortColumns maps the sort fields of a listing to their column
+var subscriptionSortColumns = map[string]string{
+	repositories.SortNextBilling: "s.next_billing",
+	repositories.SortPrice:       "p.price",
+	repositories.SortCreatedAt:   "s.created_at",
+}
+
+type SQLSubscriptionRepository struct {
+	db sqlstore.Handle
+}
+
+func NewSQLSubscriptionRepository(db sqlstore.Handle) *SQLSubscriptionRepository {
+	return &SQLSubscriptionRepository{db: db}
+}
+
+func (r *SQLSubscriptionRepository) Create(ctx context.Context, subscription *entities.Subscription) error {
+	id := newID(subscription.ID)
+	_, err := r.db.ExecContext(ctx, r.db.Rebind(`INSERT INTO subscriptions (`+subscriptionColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`),
+		append([]any{id.String()}, r.values(subscription)...)...)
+	if err != nil {
+		return sqlWriteError(r.db, err)
+	}
+	subscription.ID = id
+	return nil
+}
+
+func (r *SQLSubscriptionRepository) GetByID(ctx context.Context, id entities.ID) (*entities.Subscription, error) {
+	row := r.db.QueryRowContext(ctx, r.db.Rebind(`SELECT `+subscriptionColumns+` FROM subscriptions WHERE id = ?`), id.String())
+	subscription, err := scanSubscription(row)
+	if err != nil {
+		return nil, sqlReadError(err)
+	}
+	return subscription, nil
+}
+
+func (r *SQLSubscriptionRepository) GetAll(ctx context.Context) ([]*entities.SubscriptionWithProduct, error) {
+	return r.Find(ctx, repositories.SubscriptionQuery{})
+}
+
+func (r *SQLSubscriptionRepository) Find(ctx context.Context, query repositories.SubscriptionQuery) ([]*entities.SubscriptionWithProduct, error) {
+	statement, args := r.selectWithProduct(query)
+	rows, err := r.db.QueryContext(ctx, statement, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var subscriptions []*entities.SubscriptionWithProduct
+	for rows.Next() {
+		subscription, err := scanSubscriptionWithProduct(rows)
+		if err != nil {
+			return nil, err
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+	return subscriptions, rows.Err()
+}
+
+func (r *SQLSubscriptionRepository) Stream(ctx context.Context, fn func(*entities.SubscriptionWithProduct) error) error {
+	statement, args := r.selectWithProduct(repositories.SubscriptionQuery{})
+	rows, err := r.db.QueryContext(ctx, statement, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		subscription, err := scanSubscriptionWithProduct(rows)
+		if err != nil {
+			return err
+		}
+		if err := fn(subscription); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func (r *SQLSubscriptionRepository) GetByUserID(ctx context.Context, userID entities.ID) ([]*entities.SubscriptionWithProduct, error) {
+	return r.Find(ctx, repositories.SubscriptionQuery{Filter: repositories.SubscriptionFilter{UserID: &userID}})
+}
+
+func (r *SQLSubscriptionRepository) Update(ctx context.Context, subscription *entities.Subscription) error {
+	query := `UPDATE subscriptions SET user_id = ?, product_id = ?, status = ?, start_date = ?, end_date = ?, next_billing = ?,
+		price_at_start = ?, created_at = ?, updated_at = ? WHERE id = ?`
+	result, err := r.db.ExecContext(ctx, r.db.Rebind(query), append(r.values(subscription), subscription.ID.String())...)
+	return sqlAffected(r.db, result, err)
+}
+
+func (r *SQLSubscriptionRepository) Delete(ctx context.Context, id entities.ID) error {
+	result, err := r.db.ExecContext(ctx, r.db.Rebind(`DELETE FROM subscriptions WHERE id = ?`), id.String())
+	return sqlAffected(r.db, result, err)
+}
+
+func (r *SQLSubscriptionRepository) GetByProductID(ctx context.Context, productID entities.ID) ([]*entities.Subscription, error) {
+	return r.find(ctx, `WHERE product_id = ?`, productID.String())
+}
+
+// All returns every subscription, including those whose product is gone,
+// which GetAll leaves out. It is used to copy a database as a whole.
+func (r *SQLSubscriptionRepository) All(ctx context.Context) ([]*entities.Subscription, error) {
+	return r.find(ctx, ``)
+}
+
+func (r *SQLSubscriptionRepository) GetActive(ctx context.Context) ([]*entities.SubscriptionWithProduct, error) {
+	return r.Find(ctx, repositories.SubscriptionQuery{Filter: repositories.SubscriptionFilter{Status: "active"}})
+}
+
+func (r *SQLSubscriptionRepository) GetExpiring(ctx context.Context, days int) ([]*entities.SubscriptionWithProduct, error) {
+	return r.Find(ctx, expiringQuery(days))
+}
+
+func (r *SQLSubscriptionRepository) Count(ctx context.Context) (int64, error) {
+	var count int64
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM subscriptions`).Scan(&count)
+	return count, err
+}
+
+// values returns the columns of a subscription after its ID, in the order of subscriptionColumns
+func (r *SQLSubscriptionRepository) values(subscription *entities.Subscription) []any {
+	return []any{
+		subscription.UserID.String(), subscription.ProductID.String(), subscription.Status,
+		r.db.Time(subscription.StartDate), r.db.NullTime(subscription.EndDate), r.db.Time(subscription.NextBilling),
+		subscription.PriceAtStart, r.db.Time(subscription.CreatedAt), r.db.Time(subscription.UpdatedAt),
+	}
+}
+
+func (r *SQLSubscriptionRepository) find(ctx context.Context, where string, args ...any) ([]*entities.Subscription, error) {
+	rows, err := r.db.QueryContext(ctx, r.db.Rebind(`SELECT `+subscriptionColumns+` FROM subscriptions `+where+` ORDER BY created_at, id`), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var subscriptions []*entities.Subscription
+	for rows.Next() {
+		subscription, err := scanSubscription(rows)
+		if err != nil {
+			return nil, err
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+	return subscriptions, rows.Err()
+}
+
+// selectWithProduct builds the statement of a subscription query, rebound
+// for the dialect, and its arguments
+func (r *SQLSubscriptionRepository) selectWithProduct(query repositories.SubscriptionQuery) (string, []any) {
+	var conditions []string
+	var args []any
+	where := func(condition string, values ...any) {
+		conditions = append(conditions, condition)
+		args = append(args, values...)
+	}
+	whereDates := func(column string, dates repositories.DateRange) {
+		if dates.From != nil {
+			where(column+` >= ?`, r.db.Time(*dates.From))
+		}
+		if dates.To != nil {
+			comparison := ` < ?`
+			if dates.ToInclusive {
+				comparison = ` <= ?`
+			}
+			where(column+comparison, r.db.Time(*dates.To))
+		}
+	}
+
+	filter := query.Filter
+	if filter.UserID != nil {
+		where(`s.user_id = ?`, filter.UserID.String())
+	}
+	if len(filter.UserIDs) > 0 {
+		ids := make([]any, len(filter.UserIDs))
+		for i, id := range filter.UserIDs {
+			ids[i] = id.String()
+		}
+		where(`s.user_id IN (?`+strings.Repeat(`, ?`, len(ids)-1)+`)`, ids...)
+	}
+	if filter.ProductID != nil {
+		where(`s.product_id = ?`, filter.ProductID.String())
+	}
+	if filter.Status != "" {
+		where(`s.status = ?`, filter.Status)
+	}
+	if filter.Category != "" {
+		where(`p.category = ?`, filter.Category)
+	}
+	if filter.MinPrice != nil {
+		where(`p.price >= ?`, *filter.MinPrice)
+	}
+	if filter.MaxPrice != nil {
+		where(`p.price <= ?`, *filter.MaxPrice)
+	}
+	whereDates(`s.next_billing`, filter.NextBilling)
+	whereDates(`s.start_date`, filter.StartDate)
+	whereDates(`s.created_at`, filter.CreatedAt)
+
+	column, ok := subscriptionSortColumns[query.Sort]
+	if !ok {
+		column = subscriptionSortColumns[repositories.SortCreatedAt]
+	}
+	comparison, direction := `>`, `ASC`
+	if query.Descending {
+		comparison, direction = `<`, `DESC`
+	}
+	if query.After != nil {
+		value := query.After.Value
+		if t, ok := value.(time.Time); ok {
+			value = r.db.Time(t)
+		}
+		where(`(`+column+`, s.id) `+comparison+` (?, ?)`, value, query.After.ID.String())
+	}
+
+	statement := subscriptionWithProductQuery
+	if len(conditions) > 0 {
+		statement += ` WHERE ` + strings.Join(conditions, ` AND `)
+	}
+	statement += ` ORDER BY ` + column + ` ` + direction + `, s.id ` + direction
+	if query.Limit > 0 {
+		statement += ` LIMIT ?`
+		args = append(args, query.Limit)
+	}
+	return r.db.Rebind(statement), args
+}
+
+func scanSubscription(row scanner) (*entities.Subscription, error) {
+	var subscription entities.Subscription
+	var endDate sql.NullTime
+	err := row.Scan(&subscription.ID, &subscription.UserID, &subscription.ProductID, &subscription.Status, &subscription.StartDate,
+		&endDate, &subscription.NextBilling, &subscription.PriceAtStart, &subscription.CreatedAt, &subscription.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	subscription.StartDate = sqlTime(subscription.StartDate)
+	subscription.EndDate = sqlNullTime(endDate)
+	subscription.NextBilling = sqlTime(subscription.NextBilling)
+	subscription.CreatedAt = sqlTime(subscription.CreatedAt)
+	subscription.UpdatedAt = sqlTime(subscription.UpdatedAt)
+	return &subscription, nil
+}
+
+func scanSubscriptionWithProduct(row scanner) (*entities.SubscriptionWithProduct, error) {
+	var subscription entities.SubscriptionWithProduct
+	var endDate sql.NullTime
+	err := row.Scan(&subscription.ID, &subscription.UserID, &subscription.ProductID, &subscription.ProductName, &subscription.Description,
+		&subscription.Price, &subscription.BillingType, &subscription.Category, &subscription.PriceAtStart, &subscription.Status,
+		&subscription.StartDate, &endDate, &subscription.NextBilling, &subscription.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	subscription.StartDate = sqlTime(subscription.StartDate)
+	subscription.EndDate = sqlNullTime(endDate)
+	subscription.NextBilling = sqlTime(subscription.NextBilling)
+	subscription.CreatedAt = sqlTime(subscription.CreatedAt)
+	return &subscription, nil
+}
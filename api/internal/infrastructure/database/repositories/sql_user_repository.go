@@ -0,0 +1,101 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/frtasoniero/subsmanager/internal/domain/entities"
+	"github.com/frtasoniero/subsmanager/internal/infrastructure/database/sqlstore"
+)
+
+const userColumns = `id, username, password, email, status, created_at, updated_at`
+
+type SQLUserRepository struct {
+	db sqlstore.Handle
+}
+
+func NewSQLUserRepository(db sqlstore.Handle) *SQLUserRepository {
+	return &SQLUserRepository{db: db}
+}
+
+func (r *SQLUserRepository) Create(ctx context.Context, user *entities.User) error {
+	id := newID(user.ID)
+	_, err := r.db.ExecContext(ctx, r.db.Rebind(`INSERT INTO users (`+userColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?)`),
+		append([]any{id.String()}, r.values(user)...)...)
+	if err != nil {
+		return sqlWriteError(r.db, err)
+	}
+	user.ID = id
+	return nil
+}
+
+func (r *SQLUserRepository) GetByID(ctx context.Context, id entities.ID) (*entities.User, error) {
+	return r.findOne(ctx, `id = ?`, id.String())
+}
+
+func (r *SQLUserRepository) GetByEmail(ctx context.Context, email string) (*entities.User, error) {
+	return r.findOne(ctx, `email = ?`, email)
+}
+
+func (r *SQLUserRepository) GetByUsername(ctx context.Context, username string) (*entities.User, error) {
+	return r.findOne(ctx, `username = ?`, username)
+}
+
+func (r *SQLUserRepository) GetAll(ctx context.Context) ([]*entities.User, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT `+userColumns+` FROM users ORDER BY created_at, id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []*entities.User
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+	return users, rows.Err()
+}
+
+func (r *SQLUserRepository) Update(ctx context.Context, user *entities.User) error {
+	query := `UPDATE users SET username = ?, password = ?, email = ?, status = ?, created_at = ?, updated_at = ? WHERE id = ?`
+	result, err := r.db.ExecContext(ctx, r.db.Rebind(query), append(r.values(user), user.ID.String())...)
+	return sqlAffected(r.db, result, err)
+}
+
+func (r *SQLUserRepository) Delete(ctx context.Context, id entities.ID) error {
+	result, err := r.db.ExecContext(ctx, r.db.Rebind(`DELETE FROM users WHERE id = ?`), id.String())
+	return sqlAffected(r.db, result, err)
+}
+
+func (r *SQLUserRepository) Count(ctx context.Context) (int64, error) {
+	var count int64
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM users`).Scan(&count)
+	return count, err
+}
+
+// values returns the columns of a user after its ID, in the order of userColumns
+func (r *SQLUserRepository) values(user *entities.User) []any {
+	return []any{user.Username, user.Password, user.Email, user.Status, r.db.Time(user.CreatedAt), r.db.Time(user.UpdatedAt)}
+}
+
+func (r *SQLUserRepository) findOne(ctx context.Context, where string, args ...any) (*entities.User, error) {
+	row := r.db.QueryRowContext(ctx, r.db.Rebind(`SELECT `+userColumns+` FROM users WHERE `+where), args...)
+	user, err := scanUser(row)
+	if err != nil {
+		return nil, sqlReadError(err)
+	}
+	return user, nil
+}
+
+func scanUser(row scanner) (*entities.User, error) {
+	var user entities.User
+	err := row.Scan(&user.ID, &user.Username, &user.Password, &user.Email, &user.Status, &user.CreatedAt, &user.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	user.CreatedAt = sqlTime(user.CreatedAt)
+	user.UpdatedAt = sqlTime(user.UpdatedAt)
+	return &user, nil
+}
@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/frtasoniero/subsmanager/internal/domain/repositories"
+)
+
+// expiringQuery selects the active subscriptions billed within days from now,
+// overdue ones included
+func expiringQuery(days int) repositories.SubscriptionQuery {
+	threshold := time.Now().AddDate(0, 0, days)
+	return repositories.SubscriptionQuery{Filter: repositories.SubscriptionFilter{
+		Status:      "active",
+		NextBilling: repositories.DateRange{To: &threshold, ToInclusive: true},
+	}}
+}
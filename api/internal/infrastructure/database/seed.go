@@ -0,0 +1,112 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/frtasoniero/subsmanager/internal/infrastructure/database/migrate"
+	"github.com/frtasoniero/subsmanager/internal/infrastructure/database/seeds"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// InitializeDatabase migrates the database to the latest schema and populates
+// it with the given seed profile, unless it already contains data
+func InitializeDatabase(config Config, profile string) error {
+	// Create client and database connection
+	client, db, err := NewConnection(config)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer Close(client)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := migrateUp(ctx, db); err != nil {
+		return err
+	}
+
+	empty, err := seeds.IsEmpty(ctx, db)
+	if err != nil {
+		return err
+	}
+	if !empty {
+		fmt.Println("⚠️  Database already contains data. Skipping initialization.")
+		return nil
+	}
+
+	if err := seedProfile(ctx, db, profile); err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Database '%s' initialized with the %s profile!\n", config.Database, profile)
+	return nil
+}
+
+// CleanDatabase removes all data from collections and reinitializes them with the given seed profile
+func CleanDatabase(config Config, profile string) error {
+	// Create client and database connection
+	client, db, err := NewConnection(config)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer Close(client)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := migrateUp(ctx, db); err != nil {
+		return err
+	}
+
+	// Clean all collections
+	fmt.Println("🧹 Cleaning database...")
+	removed, err := seeds.Clear(ctx, db)
+	if err != nil {
+		return err
+	}
+	for _, name := range seeds.Collections {
+		fmt.Printf("🗑️  Removed %d documents from %s collection\n", removed[name], name)
+	}
+
+	// Re-initialize with the profile data
+	fmt.Printf("🔄 Re-initializing with the %s profile...\n", profile)
+	if err := seedProfile(ctx, db, profile); err != nil {
+		return err
+	}
+
+	fmt.Println("✅ Database cleaned and reset to default data!")
+	return nil
+}
+
+func migrateUp(ctx context.Context, db *mongo.Database) error {
+	applied, err := migrate.NewMigrator(db).Up(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to migrate database: %w", err)
+	}
+	for _, migration := range applied {
+		fmt.Printf("⬆️  Applied migration %d_%s\n", migration.Version, migration.Name)
+	}
+	return nil
+}
+
+func seedProfile(ctx context.Context, db *mongo.Database, name string) error {
+	profile, err := seeds.LoadProfile(name)
+	if err != nil {
+		return err
+	}
+	dataset, err := profile.Build(time.Now())
+	if err != nil {
+		return err
+	}
+	if err := seeds.Insert(ctx, db, dataset); err != nil {
+		return err
+	}
+
+	counts := dataset.Counts()
+	for _, name := range seeds.Collections {
+		fmt.Printf("✅ %s collection seeded with %d records!\n", name, counts[name])
+	}
+	return nil
+}
@@ -0,0 +1,225 @@
+package seeds
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/frtasoniero/subsmanager/internal/domain/entities"
+)
+
+// GenerateOptions controls the synthetic dataset. The same options, including
+// Now, always produce the same dataset.
+type GenerateOptions struct {
+	Users int
+	Seed  int64
+	// Now is the reference date; history is generated before it
+	Now time.Time
+	// Years is how far back signups go, defaults to 3
+	Years int
+}
+
+// catalog is the product list of generated datasets
+var catalog = []ProductSeed{
+	{Name: "Netflix", Description: "Streaming service with movies and TV shows", Price: 15.99, BillingType: "monthly", Category: "streaming"},
+	{Name: "Disney+", Description: "Disney streaming platform", Price: 7.99, BillingType: "monthly", Category: "streaming"},
+	{Name: "HBO Max", Description: "Series and movies streaming", Price: 15.99, BillingType: "monthly", Category: "streaming"},
+	{Name: "YouTube Premium", Description: "Ad-free YouTube experience", Price: 11.99, BillingType: "monthly", Category: "streaming"},
+	{Name: "Apple TV+", Description: "Apple original series", Price: 9.99, BillingType: "monthly", Category: "streaming"},
+	{Name: "Spotify", Description: "Music streaming platform", Price: 9.99, BillingType: "monthly", Category: "music"},
+	{Name: "Apple Music", Description: "Music streaming platform", Price: 10.99, BillingType: "monthly", Category: "music"},
+	{Name: "Tidal", Description: "High fidelity music streaming", Price: 10.99, BillingType: "monthly", Category: "music"},
+	{Name: "Audible", Description: "Audiobooks", Price: 14.95, BillingType: "monthly", Category: "books"},
+	{Name: "Kindle Unlimited", Description: "E-book subscription", Price: 11.99, BillingType: "monthly", Category: "books"},
+	{Name: "Amazon Prime", Description: "Amazon Prime membership", Price: 139.00, BillingType: "yearly", Category: "shopping"},
+	{Name: "Costco Membership", Description: "Warehouse club membership", Price: 65.00, BillingType: "yearly", Category: "shopping"},
+	{Name: "Xbox Game Pass", Description: "Game library subscription", Price: 16.99, BillingType: "monthly", Category: "gaming"},
+	{Name: "PlayStation Plus", Description: "Online multiplayer and games", Price: 79.99, BillingType: "yearly", Category: "gaming"},
+	{Name: "Nintendo Switch Online", Description: "Online play and classic games", Price: 19.99, BillingType: "yearly", Category: "gaming"},
+	{Name: "Microsoft 365", Description: "Office apps and cloud storage", Price: 99.99, BillingType: "yearly", Category: "software"},
+	{Name: "Adobe Creative Cloud", Description: "Creative apps", Price: 59.99, BillingType: "monthly", Category: "software"},
+	{Name: "Dropbox Plus", Description: "Cloud storage", Price: 11.99, BillingType: "monthly", Category: "software"},
+	{Name: "1Password", Description: "Password manager", Price: 35.88, BillingType: "yearly", Category: "software"},
+	{Name: "iCloud+", Description: "Cloud storage", Price: 2.99, BillingType: "monthly", Category: "software"},
+	{Name: "The New York Times", Description: "News subscription", Price: 4.25, BillingType: "weekly", Category: "news"},
+	{Name: "The Economist", Description: "Weekly news magazine", Price: 189.00, BillingType: "yearly", Category: "news"},
+	{Name: "HelloFresh", Description: "Meal kit delivery", Price: 69.99, BillingType: "weekly", Category: "food"},
+	{Name: "Peloton App", Description: "Fitness classes", Price: 12.99, BillingType: "monthly", Category: "fitness"},
+	{Name: "Strava", Description: "Activity tracking", Price: 79.99, BillingType: "yearly", Category: "fitness"},
+	{Name: "Headspace", Description: "Meditation app", Price: 12.99, BillingType: "monthly", Category: "health"},
+	{Name: "Duolingo Super", Description: "Language learning", Price: 83.99, BillingType: "yearly", Category: "education"},
+	{Name: "Coursera Plus", Description: "Online courses", Price: 59.00, BillingType: "monthly", Category: "education"},
+}
+
+var (
+	firstNames = []string{"james", "mary", "robert", "patricia", "john", "jennifer", "michael", "linda", "david", "elizabeth",
+		"william", "barbara", "richard", "susan", "joseph", "jessica", "thomas", "sarah", "carlos", "ana",
+		"lucas", "julia", "pedro", "camila", "hiro", "yuki", "amara", "kwame", "priya", "arjun"}
+	lastNames = []string{"smith", "johnson", "williams", "brown", "jones", "garcia", "miller", "davis", "rodriguez", "martinez",
+		"silva", "santos", "oliveira", "souza", "tanaka", "suzuki", "okafor", "mensah", "patel", "sharma"}
+	emailDomains = []string{"example.com", "example.org", "example.net", "mail.example.com"}
+)
+
+// subscriptionsPerUser is the cumulative distribution of subscription counts per user
+var subscriptionsPerUser = []struct {
+	count       int
+	probability float64
+}{
+	{0, 0.08}, {1, 0.30}, {2, 0.55}, {3, 0.74}, {4, 0.87}, {5, 0.94}, {6, 0.98}, {8, 1},
+}
+
+// generator wraps a seeded random source
+type generator struct {
+	rng *rand.Rand
+	now time.Time
+}
+
+// Generate produces a reproducible dataset of users with subscription histories.
+// Users sign up over the last Years years. Each subscribes to a few products;
+// about a third of the subscriptions are cancelled or expired, and the rest
+// renew up to Now.
+func Generate(opts GenerateOptions) (*Dataset, error) {
+	if opts.Users < 1 {
+		return nil, fmt.Errorf("users must be at least 1")
+	}
+	if opts.Years < 1 {
+		opts.Years = 3
+	}
+	if opts.Now.IsZero() {
+		return nil, fmt.Errorf("a reference date is required")
+	}
+
+	g := &generator{
+		rng: rand.New(rand.NewSource(opts.Seed)),
+		now: opts.Now.UTC(),
+	}
+	earliest := g.now.AddDate(-opts.Years, 0, 0)
+
+	dataset := &Dataset{}
+	for _, seed := range catalog {
+		launched := g.between(earliest.AddDate(-1, 0, 0), earliest)
+		product := &entities.Product{
+			ID:          g.id(launched),
+			Name:        seed.Name,
+			Description: seed.Description,
+			Price:       seed.Price,
+			BillingType: seed.BillingType,
+			Category:    seed.Category,
+			Status:      "active",
+			CreatedAt:   launched,
+			UpdatedAt:   launched,
+		}
+		// A few products are discontinued but keep their subscribers
+		if g.rng.Float64() < 0.05 {
+			product.Status = "inactive"
+		}
+		dataset.Products = append(dataset.Products, product)
+	}
+
+	for i := 0; i < opts.Users; i++ {
+		user := g.user(i, earliest)
+		dataset.Users = append(dataset.Users, user)
+		dataset.Subscriptions = append(dataset.Subscriptions, g.subscriptions(user, dataset.Products)...)
+	}
+
+	return dataset, nil
+}
+
+func (g *generator) user(index int, earliest time.Time) *entities.User {
+	first := firstNames[g.rng.Intn(len(firstNames))]
+	last := lastNames[g.rng.Intn(len(lastNames))]
+	// The index keeps usernames and emails unique
+	username := fmt.Sprintf("%s.%s%d", first, last, index+1)
+	signup := g.between(earliest, g.now)
+
+	user := &entities.User{
+		ID:        g.id(signup),
+		Username:  username,
+		Email:     username + "@" + emailDomains[g.rng.Intn(len(emailDomains))],
+		Status:    "active",
+		CreatedAt: signup,
+		UpdatedAt: signup,
+	}
+	user.SetPassword(fmt.Sprintf("password-%d", g.rng.Int63()))
+	if g.rng.Float64() < 0.04 {
+		user.Status = "inactive"
+	}
+	return user
+}
+
+func (g *generator) subscriptions(user *entities.User, products []*entities.Product) []*entities.Subscription {
+	count := 0
+	draw := g.rng.Float64()
+	for _, bucket := range subscriptionsPerUser {
+		if draw <= bucket.probability {
+			count = bucket.count
+			break
+		}
+	}
+
+	subscriptions := make([]*entities.Subscription, 0, count)
+	for _, productIndex := range g.rng.Perm(len(products))[:count] {
+		product := products[productIndex]
+		start := g.between(user.CreatedAt, g.now)
+
+		subscription := &entities.Subscription{
+			ID:           g.id(start),
+			UserID:       user.ID,
+			ProductID:    product.ID,
+			Status:       "active",
+			StartDate:    start,
+			PriceAtStart: g.historicalPrice(product.Price, start),
+			CreatedAt:    start,
+			UpdatedAt:    start,
+		}
+
+		// Walk the billing cycles up to now; a churned subscription stops at a
+		// renewal date between its start and now
+		next := entities.NextBillingDate(start, product.BillingType)
+		churned := g.rng.Float64() < 0.35 || user.Status == "inactive"
+		var end time.Time
+		if churned && next.Before(g.now) {
+			end = g.between(next, g.now)
+		}
+		for !next.After(g.now) && (end.IsZero() || next.Before(end)) {
+			next = entities.NextBillingDate(next, product.BillingType)
+		}
+		subscription.NextBilling = next
+
+		if !end.IsZero() {
+			subscription.EndDate = &end
+			subscription.UpdatedAt = end
+			subscription.Status = "cancelled"
+			if g.rng.Float64() < 0.2 {
+				subscription.Status = "expired"
+			}
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+	return subscriptions
+}
+
+// historicalPrice lowers the current price by 5% for every full year before now
+func (g *generator) historicalPrice(current float64, start time.Time) float64 {
+	years := math.Floor(g.now.Sub(start).Hours() / 24 / 365)
+	return math.Round(current*math.Pow(0.95, years)*100) / 100
+}
+
+// between returns a random time in [from, to), truncated to the second
+func (g *generator) between(from, to time.Time) time.Time {
+	if !to.After(from) {
+		return from
+	}
+	offset := time.Duration(g.rng.Int63n(int64(to.Sub(from))))
+	return from.Add(offset).Truncate(time.Second)
+}
+
+// id derives an ID from the random source so IDs are reproducible too
+func (g *generator) id(created time.Time) entities.ID {
+	return entities.NewIDAt(created, func(random []byte) (int, error) {
+		binary.BigEndian.PutUint64(random, g.rng.Uint64())
+		return len(random), nil
+	})
+}
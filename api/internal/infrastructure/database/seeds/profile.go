@@ -0,0 +1,226 @@
+package seeds
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/frtasoniero/subsmanager/internal/domain/entities"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultProfile is the profile used by init-db and clean-db
+const DefaultProfile = "demo"
+
+//go:embed profiles/*.yaml
+var builtin embed.FS
+
+// ErrInvalidProfile is returned when a profile cannot be loaded or resolved
+var ErrInvalidProfile = errors.New("invalid seed profile")
+
+// Profile describes a dataset. Dates are offsets from today such as -2m or +1w.
+type Profile struct {
+	Name          string             `json:"name" yaml:"name"`
+	Description   string             `json:"description" yaml:"description"`
+	Products      []ProductSeed      `json:"products" yaml:"products"`
+	Users         []UserSeed         `json:"users" yaml:"users"`
+	Subscriptions []SubscriptionSeed `json:"subscriptions" yaml:"subscriptions"`
+}
+
+// ProductSeed is a product of a profile. Status defaults to active.
+type ProductSeed struct {
+	Name        string  `json:"name" yaml:"name"`
+	Description string  `json:"description" yaml:"description"`
+	Price       float64 `json:"price" yaml:"price"`
+	BillingType string  `json:"billing_type" yaml:"billing_type"`
+	Category    string  `json:"category" yaml:"category"`
+	Status      string  `json:"status" yaml:"status"`
+}
+
+// UserSeed is a user of a profile. Status defaults to active.
+type UserSeed struct {
+	Username string `json:"username" yaml:"username"`
+	Email    string `json:"email" yaml:"email"`
+	Password string `json:"password" yaml:"password"`
+	Status   string `json:"status" yaml:"status"`
+}
+
+// SubscriptionSeed references a user by username and a product by name.
+// Price defaults to the product price and status to active.
+type SubscriptionSeed struct {
+	User        string  `json:"user" yaml:"user"`
+	Product     string  `json:"product" yaml:"product"`
+	Price       float64 `json:"price" yaml:"price"`
+	Status      string  `json:"status" yaml:"status"`
+	Start       string  `json:"start" yaml:"start"`
+	End         string  `json:"end" yaml:"end"`
+	NextBilling string  `json:"next_billing" yaml:"next_billing"`
+}
+
+// Profiles returns the names of the built-in profiles
+func Profiles() []string {
+	entries, _ := builtin.ReadDir("profiles")
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, strings.TrimSuffix(entry.Name(), ".yaml"))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LoadProfile loads a built-in profile by name, or a .yaml, .yml or .json file by path
+func LoadProfile(nameOrPath string) (*Profile, error) {
+	var data []byte
+	var err error
+	ext := strings.ToLower(filepath.Ext(nameOrPath))
+
+	if ext == "" {
+		data, err = builtin.ReadFile(path.Join("profiles", nameOrPath+".yaml"))
+		if err != nil {
+			return nil, fmt.Errorf("%w: unknown profile %q, built-in profiles are %s",
+				ErrInvalidProfile, nameOrPath, strings.Join(Profiles(), ", "))
+		}
+		ext = ".yaml"
+	} else {
+		data, err = os.ReadFile(nameOrPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var profile Profile
+	switch ext {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &profile)
+	case ".json":
+		err = json.Unmarshal(data, &profile)
+	default:
+		return nil, fmt.Errorf("%w: unsupported file type %q, expected .yaml, .yml or .json", ErrInvalidProfile, ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidProfile, err)
+	}
+	return &profile, nil
+}
+
+// offsetPattern matches date offsets such as -2m, +1w or 0d
+var offsetPattern = regexp.MustCompile(`^([+-]?\d+)([dwmy])$`)
+
+// resolveOffset applies a date offset to now
+func resolveOffset(offset string, now time.Time) (time.Time, error) {
+	match := offsetPattern.FindStringSubmatch(strings.TrimSpace(offset))
+	if match == nil {
+		return time.Time{}, fmt.Errorf("invalid date offset %q, expected for example -2m, +1w or 0d", offset)
+	}
+	n, _ := strconv.Atoi(match[1])
+	switch match[2] {
+	case "d":
+		return now.AddDate(0, 0, n), nil
+	case "w":
+		return now.AddDate(0, 0, 7*n), nil
+	case "m":
+		return now.AddDate(0, n, 0), nil
+	default:
+		return now.AddDate(n, 0, 0), nil
+	}
+}
+
+// Build resolves the references and date offsets of the profile into a dataset
+func (p *Profile) Build(now time.Time) (*Dataset, error) {
+	dataset := &Dataset{}
+
+	products := make(map[string]*entities.Product, len(p.Products))
+	for _, seed := range p.Products {
+		product := &entities.Product{
+			ID:          entities.NewID(),
+			Name:        seed.Name,
+			Description: seed.Description,
+			Price:       seed.Price,
+			BillingType: seed.BillingType,
+			Category:    seed.Category,
+			Status:      defaultString(seed.Status, "active"),
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}
+		products[seed.Name] = product
+		dataset.Products = append(dataset.Products, product)
+	}
+
+	users := make(map[string]*entities.User, len(p.Users))
+	for _, seed := range p.Users {
+		user := &entities.User{
+			ID:        entities.NewID(),
+			Username:  seed.Username,
+			Email:     seed.Email,
+			Status:    defaultString(seed.Status, "active"),
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		user.SetPassword(seed.Password)
+		users[seed.Username] = user
+		dataset.Users = append(dataset.Users, user)
+	}
+
+	for i, seed := range p.Subscriptions {
+		user, ok := users[seed.User]
+		if !ok {
+			return nil, fmt.Errorf("%w: subscription %d references unknown user %q", ErrInvalidProfile, i+1, seed.User)
+		}
+		product, ok := products[seed.Product]
+		if !ok {
+			return nil, fmt.Errorf("%w: subscription %d references unknown product %q", ErrInvalidProfile, i+1, seed.Product)
+		}
+
+		start, err := resolveOffset(defaultString(seed.Start, "0d"), now)
+		if err != nil {
+			return nil, fmt.Errorf("%w: subscription %d: %v", ErrInvalidProfile, i+1, err)
+		}
+		nextBilling := entities.NextBillingDate(start, product.BillingType)
+		if seed.NextBilling != "" {
+			if nextBilling, err = resolveOffset(seed.NextBilling, now); err != nil {
+				return nil, fmt.Errorf("%w: subscription %d: %v", ErrInvalidProfile, i+1, err)
+			}
+		}
+
+		subscription := &entities.Subscription{
+			ID:           entities.NewID(),
+			UserID:       user.ID,
+			ProductID:    product.ID,
+			Status:       defaultString(seed.Status, "active"),
+			StartDate:    start,
+			NextBilling:  nextBilling,
+			PriceAtStart: seed.Price,
+			CreatedAt:    start,
+			UpdatedAt:    now,
+		}
+		if subscription.PriceAtStart == 0 {
+			subscription.PriceAtStart = product.Price
+		}
+		if seed.End != "" {
+			end, err := resolveOffset(seed.End, now)
+			if err != nil {
+				return nil, fmt.Errorf("%w: subscription %d: %v", ErrInvalidProfile, i+1, err)
+			}
+			subscription.EndDate = &end
+		}
+		dataset.Subscriptions = append(dataset.Subscriptions, subscription)
+	}
+
+	return dataset, nil
+}
+
+func defaultString(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
@@ -0,0 +1,115 @@
+// Package seeds builds datasets for development and testing and inserts them.
+//
+// Datasets come from seed profiles, small YAML or JSON files describing
+// products, users and subscriptions with dates relative to today, or from
+// Generate, which produces large reproducible datasets for load and report
+// testing.
+package seeds
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/frtasoniero/subsmanager/internal/domain/entities"
+	"github.com/frtasoniero/subsmanager/internal/domain/repositories"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// insertBatchSize is the number of documents inserted per request
+const insertBatchSize = 1000
+
+// Collections are the collections a dataset writes to, in insertion order
+var Collections = []string{"products", "users", "subscriptions"}
+
+// Dataset is a set of documents with their IDs already assigned, so
+// subscriptions can reference users and products before anything is inserted
+type Dataset struct {
+	Products      []*entities.Product
+	Users         []*entities.User
+	Subscriptions []*entities.Subscription
+}
+
+// Counts returns the number of documents per collection
+func (d *Dataset) Counts() map[string]int {
+	return map[string]int{
+		"products":      len(d.Products),
+		"users":         len(d.Users),
+		"subscriptions": len(d.Subscriptions),
+	}
+}
+
+// IsEmpty returns true if none of the seeded collections contains a document
+func IsEmpty(ctx context.Context, db *mongo.Database) (bool, error) {
+	for _, name := range Collections {
+		count, err := db.Collection(name).CountDocuments(ctx, bson.M{}, options.Count().SetLimit(1))
+		if err != nil {
+			return false, fmt.Errorf("failed to count %s: %w", name, err)
+		}
+		if count > 0 {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// Clear removes every document from the seeded collections
+func Clear(ctx context.Context, db *mongo.Database) (map[string]int64, error) {
+	removed := make(map[string]int64, len(Collections))
+	for _, name := range Collections {
+		result, err := db.Collection(name).DeleteMany(ctx, bson.M{})
+		if err != nil {
+			return removed, fmt.Errorf("failed to clean %s collection: %w", name, err)
+		}
+		removed[name] = result.DeletedCount
+	}
+	return removed, nil
+}
+
+// Insert writes the dataset, products and users before the subscriptions referencing them
+func Insert(ctx context.Context, db *mongo.Database, dataset *Dataset) error {
+	if err := insertAll(ctx, db.Collection("products"), dataset.Products); err != nil {
+		return err
+	}
+	if err := insertAll(ctx, db.Collection("users"), dataset.Users); err != nil {
+		return err
+	}
+	return insertAll(ctx, db.Collection("subscriptions"), dataset.Subscriptions)
+}
+
+// Create writes the dataset through repositories, for backends without a bulk
+// insert. IDs are kept, so subscriptions still reference their user and product.
+func Create(ctx context.Context, users repositories.UserRepository, products repositories.ProductRepository,
+	subscriptions repositories.SubscriptionRepository, dataset *Dataset) error {
+	for _, product := range dataset.Products {
+		if err := products.Create(ctx, product); err != nil {
+			return fmt.Errorf("failed to seed product %q: %w", product.Name, err)
+		}
+	}
+	for _, user := range dataset.Users {
+		if err := users.Create(ctx, user); err != nil {
+			return fmt.Errorf("failed to seed user %q: %w", user.Username, err)
+		}
+	}
+	for _, subscription := range dataset.Subscriptions {
+		if err := subscriptions.Create(ctx, subscription); err != nil {
+			return fmt.Errorf("failed to seed subscription: %w", err)
+		}
+	}
+	return nil
+}
+
+func insertAll[T any](ctx context.Context, collection *mongo.Collection, documents []*T) error {
+	batch := make([]any, 0, insertBatchSize)
+	for i, document := range documents {
+		batch = append(batch, document)
+		if len(batch) == insertBatchSize || i == len(documents)-1 {
+			if _, err := collection.InsertMany(ctx, batch); err != nil {
+				return fmt.Errorf("failed to insert %s: %w", collection.Name(), err)
+			}
+			batch = batch[:0]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	_ "github.com/jackc/pgx/v5/stdlib" // registers the pgx database/sql driver
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// Drivers accepted by Open
+const (
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+)
+
+// dialect holds what differs between SQL databases
+type dialect struct {
+	name       string
+	title      string
+	driverName string
+	// dsn completes the configured data source name
+	dsn func(dsn string) string
+	// numberedPlaceholders is set when parameters are written $1, $2...
+	numberedPlaceholders bool
+	// time converts a time to a query argument
+	time              func(t time.Time) any
+	isUniqueViolation func(err error) bool
+	// lock takes the migration lock on conn, returning false when another
+	// process holds it. It is nil when transactions already serialize
+	// migrations.
+	lock   func(ctx context.Context, conn *sql.Conn) (bool, error)
+	unlock func(ctx context.Context, conn *sql.Conn) error
+}
+
+var dialects = map[string]*dialect{
+	DriverPostgres: postgres,
+	DriverSQLite:   sqliteDialect,
+}
+
+func (d *dialect) rebind(query string) string {
+	if !d.numberedPlaceholders {
+		return query
+	}
+	var rebound strings.Builder
+	n := 0
+	for _, r := range query {
+		if r == '?' {
+			n++
+			rebound.WriteByte('$')
+			rebound.WriteString(strconv.Itoa(n))
+			continue
+		}
+		rebound.WriteRune(r)
+	}
+	return rebound.String()
+}
+
+func (d *dialect) nullTime(t *time.Time) any {
+	if t == nil {
+		return nil
+	}
+	return d.time(*t)
+}
+
+// migrationLockKey identifies the migration advisory lock of this application
+const migrationLockKey = 7_216_370_031
+
+var postgres = &dialect{
+	name:                 DriverPostgres,
+	title:                "PostgreSQL",
+	driverName:           "pgx",
+	dsn:                  func(dsn string) string { return dsn },
+	numberedPlaceholders: true,
+	time:                 func(t time.Time) any { return t },
+	isUniqueViolation: func(err error) bool {
+		var pgErr *pgconn.PgError
+		return errors.As(err, &pgErr) && pgErr.Code == "23505"
+	},
+	lock: func(ctx context.Context, conn *sql.Conn) (bool, error) {
+		var locked bool
+		err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", migrationLockKey).Scan(&locked)
+		return locked, err
+	},
+	unlock: func(ctx context.Context, conn *sql.Conn) error {
+		_, err := conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", migrationLockKey)
+		return err
+	},
+}
+
+// sqliteTimeLayout stores times as fixed-width UTC text, so comparing the
+// text in SQL compares the instants. The driver parses it back for
+// TIMESTAMP columns.
+const sqliteTimeLayout = "2006-01-02 15:04:05.000000000-07:00"
+
+var sqliteDialect = &dialect{
+	name:       DriverSQLite,
+	title:      "SQLite",
+	driverName: "sqlite",
+	// The DSN is a file path. A busy timeout makes concurrent writers wait
+	// for each other instead of failing.
+	dsn: func(path string) string {
+		if strings.HasPrefix(path, "file:") {
+			return path
+		}
+		query := url.Values{"_pragma": {"busy_timeout(5000)", "journal_mode(WAL)"}}
+		return "file:" + path + "?" + query.Encode()
+	},
+	time: func(t time.Time) any { return t.UTC().Format(sqliteTimeLayout) },
+	isUniqueViolation: func(err error) bool {
+		var sqliteErr *sqlite.Error
+		if !errors.As(err, &sqliteErr) {
+			return false
+		}
+		code := sqliteErr.Code()
+		return code == sqlite3.SQLITE_CONSTRAINT_UNIQUE || code == sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY
+	},
+}
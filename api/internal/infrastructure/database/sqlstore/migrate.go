@@ -0,0 +1,372 @@
+package sqlstore
+
+import (
+	"context"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// LedgerTable records the applied migrations
+const LedgerTable = "schema_migrations"
+
+// migrationFiles holds one directory of migrations per dialect. Each version
+// has a NNNN_name.up.sql file and an optional NNNN_name.down.sql file. Without
+// a down file the migration is irreversible, like 0001_create_tables, whose
+// revert would drop every table.
+//
+//go:embed migrations
+var migrationFiles embed.FS
+
+var migrationName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+var (
+	// ErrChecksumMismatch is returned when an applied migration file was modified
+	ErrChecksumMismatch = errors.New("applied migration was modified")
+	// ErrUnknownVersion is returned when a version is neither defined nor applied
+	ErrUnknownVersion = errors.New("unknown migration version")
+	// ErrIrreversible is returned when reverting a migration without down file
+	ErrIrreversible = errors.New("migration cannot be reverted")
+	// ErrLocked is returned when another process is running migrations
+	ErrLocked = errors.New("migrations are locked by another process")
+	// ErrSchemaOutdated is returned by Check when migrations are pending
+	ErrSchemaOutdated = errors.New("database schema is outdated, run `cli migrate up`")
+)
+
+// Migration is a single versioned schema change
+type Migration struct {
+	Version  int64
+	Name     string
+	Checksum string
+	Up       string
+	Down     string
+}
+
+// Status describes a migration and whether it was applied
+type Status struct {
+	Version   int64      `json:"version"`
+	Name      string     `json:"name"`
+	Applied   bool       `json:"applied"`
+	AppliedAt *time.Time `json:"applied_at,omitempty"`
+	// Modified is set when the file changed since it was applied
+	Modified bool `json:"modified"`
+	// Missing is set when the ledger has a version no file defines
+	Missing bool `json:"missing"`
+}
+
+// record is an applied migration as stored in the ledger
+type record struct {
+	version   int64
+	name      string
+	checksum  string
+	appliedAt time.Time
+}
+
+// Migrations returns the migrations of a driver ordered by version
+func Migrations(driver string) ([]*Migration, error) {
+	dir := path.Join("migrations", driver)
+	entries, err := fs.ReadDir(migrationFiles, dir)
+	if err != nil {
+		return nil, fmt.Errorf("no migrations for driver %q: %w", driver, err)
+	}
+
+	byVersion := map[int64]*Migration{}
+	for _, entry := range entries {
+		match := migrationName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("unexpected migration file %s", entry.Name())
+		}
+		version, _ := strconv.ParseInt(match[1], 10, 64)
+		content, err := migrationFiles.ReadFile(path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		migration := byVersion[version]
+		if migration == nil {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		} else if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has two names, %s and %s", version, migration.Name, match[2])
+		}
+		if match[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]*Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up file", migration.Version, migration.Name)
+		}
+		sum := sha256.Sum256([]byte(migration.Up + migration.Down))
+		migration.Checksum = hex.EncodeToString(sum[:])
+		migrations = append(migrations, migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// Migrator applies and reverts the migrations of a database
+type Migrator struct {
+	db         *DB
+	migrations []*Migration
+}
+
+// NewMigrator creates a migrator for every migration of the database driver
+func NewMigrator(db *DB) (*Migrator, error) {
+	migrations, err := Migrations(db.dialect.name)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// Latest returns the highest defined version, or 0 when there is none
+func (m *Migrator) Latest() int64 {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+func (m *Migrator) find(version int64) *Migration {
+	for _, migration := range m.migrations {
+		if migration.Version == version {
+			return migration
+		}
+	}
+	return nil
+}
+
+func (m *Migrator) ensureLedger(ctx context.Context) error {
+	_, err := m.db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS `+LedgerTable+` (
+		version    BIGINT PRIMARY KEY,
+		name       TEXT NOT NULL,
+		checksum   TEXT NOT NULL,
+		applied_at TIMESTAMP NOT NULL
+	)`)
+	return err
+}
+
+func (m *Migrator) applied(ctx context.Context) ([]record, error) {
+	if err := m.ensureLedger(ctx); err != nil {
+		return nil, err
+	}
+	rows, err := m.db.QueryContext(ctx, `SELECT version, name, checksum, applied_at FROM `+LedgerTable+` ORDER BY version`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var records []record
+	for rows.Next() {
+		var r record
+		if err := rows.Scan(&r.version, &r.name, &r.checksum, &r.appliedAt); err != nil {
+			return nil, err
+		}
+		records = append(records, r)
+	}
+	return records, rows.Err()
+}
+
+// Current returns the last applied version, or 0 when none is applied
+func (m *Migrator) Current(ctx context.Context) (int64, error) {
+	records, err := m.applied(ctx)
+	if err != nil || len(records) == 0 {
+		return 0, err
+	}
+	return records[len(records)-1].version, nil
+}
+
+// Status lists every defined or applied migration ordered by version
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	records, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]record, len(records))
+	for _, r := range records {
+		byVersion[r.version] = r
+	}
+
+	statuses := make([]Status, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := Status{Version: migration.Version, Name: migration.Name}
+		if r, ok := byVersion[migration.Version]; ok {
+			appliedAt := r.appliedAt
+			status.Applied = true
+			status.AppliedAt = &appliedAt
+			status.Modified = r.checksum != migration.Checksum
+			delete(byVersion, migration.Version)
+		}
+		statuses = append(statuses, status)
+	}
+	for _, r := range byVersion {
+		appliedAt := r.appliedAt
+		statuses = append(statuses, Status{Version: r.version, Name: r.name, Applied: true, AppliedAt: &appliedAt, Missing: true})
+	}
+
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Version < statuses[j].Version })
+	return statuses, nil
+}
+
+// Check returns ErrSchemaOutdated unless every migration is applied unmodified
+func (m *Migrator) Check(ctx context.Context) error {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+	for _, status := range statuses {
+		switch {
+		case status.Modified:
+			return fmt.Errorf("%w: %d_%s", ErrChecksumMismatch, status.Version, status.Name)
+		case !status.Applied:
+			return fmt.Errorf("%w: %d_%s is pending", ErrSchemaOutdated, status.Version, status.Name)
+		}
+	}
+	return nil
+}
+
+// Up applies every pending migration and returns the applied ones
+func (m *Migrator) Up(ctx context.Context) ([]*Migration, error) {
+	return m.To(ctx, m.Latest())
+}
+
+// Down reverts the given number of most recent migrations
+func (m *Migrator) Down(ctx context.Context, steps int) ([]*Migration, error) {
+	if steps < 1 {
+		return nil, errors.New("steps must be at least 1")
+	}
+
+	records, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	target := int64(0)
+	if steps < len(records) {
+		target = records[len(records)-steps-1].version
+	}
+	return m.To(ctx, target)
+}
+
+// To migrates up or down until version is the last applied migration.
+// Version 0 reverts every migration, unless one of them is irreversible.
+func (m *Migrator) To(ctx context.Context, version int64) ([]*Migration, error) {
+	if version != 0 && m.find(version) == nil {
+		return nil, fmt.Errorf("%w: %d", ErrUnknownVersion, version)
+	}
+
+	unlock, err := m.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, status := range statuses {
+		if status.Modified {
+			return nil, fmt.Errorf("%w: %d_%s", ErrChecksumMismatch, status.Version, status.Name)
+		}
+	}
+
+	// Check every migration to revert first, so none is reverted when one of
+	// them cannot be
+	var reverts []*Migration
+	for i := len(statuses) - 1; i >= 0; i-- {
+		status := statuses[i]
+		if !status.Applied || status.Version <= version {
+			continue
+		}
+		if status.Missing {
+			return nil, fmt.Errorf("%w: %d is applied but not defined, cannot revert it", ErrUnknownVersion, status.Version)
+		}
+		migration := m.find(status.Version)
+		if migration.Down == "" {
+			return nil, fmt.Errorf("%w: %d_%s", ErrIrreversible, migration.Version, migration.Name)
+		}
+		reverts = append(reverts, migration)
+	}
+
+	var done []*Migration
+
+	// Revert applied migrations above the target, newest first
+	for _, migration := range reverts {
+		err := m.inTx(ctx, migration.Down, `DELETE FROM `+LedgerTable+` WHERE version = ?`, migration.Version)
+		if err != nil {
+			return done, fmt.Errorf("reverting migration %d_%s failed: %w", migration.Version, migration.Name, err)
+		}
+		done = append(done, migration)
+	}
+
+	// Apply pending migrations up to the target, oldest first
+	for _, status := range statuses {
+		if status.Applied || status.Version > version {
+			continue
+		}
+		migration := m.find(status.Version)
+		err := m.inTx(ctx, migration.Up, `INSERT INTO `+LedgerTable+` (version, name, checksum, applied_at) VALUES (?, ?, ?, ?)`,
+			migration.Version, migration.Name, migration.Checksum, m.db.Time(time.Now()))
+		if err != nil {
+			return done, fmt.Errorf("migration %d_%s failed: %w", migration.Version, migration.Name, err)
+		}
+		done = append(done, migration)
+	}
+
+	return done, nil
+}
+
+// lock takes the migration lock of the dialect, held by one connection for
+// the whole run, and returns the function releasing it
+func (m *Migrator) lock(ctx context.Context) (func(), error) {
+	if m.db.dialect.lock == nil {
+		return func() {}, nil
+	}
+
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	locked, err := m.db.dialect.lock(ctx, conn)
+	if err == nil && !locked {
+		err = ErrLocked
+	}
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return func() {
+		m.db.dialect.unlock(context.WithoutCancel(ctx), conn)
+		conn.Close()
+	}, nil
+}
+
+// inTx runs a migration script and its ledger change in one transaction, so
+// a failed migration leaves neither the schema nor the ledger half changed
+func (m *Migrator) inTx(ctx context.Context, script, ledgerQuery string, args ...any) error {
+	return m.db.InTx(ctx, func(tx *Tx) error {
+		if _, err := tx.ExecContext(ctx, script); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx, tx.Rebind(ledgerQuery), args...)
+		return err
+	})
+}
@@ -0,0 +1,45 @@
+-- IDs are the 24 hexadecimal characters of entities.ID, the same as MongoDB
+-- ObjectIDs, so data can move between backends unchanged.
+-- Subscriptions have no foreign keys: like the MongoDB collections, they may
+-- outlive the product or user they reference.
+
+CREATE TABLE users (
+    id         CHAR(24) PRIMARY KEY,
+    username   TEXT NOT NULL CONSTRAINT users_username_unique UNIQUE CHECK (username <> ''),
+    password   TEXT NOT NULL,
+    email      TEXT NOT NULL CONSTRAINT users_email_unique UNIQUE CHECK (length(email) BETWEEN 1 AND 255),
+    status     TEXT NOT NULL CHECK (status IN ('active', 'inactive')),
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE products (
+    id           CHAR(24) PRIMARY KEY,
+    name         TEXT NOT NULL CONSTRAINT products_name_unique UNIQUE CHECK (name <> ''),
+    description  TEXT NOT NULL,
+    price        DOUBLE PRECISION NOT NULL CHECK (price >= 0),
+    billing_type TEXT NOT NULL CHECK (billing_type IN ('weekly', 'monthly', 'yearly')),
+    category     TEXT NOT NULL,
+    status       TEXT NOT NULL CHECK (status IN ('active', 'inactive')),
+    created_at   TIMESTAMPTZ NOT NULL,
+    updated_at   TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX products_category ON products (category);
+
+CREATE TABLE subscriptions (
+    id             CHAR(24) PRIMARY KEY,
+    user_id        CHAR(24) NOT NULL,
+    product_id     CHAR(24) NOT NULL,
+    status         TEXT NOT NULL CHECK (status IN ('active', 'cancelled', 'expired')),
+    start_date     TIMESTAMPTZ NOT NULL,
+    end_date       TIMESTAMPTZ,
+    next_billing   TIMESTAMPTZ NOT NULL,
+    price_at_start DOUBLE PRECISION NOT NULL,
+    created_at     TIMESTAMPTZ NOT NULL,
+    updated_at     TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX subscriptions_user_id ON subscriptions (user_id);
+CREATE INDEX subscriptions_product_id ON subscriptions (product_id);
+CREATE INDEX subscriptions_status_next_billing ON subscriptions (status, next_billing);
@@ -0,0 +1,4 @@
+-- Subscriptions can be paused
+ALTER TABLE subscriptions DROP CONSTRAINT subscriptions_status_check;
+ALTER TABLE subscriptions ADD CONSTRAINT subscriptions_status_check
+    CHECK (status IN ('active', 'paused', 'cancelled', 'expired'));
@@ -0,0 +1,2 @@
+DROP INDEX subscriptions_created_at_id;
+DROP INDEX subscriptions_next_billing_id;
@@ -0,0 +1,3 @@
+-- Keyset pagination of subscription listings sorts by a column, then by id
+CREATE INDEX subscriptions_next_billing_id ON subscriptions (next_billing, id);
+CREATE INDEX subscriptions_created_at_id ON subscriptions (created_at, id);
@@ -0,0 +1,27 @@
+-- Subscriptions can be paused. SQLite cannot alter a CHECK constraint, so the
+-- table is rebuilt with the same columns and indexes.
+
+CREATE TABLE subscriptions_rebuilt (
+    id             TEXT PRIMARY KEY,
+    user_id        TEXT NOT NULL,
+    product_id     TEXT NOT NULL,
+    status         TEXT NOT NULL CHECK (status IN ('active', 'paused', 'cancelled', 'expired')),
+    start_date     TIMESTAMP NOT NULL,
+    end_date       TIMESTAMP,
+    next_billing   TIMESTAMP NOT NULL,
+    price_at_start REAL NOT NULL,
+    created_at     TIMESTAMP NOT NULL,
+    updated_at     TIMESTAMP NOT NULL
+);
+
+INSERT INTO subscriptions_rebuilt (id, user_id, product_id, status, start_date, end_date, next_billing, price_at_start, created_at, updated_at)
+SELECT id, user_id, product_id, status, start_date, end_date, next_billing, price_at_start, created_at, updated_at FROM subscriptions;
+
+DROP TABLE subscriptions;
+ALTER TABLE subscriptions_rebuilt RENAME TO subscriptions;
+
+CREATE INDEX subscriptions_user_id ON subscriptions (user_id);
+CREATE INDEX subscriptions_product_id ON subscriptions (product_id);
+CREATE INDEX subscriptions_status_next_billing ON subscriptions (status, next_billing);
+CREATE INDEX subscriptions_next_billing_id ON subscriptions (next_billing, id);
+CREATE INDEX subscriptions_created_at_id ON subscriptions (created_at, id);
@@ -0,0 +1,152 @@
+// Package sqlstore opens the SQL databases behind the SQL repositories and
+// applies their schema migrations.
+//
+// Queries are written once with ? placeholders and rewritten for the dialect
+// of the connection by DB.Rebind, so the repositories are shared by every SQL
+// backend.
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+)
+
+// Handle runs queries in the dialect of a database. It is implemented by DB
+// and Tx, so repositories work the same inside and outside a transaction.
+type Handle interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+	Rebind(query string) string
+	IsUniqueViolation(err error) bool
+	Time(t time.Time) any
+	NullTime(t *time.Time) any
+}
+
+// DB is a SQL connection pool together with its dialect
+type DB struct {
+	*sql.DB
+	dialect *dialect
+}
+
+// Tx is a transaction together with its dialect
+type Tx struct {
+	*sql.Tx
+	dialect *dialect
+}
+
+// Config holds SQL connection configuration
+type Config struct {
+	// Driver is one of the Driver constants
+	Driver string
+	DSN    string
+	// Timeout bounds the first connection, zero means no limit. SQLite
+	// opens a local file and needs none.
+	Timeout time.Duration
+}
+
+// Open connects to the database and checks that it answers
+func Open(config Config) (*DB, error) {
+	dialect, ok := dialects[config.Driver]
+	if !ok {
+		return nil, fmt.Errorf("unknown SQL driver %q", config.Driver)
+	}
+
+	log.Printf("🔄 Connecting to %s...", dialect.title)
+	pool, err := sql.Open(dialect.driverName, dialect.dsn(config.DSN))
+	if err != nil {
+		log.Printf("❌ Failed to open %s: %v", dialect.title, err)
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if config.Timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, config.Timeout)
+	}
+	defer cancel()
+	if err := pool.PingContext(ctx); err != nil {
+		pool.Close()
+		log.Printf("❌ Failed to ping %s: %v", dialect.title, err)
+		return nil, err
+	}
+
+	log.Printf("✅ Connected to %s", dialect.title)
+	return &DB{DB: pool, dialect: dialect}, nil
+}
+
+// Close closes the connection pool
+func (db *DB) Close() error {
+	log.Printf("🔄 Closing %s connection...", db.dialect.title)
+	if err := db.DB.Close(); err != nil {
+		log.Printf("❌ Error closing %s connection: %v", db.dialect.title, err)
+		return err
+	}
+	log.Printf("✅ %s connection closed successfully", db.dialect.title)
+	return nil
+}
+
+// Driver returns the driver the database was opened with
+func (db *DB) Driver() string {
+	return db.dialect.name
+}
+
+// Title returns the display name of the database, e.g. PostgreSQL
+func (db *DB) Title() string {
+	return db.dialect.title
+}
+
+// InTx runs fn in a transaction, committed when fn returns nil
+func (db *DB) InTx(ctx context.Context, fn func(tx *Tx) error) error {
+	sqlTx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(&Tx{Tx: sqlTx, dialect: db.dialect}); err != nil {
+		sqlTx.Rollback()
+		return err
+	}
+	return sqlTx.Commit()
+}
+
+// Rebind rewrites the ? placeholders of query for the dialect
+func (db *DB) Rebind(query string) string {
+	return db.dialect.rebind(query)
+}
+
+// IsUniqueViolation returns true if err reports a violated unique constraint
+func (db *DB) IsUniqueViolation(err error) bool {
+	return err != nil && db.dialect.isUniqueViolation(err)
+}
+
+// Time converts a time to a query argument of the dialect
+func (db *DB) Time(t time.Time) any {
+	return db.dialect.time(t)
+}
+
+// NullTime converts an optional time to a query argument of the dialect
+func (db *DB) NullTime(t *time.Time) any {
+	return db.dialect.nullTime(t)
+}
+
+// Rebind rewrites the ? placeholders of query for the dialect
+func (tx *Tx) Rebind(query string) string {
+	return tx.dialect.rebind(query)
+}
+
+// IsUniqueViolation returns true if err reports a violated unique constraint
+func (tx *Tx) IsUniqueViolation(err error) bool {
+	return err != nil && tx.dialect.isUniqueViolation(err)
+}
+
+// Time converts a time to a query argument of the dialect
+func (tx *Tx) Time(t time.Time) any {
+	return tx.dialect.time(t)
+}
+
+// NullTime converts an optional time to a query argument of the dialect
+func (tx *Tx) NullTime(t *time.Time) any {
+	return tx.dialect.nullTime(t)
+}
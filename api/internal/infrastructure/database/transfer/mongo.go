@@ -0,0 +1,63 @@
+package transfer
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/frtasoniero/subsmanager/internal/domain/entities"
+	"github.com/frtasoniero/subsmanager/internal/infrastructure/database/seeds"
+)
+
+// MongoStore reads and writes the collections of a MongoDB database
+type MongoStore struct {
+	db *mongo.Database
+}
+
+func NewMongoStore(db *mongo.Database) *MongoStore {
+	return &MongoStore{db: db}
+}
+
+func (s *MongoStore) Read(ctx context.Context) (*seeds.Dataset, error) {
+	var dataset seeds.Dataset
+	if err := readAll(ctx, s.db.Collection("products"), &dataset.Products); err != nil {
+		return nil, err
+	}
+	if err := readAll(ctx, s.db.Collection("users"), &dataset.Users); err != nil {
+		return nil, err
+	}
+	if err := readAll(ctx, s.db.Collection("subscriptions"), &dataset.Subscriptions); err != nil {
+		return nil, err
+	}
+	return &dataset, nil
+}
+
+func (s *MongoStore) IsEmpty(ctx context.Context) (bool, error) {
+	return seeds.IsEmpty(ctx, s.db)
+}
+
+// Write is not atomic: MongoDB transactions need a replica set, which a
+// single-user deployment usually does not run
+func (s *MongoStore) Write(ctx context.Context, dataset *seeds.Dataset, replace bool) error {
+	if replace {
+		if _, err := seeds.Clear(ctx, s.db); err != nil {
+			return err
+		}
+	}
+	return seeds.Insert(ctx, s.db, dataset)
+}
+
+// readAll decodes every document of a collection, in ID order
+func readAll[T entities.User | entities.Product | entities.Subscription](ctx context.Context, collection *mongo.Collection, documents *[]*T) error {
+	cursor, err := collection.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", collection.Name(), err)
+	}
+	if err := cursor.All(ctx, documents); err != nil {
+		return fmt.Errorf("failed to read %s: %w", collection.Name(), err)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package transfer
+
+import (
+	"context"
+
+	"github.com/frtasoniero/subsmanager/internal/infrastructure/database/repositories"
+	"github.com/frtasoniero/subsmanager/internal/infrastructure/database/seeds"
+	"github.com/frtasoniero/subsmanager/internal/infrastructure/database/sqlstore"
+)
+
+// sqlTables are the tables of a dataset, in deletion order
+var sqlTables = []string{"subscriptions", "users", "products"}
+
+// SQLStore reads and writes the tables of a PostgreSQL or SQLite database
+type SQLStore struct {
+	db *sqlstore.DB
+}
+
+func NewSQLStore(db *sqlstore.DB) *SQLStore {
+	return &SQLStore{db: db}
+}
+
+func (s *SQLStore) Read(ctx context.Context) (*seeds.Dataset, error) {
+	var dataset seeds.Dataset
+	var err error
+	if dataset.Products, err = repositories.NewSQLProductRepository(s.db).GetAll(ctx); err != nil {
+		return nil, err
+	}
+	if dataset.Users, err = repositories.NewSQLUserRepository(s.db).GetAll(ctx); err != nil {
+		return nil, err
+	}
+	// All rather than GetAll, which leaves out subscriptions of deleted products
+	if dataset.Subscriptions, err = repositories.NewSQLSubscriptionRepository(s.db).All(ctx); err != nil {
+		return nil, err
+	}
+	return &dataset, nil
+}
+
+func (s *SQLStore) IsEmpty(ctx context.Context) (bool, error) {
+	for _, table := range sqlTables {
+		var exists bool
+		if err := s.db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM `+table+`)`).Scan(&exists); err != nil {
+			return false, err
+		}
+		if exists {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// Write runs in a single transaction, so a failed copy leaves the database
+// as it was
+func (s *SQLStore) Write(ctx context.Context, dataset *seeds.Dataset, replace bool) error {
+	return s.db.InTx(ctx, func(tx *sqlstore.Tx) error {
+		if replace {
+			for _, table := range sqlTables {
+				if _, err := tx.ExecContext(ctx, `DELETE FROM `+table); err != nil {
+					return err
+				}
+			}
+		}
+		return seeds.Create(ctx, repositories.NewSQLUserRepository(tx), repositories.NewSQLProductRepository(tx),
+			repositories.NewSQLSubscriptionRepository(tx), dataset)
+	})
+}
@@ -0,0 +1,52 @@
+// Package transfer copies the data of one storage backend to another, for
+// example from MongoDB to an embedded SQLite file and back.
+//
+// Products, users and subscriptions keep their IDs and timestamps, so the
+// references between them survive the copy.
+package transfer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/frtasoniero/subsmanager/internal/infrastructure/database/seeds"
+)
+
+// ErrTargetNotEmpty is returned by Copy when the target already holds data
+// and replace is not set
+var ErrTargetNotEmpty = errors.New("target storage is not empty")
+
+// Store is a storage backend data can be copied from and to
+type Store interface {
+	// Read loads every product, user and subscription
+	Read(ctx context.Context) (*seeds.Dataset, error)
+	// IsEmpty returns true if the store holds no product, user or subscription
+	IsEmpty(ctx context.Context) (bool, error)
+	// Write inserts the dataset, after removing the existing data when
+	// replace is set
+	Write(ctx context.Context, dataset *seeds.Dataset, replace bool) error
+}
+
+// Copy copies every product, user and subscription of from into to and
+// returns what was copied
+func Copy(ctx context.Context, from, to Store, replace bool) (*seeds.Dataset, error) {
+	if !replace {
+		empty, err := to.IsEmpty(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if !empty {
+			return nil, ErrTargetNotEmpty
+		}
+	}
+
+	dataset, err := from.Read(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read source: %w", err)
+	}
+	if err := to.Write(ctx, dataset, replace); err != nil {
+		return nil, fmt.Errorf("failed to write target: %w", err)
+	}
+	return dataset, nil
+}
@@ -0,0 +1,115 @@
+// Package events publishes subscription writes to in-process listeners,
+// such as the gRPC WatchSubscriptions streams. SubscriptionRepository
+// decorates a repository and publishes after every successful write, so
+// writes made by other processes, such as the CLI, are not seen.
+package events
+
+import (
+	"sync"
+	"time"
+
+	"github.com/frtasoniero/subsmanager/internal/domain/entities"
+)
+
+// Types of events
+const (
+	TypeCreated = "created"
+	TypeUpdated = "updated"
+	TypeDeleted = "deleted"
+)
+
+// bufferSize is the number of events a listener can fall behind by before
+// it is dropped
+const bufferSize = 64
+
+// Event is a write of a subscription
+type Event struct {
+	Type string
+	// Subscription is the subscription after the write, or before it when
+	// deleted
+	Subscription entities.Subscription
+	At           time.Time
+}
+
+// Broker fans events out to listeners. Publishing never blocks: a listener
+// whose buffer is full is dropped and its channel closed.
+type Broker struct {
+	mu        sync.Mutex
+	listeners map[*listener]struct{}
+	closed    bool
+	closeOnce sync.Once
+}
+
+type listener struct {
+	events chan Event
+	accept func(Event) bool
+}
+
+func NewBroker() *Broker {
+	return &Broker{listeners: make(map[*listener]struct{})}
+}
+
+// Subscribe returns a channel receiving the events accepted by accept, nil
+// accepting every event. The channel is closed by unsubscribe, when the
+// listener falls behind or when the broker is closed. Call unsubscribe once
+// done listening.
+func (b *Broker) Subscribe(accept func(Event) bool) (events <-chan Event, unsubscribe func()) {
+	l := &listener{events: make(chan Event, bufferSize), accept: accept}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		close(l.events)
+	} else {
+		b.listeners[l] = struct{}{}
+	}
+	return l.events, func() { b.remove(l) }
+}
+
+// Publish sends an event to every listener accepting it
+func (b *Broker) Publish(event Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for l := range b.listeners {
+		if l.accept != nil && !l.accept(event) {
+			continue
+		}
+		select {
+		case l.events <- event:
+		default:
+			delete(b.listeners, l)
+			close(l.events)
+		}
+	}
+}
+
+// Close closes the channels of every listener, ending their streams, and of
+// the listeners subscribing afterwards. Calls after the first do nothing, so
+// both the server shutdown and App.Close can call it.
+func (b *Broker) Close() {
+	b.closeOnce.Do(func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		b.closed = true
+		for l := range b.listeners {
+			delete(b.listeners, l)
+			close(l.events)
+		}
+	})
+}
+
+// Closed returns true once Close was called, telling listeners whose
+// channel is closed that they were not dropped for falling behind
+func (b *Broker) Closed() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.closed
+}
+
+func (b *Broker) remove(l *listener) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.listeners[l]; ok {
+		delete(b.listeners, l)
+		close(l.events)
+	}
+}
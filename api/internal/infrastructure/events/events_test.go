@@ -0,0 +1,137 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/frtasoniero/subsmanager/internal/domain/entities"
+)
+
+func event(userID entities.ID) Event {
+	return Event{Type: TypeCreated, Subscription: entities.Subscription{ID: entities.NewID(), UserID: userID}}
+}
+
+// drain returns the events buffered in ch and whether ch is closed
+func drain(ch <-chan Event) (received []Event, closed bool) {
+	for {
+		select {
+		case e, ok := <-ch:
+			if !ok {
+				return received, true
+			}
+			received = append(received, e)
+		default:
+			return received, false
+		}
+	}
+}
+
+func TestBrokerDropsSlowListener(t *testing.T) {
+	b := NewBroker()
+	slow, unsubscribe := b.Subscribe(nil)
+	defer unsubscribe()
+	fast, unsubscribeFast := b.Subscribe(nil)
+	defer unsubscribeFast()
+
+	for i := 0; i < bufferSize; i++ {
+		b.Publish(event(entities.NewID()))
+		drain(fast)
+	}
+	received, closed := drain(slow)
+	if len(received) != bufferSize || closed {
+		t.Fatalf("slow listener got %d events, closed %t, want %d buffered and open", len(received), closed, bufferSize)
+	}
+
+	for i := 0; i <= bufferSize; i++ {
+		b.Publish(event(entities.NewID()))
+		drain(fast)
+	}
+	received, closed = drain(slow)
+	if len(received) != bufferSize || !closed {
+		t.Errorf("slow listener got %d events, closed %t, want %d then closed", len(received), closed, bufferSize)
+	}
+	if _, closed := drain(fast); closed {
+		t.Errorf("fast listener dropped with the slow one")
+	}
+	if b.Closed() {
+		t.Errorf("Closed is true after dropping a listener")
+	}
+}
+
+func TestBrokerAccept(t *testing.T) {
+	b := NewBroker()
+	alice, bob := entities.NewID(), entities.NewID()
+	events, unsubscribe := b.Subscribe(func(e Event) bool { return e.Subscription.UserID == alice })
+	defer unsubscribe()
+
+	b.Publish(event(bob))
+	b.Publish(event(alice))
+	b.Publish(event(bob))
+
+	received, closed := drain(events)
+	if closed {
+		t.Fatalf("listener closed")
+	}
+	if len(received) != 1 || received[0].Subscription.UserID != alice {
+		t.Errorf("listener received %+v, want the event of %s only", received, alice)
+	}
+
+	// Rejected events do not count against the buffer
+	for i := 0; i < 2*bufferSize; i++ {
+		b.Publish(event(bob))
+	}
+	if _, closed := drain(events); closed {
+		t.Errorf("listener dropped for events it rejected")
+	}
+}
+
+func TestBrokerCloseEndsListeners(t *testing.T) {
+	b := NewBroker()
+	before, unsubscribeBefore := b.Subscribe(nil)
+	b.Publish(event(entities.NewID()))
+
+	b.Close()
+	b.Close()
+	if !b.Closed() {
+		t.Fatalf("Closed is false after Close")
+	}
+	received, closed := drain(before)
+	if len(received) != 1 || !closed {
+		t.Errorf("listener got %d events, closed %t, want the buffered event then closed", len(received), closed)
+	}
+
+	after, unsubscribeAfter := b.Subscribe(nil)
+	if _, closed := drain(after); !closed {
+		t.Errorf("listener subscribing after Close is open")
+	}
+	b.Publish(event(entities.NewID()))
+
+	// Unsubscribing from a closed broker must not close the channels again
+	unsubscribeBefore()
+	unsubscribeAfter()
+}
+
+func TestBrokerUnsubscribe(t *testing.T) {
+	b := NewBroker()
+	events, unsubscribe := b.Subscribe(nil)
+	unsubscribe()
+	if _, closed := drain(events); !closed {
+		t.Fatalf("channel open after unsubscribe")
+	}
+	unsubscribe()
+	b.Publish(event(entities.NewID()))
+}
+
+func TestBrokerUnsubscribeAfterDrop(t *testing.T) {
+	b := NewBroker()
+	events, unsubscribe := b.Subscribe(nil)
+	for i := 0; i <= bufferSize; i++ {
+		b.Publish(event(entities.NewID()))
+	}
+	if _, closed := drain(events); !closed {
+		t.Fatalf("listener not dropped after %d events", bufferSize+1)
+	}
+
+	// A second close of the channel would panic
+	unsubscribe()
+	b.Publish(event(entities.NewID()))
+}
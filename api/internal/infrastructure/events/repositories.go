@@ -0,0 +1,57 @@
+package events
+
+import (
+	"context"
+	"time"
+
+	"github.com/frtasoniero/subsmanager/internal/domain/entities"
+	"github.com/frtasoniero/subsmanager/internal/domain/repositories"
+)
+
+// SubscriptionRepository publishes an event after every successful
+// subscription write
+type SubscriptionRepository struct {
+	repositories.SubscriptionRepository
+	broker *Broker
+}
+
+func NewSubscriptionRepository(subscriptions repositories.SubscriptionRepository, broker *Broker) *SubscriptionRepository {
+	return &SubscriptionRepository{
+		SubscriptionRepository: subscriptions,
+		broker:                 broker,
+	}
+}
+
+func (r *SubscriptionRepository) Create(ctx context.Context, subscription *entities.Subscription) error {
+	if err := r.SubscriptionRepository.Create(ctx, subscription); err != nil {
+		return err
+	}
+	r.publish(TypeCreated, subscription)
+	return nil
+}
+
+func (r *SubscriptionRepository) Update(ctx context.Context, subscription *entities.Subscription) error {
+	if err := r.SubscriptionRepository.Update(ctx, subscription); err != nil {
+		return err
+	}
+	r.publish(TypeUpdated, subscription)
+	return nil
+}
+
+// Delete reads the subscription first, so the event tells whose
+// subscription was deleted
+func (r *SubscriptionRepository) Delete(ctx context.Context, id entities.ID) error {
+	subscription, err := r.SubscriptionRepository.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := r.SubscriptionRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+	r.publish(TypeDeleted, subscription)
+	return nil
+}
+
+func (r *SubscriptionRepository) publish(eventType string, subscription *entities.Subscription) {
+	r.broker.Publish(Event{Type: eventType, Subscription: *subscription, At: time.Now()})
+}
@@ -0,0 +1,117 @@
+package rpc
+
+import (
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/frtasoniero/subsmanager/internal/domain/entities"
+	"github.com/frtasoniero/subsmanager/internal/domain/repositories"
+	"github.com/frtasoniero/subsmanager/internal/infrastructure/events"
+	pb "github.com/frtasoniero/subsmanager/pkg/pb/subsmanager/v1"
+)
+
+// Enum values of the domain statuses and types
+var (
+	userStatuses = map[string]pb.UserStatus{
+		"active":   pb.UserStatus_USER_STATUS_ACTIVE,
+		"inactive": pb.UserStatus_USER_STATUS_INACTIVE,
+	}
+	productStatuses = map[string]pb.ProductStatus{
+		"active":   pb.ProductStatus_PRODUCT_STATUS_ACTIVE,
+		"inactive": pb.ProductStatus_PRODUCT_STATUS_INACTIVE,
+	}
+	billingTypes = map[string]pb.BillingType{
+		"weekly":  pb.BillingType_BILLING_TYPE_WEEKLY,
+		"monthly": pb.BillingType_BILLING_TYPE_MONTHLY,
+		"yearly":  pb.BillingType_BILLING_TYPE_YEARLY,
+	}
+	subscriptionStatuses = map[string]pb.SubscriptionStatus{
+		"active":    pb.SubscriptionStatus_SUBSCRIPTION_STATUS_ACTIVE,
+		"paused":    pb.SubscriptionStatus_SUBSCRIPTION_STATUS_PAUSED,
+		"cancelled": pb.SubscriptionStatus_SUBSCRIPTION_STATUS_CANCELLED,
+		"expired":   pb.SubscriptionStatus_SUBSCRIPTION_STATUS_EXPIRED,
+	}
+	subscriptionSorts = map[pb.SubscriptionSort]string{
+		pb.SubscriptionSort_SUBSCRIPTION_SORT_NEXT_BILLING: repositories.SortNextBilling,
+		pb.SubscriptionSort_SUBSCRIPTION_SORT_PRICE:        repositories.SortPrice,
+		pb.SubscriptionSort_SUBSCRIPTION_SORT_CREATE_TIME:  repositories.SortCreatedAt,
+	}
+	eventTypes = map[string]pb.SubscriptionEventType{
+		events.TypeCreated: pb.SubscriptionEventType_SUBSCRIPTION_EVENT_TYPE_CREATED,
+		events.TypeUpdated: pb.SubscriptionEventType_SUBSCRIPTION_EVENT_TYPE_UPDATED,
+		events.TypeDeleted: pb.SubscriptionEventType_SUBSCRIPTION_EVENT_TYPE_DELETED,
+	}
+)
+
+// domainStatus returns the domain value of a subscription status, empty for
+// SUBSCRIPTION_STATUS_UNSPECIFIED
+func domainStatus(status pb.SubscriptionStatus) string {
+	for value, enum := range subscriptionStatuses {
+		if enum == status {
+			return value
+		}
+	}
+	return ""
+}
+
+func timestamp(t time.Time) *timestamppb.Timestamp {
+	return timestamppb.New(t)
+}
+
+func toUser(user *entities.User) *pb.User {
+	return &pb.User{
+		Id:         user.ID.String(),
+		Username:   user.Username,
+		Status:     userStatuses[user.Status],
+		CreateTime: timestamp(user.CreatedAt),
+		UpdateTime: timestamp(user.UpdatedAt),
+	}
+}
+
+func toProduct(product *entities.Product) *pb.Product {
+	return &pb.Product{
+		Id:          product.ID.String(),
+		Name:        product.Name,
+		Description: product.Description,
+		Price:       product.Price,
+		BillingType: billingTypes[product.BillingType],
+		Category:    product.Category,
+		Status:      productStatuses[product.Status],
+		CreateTime:  timestamp(product.CreatedAt),
+		UpdateTime:  timestamp(product.UpdatedAt),
+	}
+}
+
+func toSubscription(subscription *entities.Subscription) *pb.Subscription {
+	message := &pb.Subscription{
+		Id:           subscription.ID.String(),
+		UserId:       subscription.UserID.String(),
+		ProductId:    subscription.ProductID.String(),
+		Status:       subscriptionStatuses[subscription.Status],
+		StartDate:    timestamp(subscription.StartDate),
+		NextBilling:  timestamp(subscription.NextBilling),
+		PriceAtStart: subscription.PriceAtStart,
+		CreateTime:   timestamp(subscription.CreatedAt),
+	}
+	if subscription.EndDate != nil {
+		message.EndDate = timestamp(*subscription.EndDate)
+	}
+	return message
+}
+
+// toJoinedSubscription converts a subscription read with its product, whose
+// product fields the message leaves out
+func toJoinedSubscription(subscription *entities.SubscriptionWithProduct) *pb.Subscription {
+	return toSubscription(&entities.Subscription{
+		ID:           subscription.ID,
+		UserID:       subscription.UserID,
+		ProductID:    subscription.ProductID,
+		Status:       subscription.Status,
+		StartDate:    subscription.StartDate,
+		EndDate:      subscription.EndDate,
+		NextBilling:  subscription.NextBilling,
+		PriceAtStart: subscription.PriceAtStart,
+		CreatedAt:    subscription.CreatedAt,
+	})
+}
@@ -0,0 +1,92 @@
+package rpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/frtasoniero/subsmanager/internal/domain/entities"
+	"github.com/frtasoniero/subsmanager/internal/usecases"
+	pb "github.com/frtasoniero/subsmanager/pkg/pb/subsmanager/v1"
+)
+
+// maxBatchGet bounds the IDs of a BatchGetProducts request
+const maxBatchGet = 500
+
+type ProductService struct {
+	pb.UnimplementedProductServiceServer
+	productUseCase *usecases.ProductUseCase
+}
+
+func NewProductService(productUseCase *usecases.ProductUseCase) *ProductService {
+	return &ProductService{
+		productUseCase: productUseCase,
+	}
+}
+
+func (s *ProductService) GetProduct(ctx context.Context, req *pb.GetProductRequest) (*pb.GetProductResponse, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	product, err := s.productUseCase.GetProductByID(ctx, id)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &pb.GetProductResponse{Product: toProduct(product)}, nil
+}
+
+func (s *ProductService) BatchGetProducts(ctx context.Context, req *pb.BatchGetProductsRequest) (*pb.BatchGetProductsResponse, error) {
+	if len(req.GetIds()) > maxBatchGet {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d IDs can be requested at once", maxBatchGet)
+	}
+	ids := make([]entities.ID, len(req.GetIds()))
+	for i, value := range req.GetIds() {
+		id, err := parseID("ids", value)
+		if err != nil {
+			return nil, err
+		}
+		ids[i] = id
+	}
+
+	products, err := s.productUseCase.GetProductsByIDs(ctx, ids)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &pb.BatchGetProductsResponse{Products: toProducts(products)}, nil
+}
+
+func (s *ProductService) ListProducts(ctx context.Context, req *pb.ListProductsRequest) (*pb.ListProductsResponse, error) {
+	var products []*entities.Product
+	var err error
+	switch {
+	case req.GetCategory() != "":
+		products, err = s.productUseCase.GetProductsByCategory(ctx, req.GetCategory())
+	case req.GetActiveOnly():
+		products, err = s.productUseCase.GetActiveProducts(ctx)
+	default:
+		products, err = s.productUseCase.GetAllProducts(ctx)
+	}
+	if err != nil {
+		return nil, statusError(err)
+	}
+	if req.GetActiveOnly() {
+		var active []*entities.Product
+		for _, product := range products {
+			if product.IsActive() {
+				active = append(active, product)
+			}
+		}
+		products = active
+	}
+	return &pb.ListProductsResponse{Products: toProducts(products)}, nil
+}
+
+func toProducts(products []*entities.Product) []*pb.Product {
+	messages := make([]*pb.Product, len(products))
+	for i, product := range products {
+		messages[i] = toProduct(product)
+	}
+	return messages
+}
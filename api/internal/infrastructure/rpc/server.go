@@ -0,0 +1,119 @@
+// Package rpc serves the gRPC API defined in proto/subsmanager/v1. Its
+// services call the same use cases as the REST handlers; the generated
+// messages and clients live in pkg/pb/subsmanager/v1.
+package rpc
+
+import (
+	"context"
+	"errors"
+	"log"
+	"runtime/debug"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+
+	"github.com/frtasoniero/subsmanager/internal/domain/entities"
+	"github.com/frtasoniero/subsmanager/internal/domain/repositories"
+	"github.com/frtasoniero/subsmanager/internal/usecases"
+	pb "github.com/frtasoniero/subsmanager/pkg/pb/subsmanager/v1"
+)
+
+// Services holds the gRPC services for dependency injection
+type Services struct {
+	Subscription *SubscriptionService
+	Product      *ProductService
+	User         *UserService
+}
+
+// NewServer creates a gRPC server logging every call and recovering from
+// panics, like the middleware of the Gin router
+func NewServer() *grpc.Server {
+	return grpc.NewServer(
+		grpc.ChainUnaryInterceptor(logUnary, recoverUnary),
+		grpc.ChainStreamInterceptor(logStream, recoverStream),
+	)
+}
+
+// RegisterServices registers the services on a server with the standard
+// health service. Reflection, which lets tools such as grpcurl call the
+// services without the proto files, is registered when reflect is set.
+func RegisterServices(server *grpc.Server, services *Services, reflect bool) {
+	pb.RegisterSubscriptionServiceServer(server, services.Subscription)
+	pb.RegisterProductServiceServer(server, services.Product)
+	pb.RegisterUserServiceServer(server, services.User)
+
+	healthpb.RegisterHealthServer(server, health.NewServer())
+	if reflect {
+		reflection.Register(server)
+	}
+}
+
+// statusError returns the gRPC status of a use case error
+func statusError(err error) error {
+	switch {
+	case errors.Is(err, repositories.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, repositories.ErrDuplicate):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, entities.ErrInvalidID), errors.Is(err, usecases.ErrInvalidListQuery), errors.Is(err, repositories.ErrInvalid):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, usecases.ErrInvalidStatusChange):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+// parseID validates an ID field of a request
+func parseID(field, value string) (entities.ID, error) {
+	id, err := entities.ParseID(value)
+	if err != nil {
+		return "", status.Errorf(codes.InvalidArgument, "%s: %v", field, err)
+	}
+	return id, nil
+}
+
+func logUnary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	logCall(info.FullMethod, start, err)
+	return resp, err
+}
+
+func logStream(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	err := handler(srv, stream)
+	logCall(info.FullMethod, start, err)
+	return err
+}
+
+func logCall(method string, start time.Time, err error) {
+	log.Printf("[gRPC] %-16s | %13v | %s", status.Code(err), time.Since(start), method)
+}
+
+func recoverUnary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	defer recoverCall(info.FullMethod, &err)
+	return handler(ctx, req)
+}
+
+func recoverStream(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer recoverCall(info.FullMethod, &err)
+	return handler(srv, stream)
+}
+
+// recoverCall turns a panic of a call into an Internal error
+func recoverCall(method string, err *error) {
+	if r := recover(); r != nil {
+		log.Printf("❌ Panic in %s: %v\n%s", method, r, debug.Stack())
+		*err = status.Error(codes.Internal, "internal error")
+	}
+}
@@ -0,0 +1,201 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/frtasoniero/subsmanager/internal/domain/entities"
+	"github.com/frtasoniero/subsmanager/internal/domain/repositories"
+	"github.com/frtasoniero/subsmanager/internal/infrastructure/events"
+	"github.com/frtasoniero/subsmanager/internal/usecases"
+	pb "github.com/frtasoniero/subsmanager/pkg/pb/subsmanager/v1"
+)
+
+type SubscriptionService struct {
+	pb.UnimplementedSubscriptionServiceServer
+	subscriptionUseCase *usecases.SubscriptionUseCase
+	productUseCase      *usecases.ProductUseCase
+	userUseCase         *usecases.UserUseCase
+	events              *events.Broker
+}
+
+func NewSubscriptionService(subscriptionUseCase *usecases.SubscriptionUseCase, productUseCase *usecases.ProductUseCase, userUseCase *usecases.UserUseCase, broker *events.Broker) *SubscriptionService {
+	return &SubscriptionService{
+		subscriptionUseCase: subscriptionUseCase,
+		productUseCase:      productUseCase,
+		userUseCase:         userUseCase,
+		events:              broker,
+	}
+}
+
+func (s *SubscriptionService) GetSubscription(ctx context.Context, req *pb.GetSubscriptionRequest) (*pb.GetSubscriptionResponse, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	subscription, err := s.subscriptionUseCase.GetSubscriptionByID(ctx, id)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &pb.GetSubscriptionResponse{Subscription: toSubscription(subscription)}, nil
+}
+
+func (s *SubscriptionService) ListSubscriptions(ctx context.Context, req *pb.ListSubscriptionsRequest) (*pb.ListSubscriptionsResponse, error) {
+	query := usecases.SubscriptionListQuery{
+		Sort:   subscriptionSorts[req.GetSort()],
+		Limit:  int(req.GetPageSize()),
+		Cursor: req.GetPageToken(),
+		Filter: repositories.SubscriptionFilter{
+			Status:   domainStatus(req.GetStatus()),
+			Category: req.GetCategory(),
+		},
+	}
+	if req.GetDescending() {
+		query.Order = usecases.OrderDesc
+	}
+	if req.GetUserId() != "" {
+		userID, err := parseID("user_id", req.GetUserId())
+		if err != nil {
+			return nil, err
+		}
+		query.Filter.UserID = &userID
+	}
+	if req.GetProductId() != "" {
+		productID, err := parseID("product_id", req.GetProductId())
+		if err != nil {
+			return nil, err
+		}
+		query.Filter.ProductID = &productID
+	}
+
+	page, err := s.subscriptionUseCase.ListSubscriptions(ctx, query)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	resp := &pb.ListSubscriptionsResponse{
+		Subscriptions: make([]*pb.Subscription, len(page.Subscriptions)),
+		NextPageToken: page.Pagination.NextCursor,
+	}
+	for i, subscription := range page.Subscriptions {
+		resp.Subscriptions[i] = toJoinedSubscription(subscription)
+	}
+	return resp, nil
+}
+
+// CreateSubscription subscribes a user to a product. Like "cli subscriptions
+// create", the price defaults to the product price and the next billing date
+// to one billing cycle after the start date.
+func (s *SubscriptionService) CreateSubscription(ctx context.Context, req *pb.CreateSubscriptionRequest) (*pb.CreateSubscriptionResponse, error) {
+	userID, err := parseID("user_id", req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	productID, err := parseID("product_id", req.GetProductId())
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.userUseCase.GetUserByID(ctx, userID); err != nil {
+		return nil, statusError(fmt.Errorf("user %s: %w", userID, err))
+	}
+	product, err := s.productUseCase.GetProductByID(ctx, productID)
+	if err != nil {
+		return nil, statusError(fmt.Errorf("product %s: %w", productID, err))
+	}
+
+	subscription := &entities.Subscription{
+		UserID:       userID,
+		ProductID:    productID,
+		PriceAtStart: product.Price,
+		StartDate:    time.Now(),
+	}
+	if req.PriceAtStart != nil {
+		subscription.PriceAtStart = req.GetPriceAtStart()
+	}
+	if req.GetStartDate() != nil {
+		subscription.StartDate = req.GetStartDate().AsTime()
+	}
+	subscription.NextBilling = entities.NextBillingDate(subscription.StartDate, product.BillingType)
+	if req.GetNextBilling() != nil {
+		subscription.NextBilling = req.GetNextBilling().AsTime()
+	}
+
+	if err := s.subscriptionUseCase.CreateSubscription(ctx, subscription); err != nil {
+		return nil, statusError(err)
+	}
+	return &pb.CreateSubscriptionResponse{Subscription: toSubscription(subscription)}, nil
+}
+
+func (s *SubscriptionService) CancelSubscription(ctx context.Context, req *pb.CancelSubscriptionRequest) (*pb.CancelSubscriptionResponse, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	subscription, err := s.subscriptionUseCase.CancelSubscription(ctx, id)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &pb.CancelSubscriptionResponse{Subscription: toSubscription(subscription)}, nil
+}
+
+func (s *SubscriptionService) PauseSubscription(ctx context.Context, req *pb.PauseSubscriptionRequest) (*pb.PauseSubscriptionResponse, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	subscription, err := s.subscriptionUseCase.PauseSubscription(ctx, id)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &pb.PauseSubscriptionResponse{Subscription: toSubscription(subscription)}, nil
+}
+
+// WatchSubscriptions streams subscription events until the client cancels,
+// falls behind or the server shuts down
+func (s *SubscriptionService) WatchSubscriptions(req *pb.WatchSubscriptionsRequest, stream grpc.ServerStreamingServer[pb.WatchSubscriptionsResponse]) error {
+	var accept func(events.Event) bool
+	if req.GetUserId() != "" {
+		userID, err := parseID("user_id", req.GetUserId())
+		if err != nil {
+			return err
+		}
+		accept = func(event events.Event) bool {
+			return event.Subscription.UserID == userID
+		}
+	}
+
+	received, unsubscribe := s.events.Subscribe(accept)
+	defer unsubscribe()
+	// Send the headers now, so the client knows events are being watched
+	// before the first one is sent
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case event, ok := <-received:
+			if !ok {
+				if s.events.Closed() {
+					return status.Error(codes.Unavailable, "the server is shutting down")
+				}
+				return status.Error(codes.ResourceExhausted, "the stream fell too far behind the events")
+			}
+			err := stream.Send(&pb.WatchSubscriptionsResponse{
+				Type:         eventTypes[event.Type],
+				Subscription: toSubscription(&event.Subscription),
+				EventTime:    timestamp(event.At),
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
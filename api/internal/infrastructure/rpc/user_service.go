@@ -0,0 +1,43 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/frtasoniero/subsmanager/internal/usecases"
+	pb "github.com/frtasoniero/subsmanager/pkg/pb/subsmanager/v1"
+)
+
+type UserService struct {
+	pb.UnimplementedUserServiceServer
+	userUseCase *usecases.UserUseCase
+}
+
+func NewUserService(userUseCase *usecases.UserUseCase) *UserService {
+	return &UserService{
+		userUseCase: userUseCase,
+	}
+}
+
+func (s *UserService) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.GetUserResponse, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.userUseCase.GetUserByID(ctx, id)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &pb.GetUserResponse{User: toUser(user)}, nil
+}
+
+func (s *UserService) ListUsers(ctx context.Context, req *pb.ListUsersRequest) (*pb.ListUsersResponse, error) {
+	users, err := s.userUseCase.GetAllUsers(ctx)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	resp := &pb.ListUsersResponse{Users: make([]*pb.User, len(users))}
+	for i, user := range users {
+		resp.Users[i] = toUser(user)
+	}
+	return resp, nil
+}
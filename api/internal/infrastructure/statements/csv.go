@@ -0,0 +1,167 @@
+package statements
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/frtasoniero/subsmanager/internal/domain/entities"
+)
+
+// csvDateLayouts are tried in order when parsing CSV dates
+var csvDateLayouts = []string{
+	"2006-01-02",
+	"2006/01/02",
+	"01/02/2006",
+	"02.01.2006",
+	"2 Jan 2006",
+	"Jan 2, 2006",
+}
+
+// Column names recognised in CSV headers, compared case-insensitively
+var (
+	csvDateColumns        = []string{"date", "posted", "posting date", "transaction date", "booking date"}
+	csvDescriptionColumns = []string{"description", "payee", "name", "merchant", "memo", "details"}
+	csvAmountColumns      = []string{"amount", "value"}
+	csvDebitColumns       = []string{"debit", "withdrawal", "money out"}
+	csvCreditColumns      = []string{"credit", "deposit", "money in"}
+)
+
+// ParseCSV reads a generic bank statement CSV with a header row. It needs a date
+// and description column, and either a signed amount column or separate debit
+// and credit columns. Comma and semicolon delimiters are detected.
+func ParseCSV(r io.Reader) ([]entities.BankTransaction, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := csv.NewReader(strings.NewReader(string(data)))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	if firstLine, _, _ := strings.Cut(string(data), "\n"); strings.Count(firstLine, ";") > strings.Count(firstLine, ",") {
+		reader.Comma = ';'
+	}
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to read CSV header: %v", ErrInvalidStatement, err)
+	}
+
+	dateColumn := findColumn(header, csvDateColumns)
+	descriptionColumn := findColumn(header, csvDescriptionColumns)
+	amountColumn := findColumn(header, csvAmountColumns)
+	debitColumn := findColumn(header, csvDebitColumns)
+	creditColumn := findColumn(header, csvCreditColumns)
+
+	if dateColumn < 0 || descriptionColumn < 0 {
+		return nil, fmt.Errorf("%w: CSV needs a date and a description column", ErrInvalidStatement)
+	}
+	if amountColumn < 0 && debitColumn < 0 {
+		return nil, fmt.Errorf("%w: CSV needs an amount or a debit column", ErrInvalidStatement)
+	}
+
+	var transactions []entities.BankTransaction
+	line := 1
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		line++
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", ErrInvalidStatement, line, err)
+		}
+
+		field := func(column int) string {
+			if column < 0 || column >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[column])
+		}
+
+		date, err := parseCSVDate(field(dateColumn))
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", ErrInvalidStatement, line, err)
+		}
+
+		var amount float64
+		if amountColumn >= 0 {
+			amount, err = parseAmount(field(amountColumn))
+		} else {
+			var debit, credit float64
+			debit, err = parseAmount(field(debitColumn))
+			if err == nil {
+				credit, err = parseAmount(field(creditColumn))
+			}
+			if debit > 0 {
+				debit = -debit
+			}
+			amount = credit + debit
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", ErrInvalidStatement, line, err)
+		}
+
+		transactions = append(transactions, entities.BankTransaction{
+			Date:        date,
+			Description: field(descriptionColumn),
+			Amount:      amount,
+		})
+	}
+
+	return transactions, nil
+}
+
+func findColumn(header []string, names []string) int {
+	for i, column := range header {
+		column = strings.ToLower(strings.TrimSpace(column))
+		for _, name := range names {
+			if column == name {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func parseCSVDate(value string) (time.Time, error) {
+	for _, layout := range csvDateLayouts {
+		if date, err := time.Parse(layout, value); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognised date %q", value)
+}
+
+// parseAmount parses amounts such as "-15.99", "15,99", "1,234.50" or "$9.99".
+// Empty values are zero.
+func parseAmount(value string) (float64, error) {
+	value = strings.TrimSpace(strings.Trim(value, "$€£ "))
+	if value == "" {
+		return 0, nil
+	}
+
+	negative := strings.HasPrefix(value, "(") && strings.HasSuffix(value, ")")
+	value = strings.Trim(value, "()")
+
+	// A comma after the last dot, or without any dot, is a decimal separator
+	if lastComma := strings.LastIndex(value, ","); lastComma > strings.LastIndex(value, ".") && len(value)-lastComma-1 <= 2 {
+		value = strings.ReplaceAll(value, ".", "")
+		value = strings.Replace(value, ",", ".", 1)
+	} else {
+		value = strings.ReplaceAll(value, ",", "")
+	}
+
+	amount, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q", value)
+	}
+	if negative {
+		amount = -amount
+	}
+	return amount, nil
+}
@@ -0,0 +1,100 @@
+package statements
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/frtasoniero/subsmanager/internal/domain/entities"
+)
+
+// ParseOFX reads the STMTTRN records of an OFX or QFX statement. Both the SGML
+// flavour of OFX 1.x, where elements are not closed, and the XML flavour of
+// OFX 2.x are supported.
+func ParseOFX(r io.Reader) ([]entities.BankTransaction, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	body := string(data)
+	upper := strings.ToUpper(body)
+
+	if !strings.Contains(upper, "<OFX>") {
+		return nil, fmt.Errorf("%w: missing <OFX> element", ErrInvalidStatement)
+	}
+
+	var transactions []entities.BankTransaction
+	for offset := 0; ; {
+		start := strings.Index(upper[offset:], "<STMTTRN>")
+		if start < 0 {
+			break
+		}
+		start += offset + len("<STMTTRN>")
+
+		end := strings.Index(upper[start:], "</STMTTRN>")
+		if end < 0 {
+			// SGML statements may omit the closing tag, so the record ends at the next one
+			end = strings.Index(upper[start:], "<STMTTRN>")
+			if end < 0 {
+				end = len(upper) - start
+			}
+		}
+		record := body[start : start+end]
+		offset = start + end
+
+		transaction, err := parseOFXRecord(record)
+		if err != nil {
+			return nil, err
+		}
+		transactions = append(transactions, transaction)
+	}
+
+	return transactions, nil
+}
+
+func parseOFXRecord(record string) (entities.BankTransaction, error) {
+	var transaction entities.BankTransaction
+
+	posted := ofxValue(record, "DTPOSTED")
+	if len(posted) < 8 {
+		return transaction, fmt.Errorf("%w: missing DTPOSTED in transaction", ErrInvalidStatement)
+	}
+	date, err := time.Parse("20060102", posted[:8])
+	if err != nil {
+		return transaction, fmt.Errorf("%w: invalid DTPOSTED %q", ErrInvalidStatement, posted)
+	}
+
+	amount, err := strconv.ParseFloat(strings.Replace(ofxValue(record, "TRNAMT"), ",", ".", 1), 64)
+	if err != nil {
+		return transaction, fmt.Errorf("%w: invalid TRNAMT in transaction", ErrInvalidStatement)
+	}
+
+	description := ofxValue(record, "NAME")
+	if memo := ofxValue(record, "MEMO"); description == "" {
+		description = memo
+	}
+
+	transaction.Date = date
+	transaction.Amount = amount
+	transaction.Description = description
+	return transaction, nil
+}
+
+// ofxValue returns the text following an element start tag, up to the next tag or line end
+func ofxValue(record, element string) string {
+	i := strings.Index(strings.ToUpper(record), "<"+element+">")
+	if i < 0 {
+		return ""
+	}
+	value := record[i+len(element)+2:]
+	if end := strings.IndexAny(value, "<\r\n"); end >= 0 {
+		value = value[:end]
+	}
+	return unescapeOFX(strings.TrimSpace(value))
+}
+
+func unescapeOFX(value string) string {
+	return strings.NewReplacer("&amp;", "&", "&lt;", "<", "&gt;", ">").Replace(value)
+}
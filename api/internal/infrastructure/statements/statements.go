@@ -0,0 +1,34 @@
+// Package statements parses bank statement files (OFX/QFX and generic CSV)
+// into transactions. Parsing is done fully offline.
+package statements
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/frtasoniero/subsmanager/internal/domain/entities"
+)
+
+// Supported statement formats
+const (
+	FormatOFX = "ofx"
+	FormatQFX = "qfx"
+	FormatCSV = "csv"
+)
+
+// ErrInvalidStatement is returned when a statement file cannot be parsed
+var ErrInvalidStatement = errors.New("invalid statement file")
+
+// Parse reads the transactions of a statement in the given format
+func Parse(r io.Reader, format string) ([]entities.BankTransaction, error) {
+	switch strings.ToLower(format) {
+	case FormatOFX, FormatQFX:
+		return ParseOFX(r)
+	case FormatCSV:
+		return ParseCSV(r)
+	default:
+		return nil, fmt.Errorf("%w: unsupported format %q, expected ofx, qfx or csv", ErrInvalidStatement, format)
+	}
+}
@@ -0,0 +1,60 @@
+// Package graphql serves a GraphQL schema over users, products and
+// subscriptions. Products of subscription lists are loaded in batches, one
+// repository call per list instead of one per subscription.
+package graphql
+
+import (
+	_ "embed"
+	"net/http"
+
+	graphqlgo "github.com/graph-gophers/graphql-go"
+
+	"github.com/frtasoniero/subsmanager/internal/usecases"
+	"github.com/frtasoniero/subsmanager/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+//go:embed schema.graphql
+var schemaSource string
+
+// maxDepth bounds the nesting of queries
+const maxDepth = 10
+
+type Handler struct {
+	resolver *Resolver
+	schema   *graphqlgo.Schema
+}
+
+func NewHandler(subscriptionUseCase *usecases.SubscriptionUseCase, productUseCase *usecases.ProductUseCase, userUseCase *usecases.UserUseCase) *Handler {
+	resolver := &Resolver{
+		subscriptionUseCase: subscriptionUseCase,
+		productUseCase:      productUseCase,
+		userUseCase:         userUseCase,
+	}
+	return &Handler{
+		resolver: resolver,
+		schema:   graphqlgo.MustParseSchema(schemaSource, resolver, graphqlgo.MaxDepth(maxDepth)),
+	}
+}
+
+// request is the body of a GraphQL request
+type request struct {
+	Query         string         `json:"query" binding:"required"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+// Query executes a GraphQL query or mutation. The response follows the
+// GraphQL specification rather than the APIResponse envelope: errors of
+// fields are listed next to the data that could be resolved.
+func (h *Handler) Query(c *gin.Context) {
+	var req request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid GraphQL request", err)
+		return
+	}
+
+	ctx := h.resolver.withLoaders(c.Request.Context())
+	response := h.schema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+	c.JSON(http.StatusOK, response)
+}
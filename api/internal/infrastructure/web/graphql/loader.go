@@ -0,0 +1,106 @@
+package graphql
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Batching of loaders
+const (
+	// batchWait is how long a load waits for other loads to join its batch
+	batchWait = 2 * time.Millisecond
+	// maxBatch bounds the keys of one fetch
+	maxBatch = 500
+)
+
+// loader batches the loads of one request, so resolving a field of every item
+// of a list fetches the values of all items at once. Values are cached for
+// the rest of the request.
+type loader[K comparable, V any] struct {
+	ctx   context.Context
+	fetch func(ctx context.Context, keys []K) (map[K]V, error)
+
+	mu        sync.Mutex
+	results   map[K]*result[V]
+	pending   []K
+	scheduled bool
+}
+
+// result is the value of a key, available once ready is closed
+type result[V any] struct {
+	ready chan struct{}
+	value V
+	err   error
+}
+
+// newLoader creates a loader fetching with the context of a request. fetch
+// leaves out the keys that have no value.
+func newLoader[K comparable, V any](ctx context.Context, fetch func(ctx context.Context, keys []K) (map[K]V, error)) *loader[K, V] {
+	return &loader[K, V]{ctx: ctx, fetch: fetch, results: make(map[K]*result[V])}
+}
+
+// Prime queues keys that are about to be loaded, such as the keys of every
+// item of a list, so the first load fetches them all. Nothing is fetched
+// until a key is loaded.
+func (l *loader[K, V]) Prime(keys ...K) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, key := range keys {
+		l.queue(key)
+	}
+}
+
+// Load returns the value of key, or the zero value when it has none
+func (l *loader[K, V]) Load(ctx context.Context, key K) (V, error) {
+	l.mu.Lock()
+	r := l.queue(key)
+	if len(l.pending) > 0 && !l.scheduled {
+		l.scheduled = true
+		time.AfterFunc(batchWait, l.dispatch)
+	}
+	l.mu.Unlock()
+
+	select {
+	case <-r.ready:
+		return r.value, r.err
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	}
+}
+
+// queue returns the result of key, queuing the key when it is new. The
+// caller holds mu.
+func (l *loader[K, V]) queue(key K) *result[V] {
+	if r, ok := l.results[key]; ok {
+		return r
+	}
+	r := &result[V]{ready: make(chan struct{})}
+	l.results[key] = r
+	l.pending = append(l.pending, key)
+	return r
+}
+
+// dispatch fetches the pending keys
+func (l *loader[K, V]) dispatch() {
+	l.mu.Lock()
+	keys := l.pending
+	l.pending = nil
+	l.scheduled = false
+	results := make([]*result[V], len(keys))
+	for i, key := range keys {
+		results[i] = l.results[key]
+	}
+	l.mu.Unlock()
+
+	for start := 0; start < len(keys); start += maxBatch {
+		end := min(start+maxBatch, len(keys))
+		values, err := l.fetch(l.ctx, keys[start:end])
+		for i, key := range keys[start:end] {
+			r := results[start+i]
+			r.value, r.err = values[key], err
+			close(r.ready)
+		}
+	}
+}
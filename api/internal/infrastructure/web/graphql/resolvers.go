@@ -0,0 +1,490 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	graphqlgo "github.com/graph-gophers/graphql-go"
+
+	"github.com/frtasoniero/subsmanager/internal/domain/entities"
+	"github.com/frtasoniero/subsmanager/internal/domain/repositories"
+	"github.com/frtasoniero/subsmanager/internal/usecases"
+)
+
+// Resolver resolves the root fields of the schema with the same use cases as
+// the REST handlers
+type Resolver struct {
+	subscriptionUseCase *usecases.SubscriptionUseCase
+	productUseCase      *usecases.ProductUseCase
+	userUseCase         *usecases.UserUseCase
+}
+
+// queryResolver resolves the fields of Query
+type queryResolver struct{ *Resolver }
+
+// mutationResolver resolves the fields of Mutation
+type mutationResolver struct{ *Resolver }
+
+// Query returns the resolver of queries. The fields of Query and Mutation are
+// resolved by separate types, as a Subscription method of the root resolver
+// would be taken for the resolver of GraphQL subscriptions.
+func (r *Resolver) Query() *queryResolver {
+	return &queryResolver{r}
+}
+
+// Mutation returns the resolver of mutations
+func (r *Resolver) Mutation() *mutationResolver {
+	return &mutationResolver{r}
+}
+
+// loaders holds the batch loaders of a request
+type loaders struct {
+	products *loader[entities.ID, *entities.Product]
+	// subscriptions loads every subscription of a user, whatever its status
+	subscriptions *loader[entities.ID, []*entities.SubscriptionWithProduct]
+}
+
+type loadersKey struct{}
+
+// withLoaders returns a context carrying new loaders for one request
+func (r *Resolver) withLoaders(ctx context.Context) context.Context {
+	products := newLoader(ctx, func(ctx context.Context, ids []entities.ID) (map[entities.ID]*entities.Product, error) {
+		found, err := r.productUseCase.GetProductsByIDs(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+		byID := make(map[entities.ID]*entities.Product, len(found))
+		for _, product := range found {
+			byID[product.ID] = product
+		}
+		return byID, nil
+	})
+	subscriptions := newLoader(ctx, func(ctx context.Context, userIDs []entities.ID) (map[entities.ID][]*entities.SubscriptionWithProduct, error) {
+		found, err := r.subscriptionUseCase.FindSubscriptions(ctx, repositories.SubscriptionFilter{UserIDs: userIDs})
+		if err != nil {
+			return nil, err
+		}
+		byUser := make(map[entities.ID][]*entities.SubscriptionWithProduct, len(userIDs))
+		for _, subscription := range found {
+			byUser[subscription.UserID] = append(byUser[subscription.UserID], subscription)
+		}
+		return byUser, nil
+	})
+	return context.WithValue(ctx, loadersKey{}, &loaders{products: products, subscriptions: subscriptions})
+}
+
+// loadersFrom returns the loaders of the request of ctx
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
+
+// parseID validates an ID argument
+func parseID(id graphqlgo.ID) (entities.ID, error) {
+	return entities.ParseID(string(id))
+}
+
+// ignoreNotFound turns a missing entity into a null field
+func ignoreNotFound(err error) error {
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil
+	}
+	return err
+}
+
+// enum returns the schema value of a domain status or type
+func enum(value string) string {
+	return strings.ToUpper(value)
+}
+
+// fromEnum returns the domain value of a schema enum value
+func fromEnum(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return strings.ToLower(*value)
+}
+
+func (r *queryResolver) User(ctx context.Context, args struct{ ID graphqlgo.ID }) (*userResolver, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	user, err := r.userUseCase.GetUserByID(ctx, id)
+	if err != nil {
+		return nil, ignoreNotFound(err)
+	}
+	return &userResolver{user: user}, nil
+}
+
+func (r *queryResolver) Users(ctx context.Context) ([]*userResolver, error) {
+	users, err := r.userUseCase.GetAllUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]*userResolver, len(users))
+	userIDs := make([]entities.ID, len(users))
+	for i, user := range users {
+		resolvers[i] = &userResolver{user: user}
+		userIDs[i] = user.ID
+	}
+	loadersFrom(ctx).subscriptions.Prime(userIDs...)
+	return resolvers, nil
+}
+
+func (r *queryResolver) Product(ctx context.Context, args struct{ ID graphqlgo.ID }) (*productResolver, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	product, err := r.productUseCase.GetProductByID(ctx, id)
+	if err != nil {
+		return nil, ignoreNotFound(err)
+	}
+	return &productResolver{product: product}, nil
+}
+
+func (r *queryResolver) Products(ctx context.Context, args struct {
+	Category   *string
+	ActiveOnly bool
+}) ([]*productResolver, error) {
+	var products []*entities.Product
+	var err error
+	switch {
+	case args.Category != nil:
+		products, err = r.productUseCase.GetProductsByCategory(ctx, *args.Category)
+	case args.ActiveOnly:
+		products, err = r.productUseCase.GetActiveProducts(ctx)
+	default:
+		products, err = r.productUseCase.GetAllProducts(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]*productResolver, 0, len(products))
+	for _, product := range products {
+		if args.ActiveOnly && !product.IsActive() {
+			continue
+		}
+		resolvers = append(resolvers, &productResolver{product: product})
+	}
+	return resolvers, nil
+}
+
+func (r *queryResolver) Subscription(ctx context.Context, args struct{ ID graphqlgo.ID }) (*subscriptionResolver, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	subscription, err := r.subscriptionUseCase.GetSubscriptionByID(ctx, id)
+	if err != nil {
+		return nil, ignoreNotFound(err)
+	}
+	return &subscriptionResolver{subscription: subscription}, nil
+}
+
+func (r *queryResolver) Subscriptions(ctx context.Context, args struct {
+	First     *int32
+	After     *string
+	Sort      *string
+	Order     *string
+	Status    *string
+	ProductID *graphqlgo.ID
+	Category  *string
+}) (*connectionResolver, error) {
+	query := usecases.SubscriptionListQuery{
+		Sort:  fromEnum(args.Sort),
+		Order: fromEnum(args.Order),
+		Filter: repositories.SubscriptionFilter{
+			Status: fromEnum(args.Status),
+		},
+	}
+	if args.First != nil {
+		query.Limit = int(*args.First)
+	}
+	if args.After != nil {
+		query.Cursor = *args.After
+	}
+	if args.ProductID != nil {
+		productID, err := parseID(*args.ProductID)
+		if err != nil {
+			return nil, err
+		}
+		query.Filter.ProductID = &productID
+	}
+	if args.Category != nil {
+		query.Filter.Category = *args.Category
+	}
+
+	page, err := r.subscriptionUseCase.ListSubscriptions(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return &connectionResolver{
+		nodes:      joinedSubscriptions(ctx, page.Subscriptions),
+		pagination: page.Pagination,
+	}, nil
+}
+
+// CreateSubscriptionInput is the input of createSubscription
+type CreateSubscriptionInput struct {
+	UserID       graphqlgo.ID
+	ProductID    graphqlgo.ID
+	StartDate    *graphqlgo.Time
+	NextBilling  *graphqlgo.Time
+	PriceAtStart *float64
+}
+
+// CreateSubscription subscribes a user to a product. Like "cli subscriptions
+// create", the price defaults to the product price and the next billing date
+// to one billing cycle after the start date.
+func (r *mutationResolver) CreateSubscription(ctx context.Context, args struct{ Input CreateSubscriptionInput }) (*subscriptionResolver, error) {
+	input := args.Input
+	userID, err := parseID(input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	productID, err := parseID(input.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := r.userUseCase.GetUserByID(ctx, userID); err != nil {
+		return nil, fmt.Errorf("user %s: %w", userID, err)
+	}
+	product, err := r.productUseCase.GetProductByID(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("product %s: %w", productID, err)
+	}
+
+	subscription := &entities.Subscription{
+		UserID:       userID,
+		ProductID:    productID,
+		PriceAtStart: product.Price,
+		StartDate:    time.Now(),
+	}
+	if input.PriceAtStart != nil {
+		subscription.PriceAtStart = *input.PriceAtStart
+	}
+	if input.StartDate != nil {
+		subscription.StartDate = input.StartDate.Time
+	}
+	subscription.NextBilling = entities.NextBillingDate(subscription.StartDate, product.BillingType)
+	if input.NextBilling != nil {
+		subscription.NextBilling = input.NextBilling.Time
+	}
+
+	if err := r.subscriptionUseCase.CreateSubscription(ctx, subscription); err != nil {
+		return nil, err
+	}
+	return &subscriptionResolver{subscription: subscription}, nil
+}
+
+func (r *mutationResolver) CancelSubscription(ctx context.Context, args struct{ ID graphqlgo.ID }) (*subscriptionResolver, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	subscription, err := r.subscriptionUseCase.CancelSubscription(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &subscriptionResolver{subscription: subscription}, nil
+}
+
+func (r *mutationResolver) PauseSubscription(ctx context.Context, args struct{ ID graphqlgo.ID }) (*subscriptionResolver, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	subscription, err := r.subscriptionUseCase.PauseSubscription(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &subscriptionResolver{subscription: subscription}, nil
+}
+
+// joinedSubscriptions resolves a list of subscriptions, priming the product
+// loader with all their products so they are fetched in one batch
+func joinedSubscriptions(ctx context.Context, subscriptions []*entities.SubscriptionWithProduct) []*subscriptionResolver {
+	resolvers := make([]*subscriptionResolver, len(subscriptions))
+	productIDs := make([]entities.ID, len(subscriptions))
+	for i, s := range subscriptions {
+		resolvers[i] = &subscriptionResolver{subscription: &entities.Subscription{
+			ID:           s.ID,
+			UserID:       s.UserID,
+			ProductID:    s.ProductID,
+			Status:       s.Status,
+			StartDate:    s.StartDate,
+			EndDate:      s.EndDate,
+			NextBilling:  s.NextBilling,
+			PriceAtStart: s.PriceAtStart,
+			CreatedAt:    s.CreatedAt,
+		}}
+		productIDs[i] = s.ProductID
+	}
+	loadersFrom(ctx).products.Prime(productIDs...)
+	return resolvers
+}
+
+type userResolver struct {
+	user *entities.User
+}
+
+func (r *userResolver) ID() graphqlgo.ID {
+	return graphqlgo.ID(r.user.ID)
+}
+
+func (r *userResolver) Username() string {
+	return r.user.Username
+}
+
+func (r *userResolver) Status() string {
+	return enum(r.user.Status)
+}
+
+func (r *userResolver) CreatedAt() graphqlgo.Time {
+	return graphqlgo.Time{Time: r.user.CreatedAt}
+}
+
+func (r *userResolver) UpdatedAt() graphqlgo.Time {
+	return graphqlgo.Time{Time: r.user.UpdatedAt}
+}
+
+// Subscriptions filters the subscriptions of the user by status once they
+// are loaded, so the subscriptions of every listed user are fetched in one
+// batch whatever the status argument
+func (r *userResolver) Subscriptions(ctx context.Context, args struct{ Status *string }) ([]*subscriptionResolver, error) {
+	all, err := loadersFrom(ctx).subscriptions.Load(ctx, r.user.ID)
+	if err != nil {
+		return nil, err
+	}
+	status := fromEnum(args.Status)
+	subscriptions := make([]*entities.SubscriptionWithProduct, 0, len(all))
+	for _, subscription := range all {
+		if status == "" || subscription.Status == status {
+			subscriptions = append(subscriptions, subscription)
+		}
+	}
+	return joinedSubscriptions(ctx, subscriptions), nil
+}
+
+type productResolver struct {
+	product *entities.Product
+}
+
+func (r *productResolver) ID() graphqlgo.ID {
+	return graphqlgo.ID(r.product.ID)
+}
+
+func (r *productResolver) Name() string {
+	return r.product.Name
+}
+
+func (r *productResolver) Description() string {
+	return r.product.Description
+}
+
+func (r *productResolver) Price() float64 {
+	return r.product.Price
+}
+
+func (r *productResolver) BillingType() string {
+	return enum(r.product.BillingType)
+}
+
+func (r *productResolver) Category() string {
+	return r.product.Category
+}
+
+func (r *productResolver) Status() string {
+	return enum(r.product.Status)
+}
+
+func (r *productResolver) CreatedAt() graphqlgo.Time {
+	return graphqlgo.Time{Time: r.product.CreatedAt}
+}
+
+func (r *productResolver) UpdatedAt() graphqlgo.Time {
+	return graphqlgo.Time{Time: r.product.UpdatedAt}
+}
+
+type subscriptionResolver struct {
+	subscription *entities.Subscription
+}
+
+func (r *subscriptionResolver) ID() graphqlgo.ID {
+	return graphqlgo.ID(r.subscription.ID)
+}
+
+func (r *subscriptionResolver) UserID() graphqlgo.ID {
+	return graphqlgo.ID(r.subscription.UserID)
+}
+
+func (r *subscriptionResolver) ProductID() graphqlgo.ID {
+	return graphqlgo.ID(r.subscription.ProductID)
+}
+
+func (r *subscriptionResolver) Status() string {
+	return enum(r.subscription.Status)
+}
+
+func (r *subscriptionResolver) StartDate() graphqlgo.Time {
+	return graphqlgo.Time{Time: r.subscription.StartDate}
+}
+
+func (r *subscriptionResolver) EndDate() *graphqlgo.Time {
+	if r.subscription.EndDate == nil {
+		return nil
+	}
+	return &graphqlgo.Time{Time: *r.subscription.EndDate}
+}
+
+func (r *subscriptionResolver) NextBilling() graphqlgo.Time {
+	return graphqlgo.Time{Time: r.subscription.NextBilling}
+}
+
+func (r *subscriptionResolver) PriceAtStart() float64 {
+	return r.subscription.PriceAtStart
+}
+
+func (r *subscriptionResolver) CreatedAt() graphqlgo.Time {
+	return graphqlgo.Time{Time: r.subscription.CreatedAt}
+}
+
+func (r *subscriptionResolver) Product(ctx context.Context) (*productResolver, error) {
+	product, err := loadersFrom(ctx).products.Load(ctx, r.subscription.ProductID)
+	if err != nil || product == nil {
+		return nil, err
+	}
+	return &productResolver{product: product}, nil
+}
+
+type connectionResolver struct {
+	nodes      []*subscriptionResolver
+	pagination usecases.Pagination
+}
+
+func (r *connectionResolver) Nodes() []*subscriptionResolver {
+	return r.nodes
+}
+
+func (r *connectionResolver) PageInfo() *pageInfoResolver {
+	return &pageInfoResolver{pagination: r.pagination}
+}
+
+type pageInfoResolver struct {
+	pagination usecases.Pagination
+}
+
+func (r *pageInfoResolver) HasNextPage() bool {
+	return r.pagination.HasMore
+}
+
+func (r *pageInfoResolver) EndCursor() *string {
+	if r.pagination.NextCursor == "" {
+		return nil
+	}
+	return &r.pagination.NextCursor
+}
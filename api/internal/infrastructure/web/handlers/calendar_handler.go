@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/frtasoniero/subsmanager/internal/domain/entities"
+	"github.com/frtasoniero/subsmanager/internal/usecases"
+	"github.com/frtasoniero/subsmanager/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type CalendarHandler struct {
+	calendarUseCase *usecases.CalendarUseCase
+}
+
+func NewCalendarHandler(calendarUseCase *usecases.CalendarUseCase) *CalendarHandler {
+	return &CalendarHandler{
+		calendarUseCase: calendarUseCase,
+	}
+}
+
+// GetCalendar returns the iCalendar feed of a user's upcoming billing dates.
+// Query parameters: token (required) and alarm_days (defaults to the configured value).
+// It answers 503 while no secret is configured to sign feed tokens.
+func (h *CalendarHandler) GetCalendar(c *gin.Context) {
+	userID, err := entities.ParseID(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid user ID", err)
+		return
+	}
+
+	if !h.calendarUseCase.Enabled() {
+		utils.ErrorResponse(c, http.StatusServiceUnavailable, "Calendar feeds are disabled", usecases.ErrCalendarDisabled)
+		return
+	}
+	if !h.calendarUseCase.VerifyToken(userID, c.Query("token")) {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Invalid calendar token", errors.New("missing or invalid token"))
+		return
+	}
+
+	alarmDays := -1
+	if value := c.Query("alarm_days"); value != "" {
+		alarmDays, err = strconv.Atoi(value)
+		if err != nil || alarmDays < 0 {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid alarm_days", err)
+			return
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := h.calendarUseCase.WriteCalendar(c.Request.Context(), &buf, userID, alarmDays); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to build calendar", err)
+		return
+	}
+
+	c.Header("Content-Disposition", `inline; filename="calendar.ics"`)
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", buf.Bytes())
+}
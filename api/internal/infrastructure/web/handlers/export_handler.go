@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/frtasoniero/subsmanager/internal/usecases"
+	"github.com/frtasoniero/subsmanager/pkg/export"
+	"github.com/frtasoniero/subsmanager/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type ExportHandler struct {
+	exportUseCase *usecases.ExportUseCase
+	// writeTimeout replaces the write timeout of the server for exports,
+	// zero removing it
+	writeTimeout time.Duration
+}
+
+func NewExportHandler(exportUseCase *usecases.ExportUseCase) *ExportHandler {
+	return &ExportHandler{
+		exportUseCase: exportUseCase,
+	}
+}
+
+// WithWriteTimeout bounds writing an export, instead of the write timeout of
+// the server, which large exports outlast. Zero removes the deadline.
+func (h *ExportHandler) WithWriteTimeout(timeout time.Duration) *ExportHandler {
+	h.writeTimeout = timeout
+	return h
+}
+
+// ExportSubscriptions streams all subscriptions with product details.
+// Query parameters: format (csv|tsv|ndjson|ods, default csv).
+func (h *ExportHandler) ExportSubscriptions(c *gin.Context) {
+	h.stream(c, "subscriptions", h.exportUseCase.ExportSubscriptions)
+}
+
+// ExportSpending streams the spending summary of every user per category.
+// Query parameters: format (csv|tsv|ndjson|ods, default csv).
+func (h *ExportHandler) ExportSpending(c *gin.Context) {
+	h.stream(c, "spending", h.exportUseCase.ExportSpendingSummaries)
+}
+
+// stream validates the format and writes the export as a file download.
+// Once streaming has started the status can no longer change, so later
+// failures abort the connection and are logged.
+func (h *ExportHandler) stream(c *gin.Context, name string, write func(context.Context, io.Writer, string) error) {
+	format := strings.ToLower(c.DefaultQuery("format", export.FormatCSV))
+	if !export.IsSupported(format) {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Unsupported export format", fmt.Errorf("format %q is not one of csv, tsv, ndjson, ods", format))
+		return
+	}
+
+	var deadline time.Time
+	if h.writeTimeout > 0 {
+		deadline = time.Now().Add(h.writeTimeout)
+	}
+	if err := http.NewResponseController(c.Writer).SetWriteDeadline(deadline); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		log.Printf("⚠️  Failed to extend the write deadline of the %s export: %v", name, err)
+	}
+
+	filename := fmt.Sprintf("%s-%s.%s", name, time.Now().UTC().Format("20060102"), format)
+	c.Header("Content-Type", export.ContentType(format))
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Status(http.StatusOK)
+
+	if err := write(c.Request.Context(), c.Writer, format); err != nil {
+		log.Printf("❌ Failed to export %s: %v", name, err)
+		c.Abort()
+	}
+}
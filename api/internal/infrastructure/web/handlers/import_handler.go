@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/frtasoniero/subsmanager/internal/domain/entities"
+	"github.com/frtasoniero/subsmanager/internal/domain/repositories"
+	"github.com/frtasoniero/subsmanager/internal/usecases"
+	"github.com/frtasoniero/subsmanager/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// maxImportSize bounds the size of an uploaded import file
+const maxImportSize = 5 << 20
+
+// maxMultipartOverhead is the room left for the multipart headers and
+// boundaries around an uploaded file
+const maxMultipartOverhead = 64 << 10
+
+type ImportHandler struct {
+	importUseCase *usecases.ImportUseCase
+}
+
+func NewImportHandler(importUseCase *usecases.ImportUseCase) *ImportHandler {
+	return &ImportHandler{
+		importUseCase: importUseCase,
+	}
+}
+
+// ImportSubscriptions imports a CSV or JSON file of subscriptions for a user.
+// The file is sent either as the "file" field of a multipart form or as the raw
+// request body. The format is taken from the "format" query parameter, the file
+// extension or the content type. Use dry_run=true to only validate the file.
+// Files larger than 5 MiB are rejected with 413.
+func (h *ImportHandler) ImportSubscriptions(c *gin.Context) {
+	userID, err := entities.ParseID(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid user ID", err)
+		return
+	}
+
+	dryRun, err := strconv.ParseBool(c.DefaultQuery("dry_run", "false"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid dry_run", err)
+		return
+	}
+
+	body, filename, err := readUpload(c, maxImportSize)
+	if err != nil {
+		uploadError(c, "Failed to read import file", err)
+		return
+	}
+
+	var rows []usecases.ImportRow
+	switch format := importFormat(c, filename); format {
+	case "csv":
+		rows, err = usecases.ParseImportCSV(bytes.NewReader(body))
+	case "json":
+		rows, err = usecases.ParseImportJSON(bytes.NewReader(body))
+	default:
+		err = fmt.Errorf("unsupported import format %q, expected csv or json", format)
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid import file", err)
+		return
+	}
+
+	result, err := h.importUseCase.ImportSubscriptions(c.Request.Context(), userID, rows, dryRun)
+	if err != nil {
+		if errors.Is(err, usecases.ErrImportValidation) {
+			utils.ErrorResponseWithData(c, http.StatusUnprocessableEntity, "Import file contains invalid rows", result, err)
+			return
+		}
+		if errors.Is(err, repositories.ErrNotFound) {
+			utils.ErrorResponse(c, http.StatusNotFound, "User not found", err)
+			return
+		}
+		utils.ErrorResponseWithData(c, http.StatusInternalServerError, "Failed to import subscriptions", result, err)
+		return
+	}
+
+	if dryRun {
+		utils.SuccessResponse(c, http.StatusOK, "Import file is valid", result)
+		return
+	}
+	utils.SuccessResponse(c, http.StatusCreated, "Subscriptions imported successfully", result)
+}
+
+// readUpload reads the uploaded file, or the request body when no multipart
+// file is sent, together with the uploaded file name if any. Uploads larger
+// than limit fail with an *http.MaxBytesError.
+func readUpload(c *gin.Context, limit int64) ([]byte, string, error) {
+	if !strings.HasPrefix(c.ContentType(), "multipart/") {
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limit)
+		body, err := io.ReadAll(c.Request.Body)
+		return body, "", err
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limit+maxMultipartOverhead)
+	header, err := c.FormFile("file")
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) || err == nil && header.Size > limit {
+		return nil, "", &http.MaxBytesError{Limit: limit}
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	file, err := header.Open()
+	if err != nil {
+		return nil, "", err
+	}
+	defer file.Close()
+	body, err := io.ReadAll(file)
+	return body, header.Filename, err
+}
+
+// uploadError responds 413 when an upload is over its size limit, and 400
+// for any other failure to read it
+func uploadError(c *gin.Context, message string, err error) {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		utils.ErrorResponse(c, http.StatusRequestEntityTooLarge, message,
+			fmt.Errorf("the file is larger than %d bytes", tooLarge.Limit))
+		return
+	}
+	utils.ErrorResponse(c, http.StatusBadRequest, message, err)
+}
+
+// importFormat picks the import format from the query, the file name or the content type
+func importFormat(c *gin.Context, filename string) string {
+	if format := c.Query("format"); format != "" {
+		return strings.ToLower(format)
+	}
+	if ext := strings.TrimPrefix(strings.ToLower(filepath.Ext(filename)), "."); ext != "" {
+		return ext
+	}
+	switch c.ContentType() {
+	case "text/csv", "application/csv":
+		return "csv"
+	default:
+		return "json"
+	}
+}
@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/frtasoniero/subsmanager/internal/domain/entities"
+	"github.com/frtasoniero/subsmanager/internal/usecases"
+	"github.com/frtasoniero/subsmanager/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// dateLayout is the date format accepted in report query parameters
+const dateLayout = "2006-01-02"
+
+type ReportHandler struct {
+	reportUseCase *usecases.ReportUseCase
+}
+
+func NewReportHandler(reportUseCase *usecases.ReportUseCase) *ReportHandler {
+	return &ReportHandler{
+		reportUseCase: reportUseCase,
+	}
+}
+
+// GetMetrics returns MRR, ARR, churn and retention metrics.
+// Query parameters: from, to (YYYY-MM-DD, both inclusive) and interval (day|week|month).
+// Defaults to the last 12 months bucketed by month.
+func (h *ReportHandler) GetMetrics(c *gin.Context) {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+
+	to, err := parseDateParam(c, "to", today)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid 'to' date, expected YYYY-MM-DD", err)
+		return
+	}
+	from, err := parseDateParam(c, "from", to.AddDate(-1, 0, 1))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid 'from' date, expected YYYY-MM-DD", err)
+		return
+	}
+
+	query := usecases.MetricsQuery{
+		From:     from,
+		To:       to.AddDate(0, 0, 1),
+		Interval: c.DefaultQuery("interval", usecases.IntervalMonth),
+	}
+
+	report, err := h.reportUseCase.GetMetrics(c.Request.Context(), query)
+	if err != nil {
+		if errors.Is(err, usecases.ErrInvalidReportQuery) {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid metrics query", err)
+			return
+		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to compute metrics", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Metrics computed successfully", report)
+}
+
+// GetCohorts returns the monthly signup cohort retention report.
+// Query parameters: product_id, category and months (default 12).
+func (h *ReportHandler) GetCohorts(c *gin.Context) {
+	query := usecases.CohortQuery{
+		Category: c.Query("category"),
+	}
+
+	if value := c.Query("product_id"); value != "" {
+		productID, err := entities.ParseID(value)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid product ID", err)
+			return
+		}
+		query.ProductID = &productID
+	}
+
+	months, err := strconv.Atoi(c.DefaultQuery("months", "12"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid months", err)
+		return
+	}
+	query.Months = months
+
+	report, err := h.reportUseCase.GetCohorts(c.Request.Context(), query)
+	if err != nil {
+		if errors.Is(err, usecases.ErrInvalidReportQuery) {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid cohort query", err)
+			return
+		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to compute cohorts", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Cohorts computed successfully", report)
+}
+
+// parseDateParam parses a YYYY-MM-DD query parameter, returning fallback when absent
+func parseDateParam(c *gin.Context, name string, fallback time.Time) (time.Time, error) {
+	value := c.Query(name)
+	if value == "" {
+		return fallback, nil
+	}
+	return time.Parse(dateLayout, value)
+}
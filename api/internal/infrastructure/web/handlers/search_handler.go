@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/frtasoniero/subsmanager/internal/domain/entities"
+	"github.com/frtasoniero/subsmanager/internal/usecases"
+	"github.com/frtasoniero/subsmanager/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type SearchHandler struct {
+	searchUseCase *usecases.SearchUseCase
+}
+
+func NewSearchHandler(searchUseCase *usecases.SearchUseCase) *SearchHandler {
+	return &SearchHandler{
+		searchUseCase: searchUseCase,
+	}
+}
+
+// Search returns the products, and the subscriptions of a user, matching a query.
+// Query parameters: q (required), user_id (scopes the search to the subscriptions
+// of that user) and limit (1 to 100, defaults to 20).
+func (h *SearchHandler) Search(c *gin.Context) {
+	query := usecases.SearchQuery{Text: c.Query("q")}
+	if value := c.Query("user_id"); value != "" {
+		userID, err := entities.ParseID(value)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid user ID", err)
+			return
+		}
+		query.UserID = &userID
+	}
+	if value := c.Query("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid limit", err)
+			return
+		}
+		query.Limit = limit
+	}
+
+	results, err := h.searchUseCase.Search(c.Request.Context(), query)
+	if err != nil {
+		if errors.Is(err, usecases.ErrInvalidSearchQuery) {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid search query", err)
+			return
+		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to search", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Search completed successfully", results)
+}
@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/frtasoniero/subsmanager/internal/domain/entities"
+	"github.com/frtasoniero/subsmanager/internal/domain/repositories"
+	"github.com/frtasoniero/subsmanager/internal/infrastructure/statements"
+	"github.com/frtasoniero/subsmanager/internal/usecases"
+	"github.com/frtasoniero/subsmanager/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// maxStatementSize bounds the size of an uploaded bank statement
+const maxStatementSize = 10 << 20
+
+type StatementHandler struct {
+	statementUseCase *usecases.StatementUseCase
+}
+
+func NewStatementHandler(statementUseCase *usecases.StatementUseCase) *StatementHandler {
+	return &StatementHandler{
+		statementUseCase: statementUseCase,
+	}
+}
+
+// AnalyzeStatement detects recurring charges in an uploaded bank statement and
+// returns candidate subscriptions. Nothing is stored. The file is sent as the
+// "file" field of a multipart form or as the raw body; the format (ofx, qfx or
+// csv) comes from the "format" query parameter or the file extension. Files
+// larger than 10 MiB are rejected with 413.
+func (h *StatementHandler) AnalyzeStatement(c *gin.Context) {
+	userID, err := entities.ParseID(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid user ID", err)
+		return
+	}
+
+	body, filename, err := readUpload(c, maxStatementSize)
+	if err != nil {
+		uploadError(c, "Failed to read statement file", err)
+		return
+	}
+
+	format := strings.ToLower(c.Query("format"))
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(filename)), ".")
+	}
+	if format == "" {
+		format = statements.FormatCSV
+	}
+
+	transactions, err := statements.Parse(bytes.NewReader(body), format)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid statement file", err)
+		return
+	}
+
+	candidates, err := h.statementUseCase.DetectRecurring(c.Request.Context(), userID, transactions)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to analyze statement", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Statement analyzed successfully", candidates)
+}
+
+// ConfirmCandidates creates subscriptions from the candidates the user confirmed
+func (h *StatementHandler) ConfirmCandidates(c *gin.Context) {
+	userID, err := entities.ParseID(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid user ID", err)
+		return
+	}
+
+	var confirmed []usecases.ConfirmedCandidate
+	if err := c.ShouldBindJSON(&confirmed); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	result, err := h.statementUseCase.ConfirmCandidates(c.Request.Context(), userID, confirmed)
+	if err != nil {
+		if errors.Is(err, usecases.ErrNoCandidatesConfirmed) {
+			utils.ErrorResponse(c, http.StatusBadRequest, "No candidates confirmed", err)
+			return
+		}
+		if errors.Is(err, usecases.ErrImportValidation) {
+			utils.ErrorResponseWithData(c, http.StatusUnprocessableEntity, "Confirmed candidates are invalid", result, err)
+			return
+		}
+		if errors.Is(err, repositories.ErrNotFound) {
+			utils.ErrorResponse(c, http.StatusNotFound, "User not found", err)
+			return
+		}
+		utils.ErrorResponseWithData(c, http.StatusInternalServerError, "Failed to create subscriptions", result, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Subscriptions created successfully", result)
+}
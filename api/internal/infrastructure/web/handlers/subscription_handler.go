@@ -1,8 +1,14 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
+	"github.com/frtasoniero/subsmanager/internal/domain/entities"
+	"github.com/frtasoniero/subsmanager/internal/domain/repositories"
 	"github.com/frtasoniero/subsmanager/internal/usecases"
 	"github.com/frtasoniero/subsmanager/pkg/utils"
 	"github.com/gin-gonic/gin"
@@ -18,12 +24,136 @@ func NewSubscriptionHandler(subscriptionUseCase *usecases.SubscriptionUseCase) *
 	}
 }
 
+// GetAllSubscriptions returns a page of subscriptions.
+// Query parameters: limit (default 50, max 200), cursor (next_cursor of the
+// previous page), sort (next_billing|price|created_at), order (asc|desc),
+// status, product_id, category, min_price, max_price, next_billing_from and
+// next_billing_to (YYYY-MM-DD, both inclusive).
 func (h *SubscriptionHandler) GetAllSubscriptions(c *gin.Context) {
-	subscriptions, err := h.subscriptionUseCase.GetAllSubscriptions(c.Request.Context())
+	h.listSubscriptions(c, repositories.SubscriptionFilter{})
+}
+
+// GetUserSubscriptions returns a page of the subscriptions of a user. It
+// accepts the query parameters of GetAllSubscriptions.
+func (h *SubscriptionHandler) GetUserSubscriptions(c *gin.Context) {
+	userID, err := entities.ParseID(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid user ID", err)
+		return
+	}
+	h.listSubscriptions(c, repositories.SubscriptionFilter{UserID: &userID})
+}
+
+func (h *SubscriptionHandler) listSubscriptions(c *gin.Context, filter repositories.SubscriptionFilter) {
+	query, err := parseSubscriptionListQuery(c, filter)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid list query", err)
+		return
+	}
+
+	page, err := h.subscriptionUseCase.ListSubscriptions(c.Request.Context(), query)
 	if err != nil {
+		if errors.Is(err, usecases.ErrInvalidListQuery) {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid list query", err)
+			return
+		}
 		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get subscriptions", err)
 		return
 	}
 
-	utils.SuccessResponse(c, http.StatusOK, "Subscriptions retrieved successfully", subscriptions)
+	utils.PaginatedResponse(c, http.StatusOK, "Subscriptions retrieved successfully", page.Subscriptions, page.Pagination)
+}
+
+// parseSubscriptionListQuery reads the pagination, sort and filter query
+// parameters of a subscription listing
+func parseSubscriptionListQuery(c *gin.Context, filter repositories.SubscriptionFilter) (usecases.SubscriptionListQuery, error) {
+	query := usecases.SubscriptionListQuery{
+		Sort:   c.Query("sort"),
+		Order:  c.Query("order"),
+		Cursor: c.Query("cursor"),
+	}
+	if value := c.Query("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil {
+			return query, fmt.Errorf("invalid limit %q", value)
+		}
+		query.Limit = limit
+	}
+
+	filter.Status = c.Query("status")
+	filter.Category = c.Query("category")
+	if value := c.Query("product_id"); value != "" {
+		productID, err := entities.ParseID(value)
+		if err != nil {
+			return query, err
+		}
+		filter.ProductID = &productID
+	}
+	var err error
+	if filter.MinPrice, err = parsePriceParam(c, "min_price"); err != nil {
+		return query, err
+	}
+	if filter.MaxPrice, err = parsePriceParam(c, "max_price"); err != nil {
+		return query, err
+	}
+	if value := c.Query("next_billing_from"); value != "" {
+		from, err := time.Parse(dateLayout, value)
+		if err != nil {
+			return query, fmt.Errorf("invalid next_billing_from %q, expected YYYY-MM-DD", value)
+		}
+		filter.NextBilling.From = &from
+	}
+	if value := c.Query("next_billing_to"); value != "" {
+		to, err := time.Parse(dateLayout, value)
+		if err != nil {
+			return query, fmt.Errorf("invalid next_billing_to %q, expected YYYY-MM-DD", value)
+		}
+		// The date is inclusive, the filter bound exclusive
+		to = to.AddDate(0, 0, 1)
+		filter.NextBilling.To = &to
+	}
+
+	query.Filter = filter
+	return query, nil
+}
+
+// GetSpendingForecast returns the projected charges of a user.
+// Query parameters: months (forecast horizon, default 12).
+func (h *SubscriptionHandler) GetSpendingForecast(c *gin.Context) {
+	userID, err := entities.ParseID(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid user ID", err)
+		return
+	}
+
+	months, err := strconv.Atoi(c.DefaultQuery("months", "12"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid months", err)
+		return
+	}
+
+	forecast, err := h.subscriptionUseCase.ForecastSpending(c.Request.Context(), userID, months)
+	if err != nil {
+		if errors.Is(err, usecases.ErrInvalidForecastHorizon) {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid forecast horizon", err)
+			return
+		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to forecast spending", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Spending forecast computed successfully", forecast)
+}
+
+// parsePriceParam parses an optional price query parameter
+func parsePriceParam(c *gin.Context, name string) (*float64, error) {
+	value := c.Query(name)
+	if value == "" {
+		return nil, nil
+	}
+	price, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s %q", name, value)
+	}
+	return &price, nil
 }
@@ -0,0 +1,120 @@
+// Package openapi serves the OpenAPI document of the REST API and a page that
+// renders it, and checks the document against the routes of the router.
+//
+// The document is maintained by hand in openapi.json: document a route there
+// when adding it to the router, and run "cli openapi check" to find the
+// routes that are missing.
+package openapi
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+//go:embed openapi.json
+var spec []byte
+
+//go:embed docs.html
+var docs []byte
+
+// Operation is an HTTP method on a path, with path parameters in the OpenAPI
+// {name} form
+type Operation struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+}
+
+// Mismatch lists the routes and the documented operations that do not match
+type Mismatch struct {
+	// Undocumented are routes of the router missing from the document
+	Undocumented []Operation
+	// Unrouted are operations of the document no route serves
+	Unrouted []Operation
+}
+
+// OK reports whether every route is documented and every operation routed
+func (m Mismatch) OK() bool {
+	return len(m.Undocumented) == 0 && len(m.Unrouted) == 0
+}
+
+// methods are the operation keys of an OpenAPI path item
+var methods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// ginParam matches the :name and *name parameters of a Gin path
+var ginParam = regexp.MustCompile(`[:*]([A-Za-z0-9_]+)`)
+
+// ServeSpec writes the OpenAPI document
+func ServeSpec(c *gin.Context) {
+	c.Data(http.StatusOK, "application/json; charset=utf-8", spec)
+}
+
+// ServeDocs writes the documentation page. It loads openapi.json relative to
+// its own URL, so both must be routed under the same prefix.
+func ServeDocs(c *gin.Context) {
+	c.Data(http.StatusOK, "text/html; charset=utf-8", docs)
+}
+
+// Operations returns the operations of the OpenAPI document, sorted by path
+// then method
+func Operations() ([]Operation, error) {
+	var document struct {
+		Paths map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	if err := json.Unmarshal(spec, &document); err != nil {
+		return nil, fmt.Errorf("invalid OpenAPI document: %w", err)
+	}
+
+	var operations []Operation
+	for path, item := range document.Paths {
+		for key := range item {
+			if slices.Contains(methods, key) {
+				operations = append(operations, Operation{Method: strings.ToUpper(key), Path: path})
+			}
+		}
+	}
+	sortOperations(operations)
+	return operations, nil
+}
+
+// Check compares the routes of a router with the operations of the document
+func Check(routes gin.RoutesInfo) (Mismatch, error) {
+	documented, err := Operations()
+	if err != nil {
+		return Mismatch{}, err
+	}
+
+	routed := make([]Operation, 0, len(routes))
+	for _, route := range routes {
+		routed = append(routed, Operation{Method: route.Method, Path: ginParam.ReplaceAllString(route.Path, "{$1}")})
+	}
+
+	var mismatch Mismatch
+	for _, operation := range routed {
+		if !slices.Contains(documented, operation) {
+			mismatch.Undocumented = append(mismatch.Undocumented, operation)
+		}
+	}
+	for _, operation := range documented {
+		if !slices.Contains(routed, operation) {
+			mismatch.Unrouted = append(mismatch.Unrouted, operation)
+		}
+	}
+	sortOperations(mismatch.Undocumented)
+	return mismatch, nil
+}
+
+func sortOperations(operations []Operation) {
+	slices.SortFunc(operations, func(a, b Operation) int {
+		if c := strings.Compare(a.Path, b.Path); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Method, b.Method)
+	})
+}
@@ -0,0 +1,32 @@
+package openapi_test
+
+import (
+	"testing"
+
+	"github.com/frtasoniero/subsmanager/internal/infrastructure/web"
+	"github.com/frtasoniero/subsmanager/internal/infrastructure/web/openapi"
+	"github.com/gin-gonic/gin"
+)
+
+// TestSpecCoversRoutes fails when a route is missing from the document or a
+// documented operation has no route, like `cli openapi check`
+func TestSpecCoversRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	// Handlers are only referenced, never called, so none is built
+	web.SetupRoutes(router, &web.AppHandlers{})
+
+	mismatch, err := openapi.Check(router.Routes())
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if mismatch.OK() {
+		return
+	}
+	for _, operation := range mismatch.Undocumented {
+		t.Errorf("%s %s: route missing from the document", operation.Method, operation.Path)
+	}
+	for _, operation := range mismatch.Unrouted {
+		t.Errorf("%s %s: documented operation has no route", operation.Method, operation.Path)
+	}
+}
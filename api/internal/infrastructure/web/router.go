@@ -1,15 +1,25 @@
 package web
 
 import (
+	"expvar"
 	"net/http"
 
+	"github.com/frtasoniero/subsmanager/internal/infrastructure/web/graphql"
 	"github.com/frtasoniero/subsmanager/internal/infrastructure/web/handlers"
+	"github.com/frtasoniero/subsmanager/internal/infrastructure/web/openapi"
 	"github.com/gin-gonic/gin"
 )
 
 // Handlers interface for dependency injection
 type AppHandlers struct {
 	Subscription *handlers.SubscriptionHandler
+	Report       *handlers.ReportHandler
+	Import       *handlers.ImportHandler
+	Export       *handlers.ExportHandler
+	Calendar     *handlers.CalendarHandler
+	Statement    *handlers.StatementHandler
+	Search       *handlers.SearchHandler
+	GraphQL      *graphql.Handler
 	// Add more handlers here as you create them
 	// User         *handlers.UserHandler
 	// Product      *handlers.ProductHandler
@@ -28,6 +38,10 @@ func SetupRoutes(r *gin.Engine, appHandlers *AppHandlers) {
 	// API v1 routes
 	v1 := r.Group("/api/v1")
 	{
+		// API documentation, kept in sync with these routes by "cli openapi check"
+		v1.GET("/openapi.json", openapi.ServeSpec)
+		v1.GET("/docs", openapi.ServeDocs)
+
 		// Subscription routes
 		subscriptions := v1.Group("/subscriptions")
 		{
@@ -45,9 +59,12 @@ func SetupRoutes(r *gin.Engine, appHandlers *AppHandlers) {
 			users.GET("", func(c *gin.Context) {
 				c.JSON(http.StatusNotImplemented, gin.H{"message": "Users endpoint not implemented yet"})
 			})
-			users.GET("/:id/subscriptions", func(c *gin.Context) {
-				c.JSON(http.StatusNotImplemented, gin.H{"message": "User subscriptions endpoint not implemented yet"})
-			})
+			users.GET("/:id/subscriptions", appHandlers.Subscription.GetUserSubscriptions)
+			users.GET("/:id/forecast", appHandlers.Subscription.GetSpendingForecast)
+			users.POST("/:id/subscriptions/import", appHandlers.Import.ImportSubscriptions)
+			users.GET("/:id/calendar.ics", appHandlers.Calendar.GetCalendar)
+			users.POST("/:id/statements/analyze", appHandlers.Statement.AnalyzeStatement)
+			users.POST("/:id/statements/confirm", appHandlers.Statement.ConfirmCandidates)
 		}
 
 		// Product routes (placeholder for future implementation)
@@ -57,5 +74,29 @@ func SetupRoutes(r *gin.Engine, appHandlers *AppHandlers) {
 				c.JSON(http.StatusNotImplemented, gin.H{"message": "Products endpoint not implemented yet"})
 			})
 		}
+
+		// Search across the product catalog and a user's subscriptions
+		v1.GET("/search", appHandlers.Search.Search)
+
+		// GraphQL over users, products and subscriptions
+		v1.POST("/graphql", appHandlers.GraphQL.Query)
+
+		// Admin routes
+		admin := v1.Group("/admin")
+		{
+			admin.GET("/metrics", appHandlers.Report.GetMetrics)
+			admin.GET("/reports/cohorts", appHandlers.Report.GetCohorts)
+			admin.GET("/exports/subscriptions", appHandlers.Export.ExportSubscriptions)
+			admin.GET("/exports/spending", appHandlers.Export.ExportSpending)
+		}
 	}
 }
+
+// AdminHandler serves the runtime and cache counters at /debug/vars. They
+// include the command line and memory statistics, so it is meant for a
+// listener that is not exposed publicly.
+func AdminHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("GET /debug/vars", expvar.Handler())
+	return mux
+}
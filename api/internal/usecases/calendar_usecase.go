@@ -0,0 +1,147 @@
+package usecases
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/frtasoniero/subsmanager/internal/domain/entities"
+	"github.com/frtasoniero/subsmanager/internal/domain/repositories"
+	"github.com/frtasoniero/subsmanager/pkg/ical"
+)
+
+// ErrCalendarDisabled is returned when no secret is configured to sign feed tokens
+var ErrCalendarDisabled = errors.New("calendar feeds are disabled, CALENDAR_SECRET is not set")
+
+// CalendarUseCase builds the iCalendar feed of a user's upcoming billing dates
+type CalendarUseCase struct {
+	subscriptionRepo repositories.SubscriptionRepository
+	secret           []byte
+	alarmDays        int
+}
+
+// NewCalendarUseCase creates a new calendar use case. Feed tokens are signed
+// with secret, and an empty secret disables the feeds.
+func NewCalendarUseCase(subscriptionRepo repositories.SubscriptionRepository, secret string, alarmDays int) *CalendarUseCase {
+	return &CalendarUseCase{
+		subscriptionRepo: subscriptionRepo,
+		secret:           []byte(secret),
+		alarmDays:        alarmDays,
+	}
+}
+
+// Enabled returns true if feed tokens can be signed
+func (uc *CalendarUseCase) Enabled() bool {
+	return len(uc.secret) > 0
+}
+
+// Token returns the token granting access to the calendar feed of a user
+func (uc *CalendarUseCase) Token(userID entities.ID) string {
+	mac := hmac.New(sha256.New, uc.secret)
+	mac.Write([]byte("calendar:" + userID.String()))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyToken returns true if token grants access to the calendar feed of the
+// user. No token is valid while the feeds are disabled.
+func (uc *CalendarUseCase) VerifyToken(userID entities.ID, token string) bool {
+	return uc.Enabled() && hmac.Equal([]byte(uc.Token(userID)), []byte(token))
+}
+
+// calendarHorizon bounds the occurrences listed one by one for billing dates
+// no recurrence rule describes
+const calendarHorizon = 2 * 365 * 24 * time.Hour
+
+// WriteCalendar writes one recurring event per subscription of the user, starting
+// at its next billing date and repeating with its billing period. Paused
+// subscriptions are emitted as tentative events without alarm, and the ones no
+// longer running as cancelled events so calendar apps remove them. A negative
+// alarmDays uses the configured default.
+//
+// Billing dates on the 29th to 31st, or on February 29 for yearly billing, roll
+// over into the next month with entities.NextBillingDate, which no RRULE can
+// express. Those events list their occurrences of the next two years instead.
+func (uc *CalendarUseCase) WriteCalendar(ctx context.Context, w io.Writer, userID entities.ID, alarmDays int) error {
+	if alarmDays < 0 {
+		alarmDays = uc.alarmDays
+	}
+
+	subscriptions, err := uc.subscriptionRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	calendar := &ical.Calendar{
+		ProdID: "-//subsmanager//Subscription Manager//EN",
+		Name:   "Subscription renewals",
+		Events: make([]ical.Event, 0, len(subscriptions)),
+	}
+
+	for _, sub := range subscriptions {
+		event := ical.Event{
+			UID:         sub.ID.String() + "@subsmanager",
+			Summary:     fmt.Sprintf("%s renewal (%.2f)", sub.ProductName, sub.ChargedPrice()),
+			Description: fmt.Sprintf("%s subscription billed %s at %.2f", sub.ProductName, sub.BillingType, sub.ChargedPrice()),
+			Date:        sub.NextBilling,
+			Status:      ical.StatusConfirmed,
+			AlarmDays:   alarmDays,
+			Stamp:       now,
+		}
+		if entities.StableBillingDay(sub.NextBilling, sub.BillingType) {
+			event.RRule = billingRRule(sub)
+		} else {
+			event.RDates = billingDates(sub, now.Add(calendarHorizon))
+		}
+
+		switch sub.Status {
+		case "active":
+		case "paused":
+			event.Summary += " (paused)"
+			event.Status = ical.StatusTentative
+			event.AlarmDays = 0
+		default:
+			event.Status = ical.StatusCancelled
+			event.AlarmDays = 0
+		}
+
+		calendar.Events = append(calendar.Events, event)
+	}
+
+	return calendar.Write(w)
+}
+
+// billingRRule returns the recurrence rule of the billing dates of a
+// subscription
+func billingRRule(sub *entities.SubscriptionWithProduct) string {
+	frequency := "MONTHLY"
+	switch sub.BillingType {
+	case "yearly":
+		frequency = "YEARLY"
+	case "weekly":
+		frequency = "WEEKLY"
+	}
+	rrule := "FREQ=" + frequency
+	if last, ok := sub.LastBillingDay(); ok {
+		rrule += ";UNTIL=" + ical.FormatDate(last)
+	}
+	return rrule
+}
+
+// billingDates returns the billing dates of a subscription following its next
+// billing date, up to until or its end date
+func billingDates(sub *entities.SubscriptionWithProduct, until time.Time) []time.Time {
+	var dates []time.Time
+	for date := entities.NextBillingDate(sub.NextBilling, sub.BillingType); date.Before(until); date = entities.NextBillingDate(date, sub.BillingType) {
+		if !sub.BillsOn(date) {
+			break
+		}
+		dates = append(dates, date)
+	}
+	return dates
+}
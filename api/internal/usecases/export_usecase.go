@@ -0,0 +1,158 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/frtasoniero/subsmanager/internal/domain/entities"
+	"github.com/frtasoniero/subsmanager/internal/domain/repositories"
+	"github.com/frtasoniero/subsmanager/pkg/export"
+)
+
+// subscriptionExportColumns are the columns of a subscription export
+var subscriptionExportColumns = []string{
+	"id", "user_id", "product_id", "product_name", "description", "category",
+	"billing_type", "price", "price_at_start", "status", "start_date", "end_date",
+	"next_billing", "created_at",
+}
+
+// spendingExportColumns are the columns of a spending summary export
+var spendingExportColumns = []string{
+	"user_id", "category", "active_subscriptions", "monthly_spend", "yearly_spend",
+}
+
+// SpendingSummary holds what a user spends on the active subscriptions of a category
+type SpendingSummary struct {
+	UserID              entities.ID `json:"user_id"`
+	Category            string      `json:"category"`
+	ActiveSubscriptions int         `json:"active_subscriptions"`
+	MonthlySpend        float64     `json:"monthly_spend"`
+	YearlySpend         float64     `json:"yearly_spend"`
+}
+
+// SpendingSummaryCache keeps computed spending summaries between requests.
+// Implementations return the cached summaries or call compute and cache its
+// result, and must be invalidated when subscriptions or products change.
+type SpendingSummaryCache interface {
+	Summaries(ctx context.Context, compute func(ctx context.Context) ([]SpendingSummary, error)) ([]SpendingSummary, error)
+}
+
+// ExportUseCase handles data exports for finance and reporting
+type ExportUseCase struct {
+	subscriptionRepo repositories.SubscriptionRepository
+	summaryCache     SpendingSummaryCache
+}
+
+// NewExportUseCase creates a new export use case
+func NewExportUseCase(subscriptionRepo repositories.SubscriptionRepository) *ExportUseCase {
+	return &ExportUseCase{
+		subscriptionRepo: subscriptionRepo,
+	}
+}
+
+// ExportSubscriptions streams every subscription with its product details to w
+func (uc *ExportUseCase) ExportSubscriptions(ctx context.Context, w io.Writer, format string) error {
+	writer, err := export.NewWriter(w, format, subscriptionExportColumns)
+	if err != nil {
+		return err
+	}
+
+	err = uc.subscriptionRepo.Stream(ctx, func(sub *entities.SubscriptionWithProduct) error {
+		return writer.WriteRow([]any{
+			sub.ID.String(), sub.UserID.String(), sub.ProductID.String(), sub.ProductName, sub.Description,
+			sub.Category, sub.BillingType, sub.Price, sub.PriceAtStart, sub.Status, sub.StartDate,
+			sub.EndDate, sub.NextBilling, sub.CreatedAt,
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("failed to export subscriptions: %w", err)
+	}
+
+	return writer.Close()
+}
+
+// WithSummaryCache makes the use case read spending summaries through cache
+func (uc *ExportUseCase) WithSummaryCache(cache SpendingSummaryCache) *ExportUseCase {
+	uc.summaryCache = cache
+	return uc
+}
+
+// GetSpendingSummaries returns the spending of every user per category,
+// based on the currently active subscriptions
+func (uc *ExportUseCase) GetSpendingSummaries(ctx context.Context) ([]SpendingSummary, error) {
+	if uc.summaryCache != nil {
+		return uc.summaryCache.Summaries(ctx, uc.computeSpendingSummaries)
+	}
+	return uc.computeSpendingSummaries(ctx)
+}
+
+func (uc *ExportUseCase) computeSpendingSummaries(ctx context.Context) ([]SpendingSummary, error) {
+	type key struct {
+		userID   entities.ID
+		category string
+	}
+	totals := make(map[key]*SpendingSummary)
+
+	err := uc.subscriptionRepo.Stream(ctx, func(sub *entities.SubscriptionWithProduct) error {
+		if sub.Status != "active" {
+			return nil
+		}
+		k := key{userID: sub.UserID, category: sub.Category}
+		summary, ok := totals[k]
+		if !ok {
+			summary = &SpendingSummary{UserID: sub.UserID, Category: sub.Category}
+			totals[k] = summary
+		}
+		summary.ActiveSubscriptions++
+		summary.MonthlySpend += sub.MonthlyAmount()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := make([]SpendingSummary, 0, len(totals))
+	for _, summary := range totals {
+		summary.MonthlySpend = round2(summary.MonthlySpend)
+		summary.YearlySpend = round2(summary.MonthlySpend * 12)
+		summaries = append(summaries, *summary)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].UserID != summaries[j].UserID {
+			return summaries[i].UserID.String() < summaries[j].UserID.String()
+		}
+		return summaries[i].Category < summaries[j].Category
+	})
+
+	return summaries, nil
+}
+
+// ExportSpendingSummaries writes the spending summaries to w
+func (uc *ExportUseCase) ExportSpendingSummaries(ctx context.Context, w io.Writer, format string) error {
+	if !export.IsSupported(format) {
+		return fmt.Errorf("unsupported export format %q", format)
+	}
+
+	summaries, err := uc.GetSpendingSummaries(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to compute spending summaries: %w", err)
+	}
+
+	writer, err := export.NewWriter(w, format, spendingExportColumns)
+	if err != nil {
+		return err
+	}
+	for _, summary := range summaries {
+		err := writer.WriteRow([]any{
+			summary.UserID.String(), summary.Category, summary.ActiveSubscriptions,
+			summary.MonthlySpend, summary.YearlySpend,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return writer.Close()
+}
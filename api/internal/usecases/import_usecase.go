@@ -0,0 +1,286 @@
+package usecases
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/frtasoniero/subsmanager/internal/domain/entities"
+	"github.com/frtasoniero/subsmanager/internal/domain/repositories"
+)
+
+// ErrImportValidation is returned when at least one imported row is invalid.
+// Nothing is written in that case.
+var ErrImportValidation = errors.New("import contains invalid rows")
+
+// maxImportRows bounds the number of rows accepted in a single import
+const maxImportRows = 5000
+
+// importDateLayout is the date format of imported start and next billing dates
+const importDateLayout = "2006-01-02"
+
+// ImportRow is a single subscription to import, as read from a CSV or JSON file
+type ImportRow struct {
+	ProductName   string      `json:"product_name"`
+	Price         json.Number `json:"price"`
+	BillingPeriod string      `json:"billing_period"`
+	StartDate     string      `json:"start_date"`
+	NextBilling   string      `json:"next_billing"`
+	Category      string      `json:"category,omitempty"`
+}
+
+// ImportRowError describes why a row cannot be imported. Rows are numbered from 1,
+// not counting the CSV header.
+type ImportRowError struct {
+	Row     int    `json:"row"`
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ImportResult reports the outcome of an import
+type ImportResult struct {
+	DryRun          bool                     `json:"dry_run"`
+	Total           int                      `json:"total"`
+	Imported        int                      `json:"imported"`
+	CreatedProducts []string                 `json:"created_products"`
+	Errors          []ImportRowError         `json:"errors"`
+	Subscriptions   []*entities.Subscription `json:"subscriptions"`
+}
+
+// ImportUseCase handles bulk import of a user's existing subscriptions
+type ImportUseCase struct {
+	subscriptionRepo repositories.SubscriptionRepository
+	productRepo      repositories.ProductRepository
+	userRepo         repositories.UserRepository
+}
+
+// NewImportUseCase creates a new import use case
+func NewImportUseCase(subscriptionRepo repositories.SubscriptionRepository, productRepo repositories.ProductRepository, userRepo repositories.UserRepository) *ImportUseCase {
+	return &ImportUseCase{
+		subscriptionRepo: subscriptionRepo,
+		productRepo:      productRepo,
+		userRepo:         userRepo,
+	}
+}
+
+// plannedSubscription is a validated row waiting for its product to be resolved
+type plannedSubscription struct {
+	row          ImportRow
+	price        float64
+	billingType  string
+	startDate    time.Time
+	nextBilling  time.Time
+	subscription *entities.Subscription
+}
+
+// ImportSubscriptions validates every row and, unless dryRun is set, creates one
+// subscription per row for the user. Products are matched by name and created
+// when unknown. When any row is invalid nothing is written and ErrImportValidation
+// is returned together with the row-level errors. An unknown user is reported
+// as repositories.ErrNotFound before anything is written.
+//
+// The import is not atomic: rows are written one by one, so a storage failure
+// half-way keeps the products and subscriptions created before it. The result
+// lists what was imported so far.
+func (uc *ImportUseCase) ImportSubscriptions(ctx context.Context, userID entities.ID, rows []ImportRow, dryRun bool) (*ImportResult, error) {
+	result := &ImportResult{
+		DryRun:          dryRun,
+		Total:           len(rows),
+		CreatedProducts: []string{},
+		Errors:          []ImportRowError{},
+		Subscriptions:   []*entities.Subscription{},
+	}
+
+	if len(rows) == 0 {
+		return result, fmt.Errorf("%w: no rows to import", ErrImportValidation)
+	}
+	if len(rows) > maxImportRows {
+		return result, fmt.Errorf("%w: at most %d rows can be imported at once", ErrImportValidation, maxImportRows)
+	}
+
+	planned := make([]*plannedSubscription, 0, len(rows))
+	for i, row := range rows {
+		plan, rowErrors := validateImportRow(i+1, row)
+		result.Errors = append(result.Errors, rowErrors...)
+		if plan != nil {
+			planned = append(planned, plan)
+		}
+	}
+	if len(result.Errors) > 0 {
+		return result, ErrImportValidation
+	}
+
+	if _, err := uc.userRepo.GetByID(ctx, userID); err != nil {
+		return result, fmt.Errorf("user %s: %w", userID, err)
+	}
+
+	// Resolve products by name, creating each unknown product only once
+	products := make(map[string]*entities.Product)
+	for _, plan := range planned {
+		product, ok := products[plan.row.ProductName]
+		if !ok {
+			var err error
+			product, err = uc.productRepo.GetByName(ctx, plan.row.ProductName)
+			if err != nil && !errors.Is(err, repositories.ErrNotFound) {
+				return result, fmt.Errorf("failed to look up product %q: %w", plan.row.ProductName, err)
+			}
+			if err != nil {
+				now := time.Now()
+				product = &entities.Product{
+					Name:        plan.row.ProductName,
+					Price:       plan.price,
+					BillingType: plan.billingType,
+					Category:    plan.row.Category,
+					Status:      "active",
+					CreatedAt:   now,
+					UpdatedAt:   now,
+				}
+				if product.Category == "" {
+					product.Category = "other"
+				}
+				if !dryRun {
+					if err := uc.productRepo.Create(ctx, product); err != nil {
+						return result, fmt.Errorf("failed to create product %q: %w", product.Name, err)
+					}
+				}
+				result.CreatedProducts = append(result.CreatedProducts, product.Name)
+			}
+			products[plan.row.ProductName] = product
+		}
+
+		now := time.Now()
+		plan.subscription = &entities.Subscription{
+			UserID:       userID,
+			ProductID:    product.ID,
+			Status:       "active",
+			StartDate:    plan.startDate,
+			NextBilling:  plan.nextBilling,
+			PriceAtStart: plan.price,
+			CreatedAt:    now,
+			UpdatedAt:    now,
+		}
+	}
+
+	for _, plan := range planned {
+		if !dryRun {
+			if err := uc.subscriptionRepo.Create(ctx, plan.subscription); err != nil {
+				return result, fmt.Errorf("failed to create subscription for %q: %w", plan.row.ProductName, err)
+			}
+			result.Imported++
+		}
+		result.Subscriptions = append(result.Subscriptions, plan.subscription)
+	}
+
+	return result, nil
+}
+
+// validateImportRow checks a single row and converts it to a planned subscription
+func validateImportRow(number int, row ImportRow) (*plannedSubscription, []ImportRowError) {
+	var rowErrors []ImportRowError
+	fail := func(field, message string) {
+		rowErrors = append(rowErrors, ImportRowError{Row: number, Field: field, Message: message})
+	}
+
+	plan := &plannedSubscription{row: row}
+	plan.row.ProductName = strings.TrimSpace(row.ProductName)
+	plan.row.Category = strings.TrimSpace(row.Category)
+
+	if plan.row.ProductName == "" {
+		fail("product_name", "product name is required")
+	}
+
+	price, err := strconv.ParseFloat(strings.TrimSpace(row.Price.String()), 64)
+	if err != nil || price <= 0 {
+		fail("price", "price must be a positive number")
+	}
+	plan.price = price
+
+	plan.billingType = strings.ToLower(strings.TrimSpace(row.BillingPeriod))
+	if plan.billingType != "weekly" && plan.billingType != "monthly" && plan.billingType != "yearly" {
+		fail("billing_period", "billing period must be weekly, monthly or yearly")
+	}
+
+	plan.startDate, err = time.Parse(importDateLayout, strings.TrimSpace(row.StartDate))
+	if err != nil {
+		fail("start_date", "start date must be formatted as YYYY-MM-DD")
+	}
+
+	plan.nextBilling, err = time.Parse(importDateLayout, strings.TrimSpace(row.NextBilling))
+	if err != nil {
+		fail("next_billing", "next billing must be formatted as YYYY-MM-DD")
+	} else if !plan.startDate.IsZero() && plan.nextBilling.Before(plan.startDate) {
+		fail("next_billing", "next billing cannot be before the start date")
+	}
+
+	if len(rowErrors) > 0 {
+		return nil, rowErrors
+	}
+	return plan, nil
+}
+
+// ParseImportCSV reads import rows from a CSV file with a header row.
+// Required columns: product_name, price, billing_period, start_date, next_billing.
+// An optional category column is used for products that have to be created.
+func ParseImportCSV(r io.Reader) ([]ImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to read CSV header: %v", ErrImportValidation, err)
+	}
+
+	columns := make(map[string]int)
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"product_name", "price", "billing_period", "start_date", "next_billing"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("%w: missing CSV column %q", ErrImportValidation, required)
+		}
+	}
+
+	field := func(record []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return record[i]
+	}
+
+	var rows []ImportRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrImportValidation, err)
+		}
+		rows = append(rows, ImportRow{
+			ProductName:   field(record, "product_name"),
+			Price:         json.Number(strings.TrimSpace(field(record, "price"))),
+			BillingPeriod: field(record, "billing_period"),
+			StartDate:     field(record, "start_date"),
+			NextBilling:   field(record, "next_billing"),
+			Category:      field(record, "category"),
+		})
+	}
+
+	return rows, nil
+}
+
+// ParseImportJSON reads import rows from a JSON array
+func ParseImportJSON(r io.Reader) ([]ImportRow, error) {
+	var rows []ImportRow
+	if err := json.NewDecoder(r).Decode(&rows); err != nil {
+		return nil, fmt.Errorf("%w: invalid JSON: %v", ErrImportValidation, err)
+	}
+	return rows, nil
+}
@@ -0,0 +1,117 @@
+package usecases
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/frtasoniero/subsmanager/internal/domain/entities"
+	"github.com/frtasoniero/subsmanager/internal/domain/repositories"
+)
+
+// Page sizes of list queries
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 200
+)
+
+// Sort orders of list queries
+const (
+	OrderAsc  = "asc"
+	OrderDesc = "desc"
+)
+
+// ErrInvalidListQuery is returned when a list is requested with invalid parameters
+var ErrInvalidListQuery = errors.New("invalid list query")
+
+// Pagination describes a page of a list and how to request the next one
+type Pagination struct {
+	Limit      int    `json:"limit"`
+	Sort       string `json:"sort"`
+	Order      string `json:"order"`
+	HasMore    bool   `json:"has_more"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// pageCursor is the decoded form of a cursor. It records the sort order it
+// was issued for, so it cannot be replayed against another order.
+type pageCursor struct {
+	Sort  string          `json:"s"`
+	Order string          `json:"o"`
+	Value json.RawMessage `json:"v"`
+	ID    entities.ID     `json:"id"`
+}
+
+// encodeCursor returns the opaque cursor of the page following key
+func encodeCursor(sort, order string, key repositories.SubscriptionKey) (string, error) {
+	value, err := json.Marshal(key.Value)
+	if err != nil {
+		return "", err
+	}
+	raw, err := json.Marshal(pageCursor{Sort: sort, Order: order, Value: value, ID: key.ID})
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// decodeCursor parses a cursor returned by encodeCursor for the same order
+func decodeCursor(cursor, sort, order string) (*repositories.SubscriptionKey, error) {
+	invalid := fmt.Errorf("%w: malformed cursor", ErrInvalidListQuery)
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, invalid
+	}
+	var decoded pageCursor
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		return nil, invalid
+	}
+	if decoded.Sort != sort || decoded.Order != order {
+		return nil, fmt.Errorf("%w: the cursor was issued for sort=%s&order=%s", ErrInvalidListQuery, decoded.Sort, decoded.Order)
+	}
+	if _, err := entities.ParseID(decoded.ID.String()); err != nil {
+		return nil, invalid
+	}
+
+	key := &repositories.SubscriptionKey{ID: decoded.ID}
+	if sort == repositories.SortPrice {
+		var price float64
+		err = json.Unmarshal(decoded.Value, &price)
+		key.Value = price
+	} else {
+		var t time.Time
+		err = json.Unmarshal(decoded.Value, &t)
+		key.Value = t
+	}
+	if err != nil {
+		return nil, invalid
+	}
+	return key, nil
+}
+
+// validatePage checks the sort, order and limit of a list query and applies
+// their defaults
+func validatePage(sort, order *string, limit *int) error {
+	if *sort == "" {
+		*sort = repositories.SortCreatedAt
+	}
+	if !slices.Contains(repositories.SortFields, *sort) {
+		return fmt.Errorf("%w: unknown sort field %q, expected one of %v", ErrInvalidListQuery, *sort, repositories.SortFields)
+	}
+	if *order == "" {
+		*order = OrderAsc
+	}
+	if *order != OrderAsc && *order != OrderDesc {
+		return fmt.Errorf("%w: unknown order %q, expected %s or %s", ErrInvalidListQuery, *order, OrderAsc, OrderDesc)
+	}
+	if *limit == 0 {
+		*limit = DefaultPageSize
+	}
+	if *limit < 1 || *limit > MaxPageSize {
+		return fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidListQuery, MaxPageSize)
+	}
+	return nil
+}
@@ -3,10 +3,10 @@ package usecases
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/frtasoniero/subsmanager/internal/domain/entities"
 	"github.com/frtasoniero/subsmanager/internal/domain/repositories"
-	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // ProductUseCase handles product business logic
@@ -34,14 +34,34 @@ func (uc *ProductUseCase) CreateProduct(ctx context.Context, product *entities.P
 		return errors.New("product with this name already exists")
 	}
 
-	return uc.productRepo.Create(ctx, product)
+	if product.Status == "" {
+		product.Status = "active"
+	}
+	now := time.Now()
+	product.CreatedAt = now
+	product.UpdatedAt = now
+
+	// The unique index catches a concurrent create that passed the check above
+	if err := uc.productRepo.Create(ctx, product); err != nil {
+		if errors.Is(err, repositories.ErrDuplicate) {
+			return errors.New("product with this name already exists")
+		}
+		return err
+	}
+	return nil
 }
 
 // GetProductByID retrieves a product by ID
-func (uc *ProductUseCase) GetProductByID(ctx context.Context, id primitive.ObjectID) (*entities.Product, error) {
+func (uc *ProductUseCase) GetProductByID(ctx context.Context, id entities.ID) (*entities.Product, error) {
 	return uc.productRepo.GetByID(ctx, id)
 }
 
+// GetProductsByIDs retrieves the products with the given IDs, in no particular
+// order, leaving out the IDs no product has
+func (uc *ProductUseCase) GetProductsByIDs(ctx context.Context, ids []entities.ID) ([]*entities.Product, error) {
+	return uc.productRepo.GetByIDs(ctx, ids)
+}
+
 // GetAllProducts retrieves all products
 func (uc *ProductUseCase) GetAllProducts(ctx context.Context) ([]*entities.Product, error) {
 	return uc.productRepo.GetAll(ctx)
@@ -64,21 +84,23 @@ func (uc *ProductUseCase) UpdateProduct(ctx context.Context, product *entities.P
 		return errors.New("invalid product price")
 	}
 
+	product.UpdatedAt = time.Now()
 	return uc.productRepo.Update(ctx, product)
 }
 
 // DeleteProduct deletes a product
-func (uc *ProductUseCase) DeleteProduct(ctx context.Context, id primitive.ObjectID) error {
+func (uc *ProductUseCase) DeleteProduct(ctx context.Context, id entities.ID) error {
 	return uc.productRepo.Delete(ctx, id)
 }
 
 // DeactivateProduct deactivates a product
-func (uc *ProductUseCase) DeactivateProduct(ctx context.Context, id primitive.ObjectID) error {
+func (uc *ProductUseCase) DeactivateProduct(ctx context.Context, id entities.ID) error {
 	product, err := uc.productRepo.GetByID(ctx, id)
 	if err != nil {
 		return err
 	}
 
 	product.Status = "inactive"
+	product.UpdatedAt = time.Now()
 	return uc.productRepo.Update(ctx, product)
 }
@@ -0,0 +1,381 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/frtasoniero/subsmanager/internal/domain/entities"
+	"github.com/frtasoniero/subsmanager/internal/domain/repositories"
+)
+
+// ErrInvalidReportQuery is returned when a report is requested with invalid parameters
+var ErrInvalidReportQuery = errors.New("invalid report query")
+
+// Report intervals used to bucket time series
+const (
+	IntervalDay   = "day"
+	IntervalWeek  = "week"
+	IntervalMonth = "month"
+)
+
+// maxSeriesPoints bounds the number of buckets a single report can produce
+const maxSeriesPoints = 1000
+
+// MetricsQuery selects the date range and bucket size of a metrics report.
+// From is inclusive and To is exclusive.
+type MetricsQuery struct {
+	From     time.Time
+	To       time.Time
+	Interval string
+}
+
+// MetricsPoint holds the metrics of a single bucket of the time series
+type MetricsPoint struct {
+	PeriodStart          time.Time `json:"period_start"`
+	PeriodEnd            time.Time `json:"period_end"`
+	MRR                  float64   `json:"mrr"`
+	ARR                  float64   `json:"arr"`
+	ActiveSubscribers    int       `json:"active_subscribers"`
+	ActiveSubscriptions  int       `json:"active_subscriptions"`
+	NewSubscriptions     int       `json:"new_subscriptions"`
+	ChurnedSubscriptions int       `json:"churned_subscriptions"`
+}
+
+// CategoryMetrics holds the metrics of the products of a single category
+type CategoryMetrics struct {
+	Category             string  `json:"category"`
+	MRR                  float64 `json:"mrr"`
+	ARR                  float64 `json:"arr"`
+	ActiveSubscriptions  int     `json:"active_subscriptions"`
+	NewSubscriptions     int     `json:"new_subscriptions"`
+	ChurnedSubscriptions int     `json:"churned_subscriptions"`
+}
+
+// MetricsReport holds the business metrics for a date range
+type MetricsReport struct {
+	From                 time.Time         `json:"from"`
+	To                   time.Time         `json:"to"`
+	Interval             string            `json:"interval"`
+	MRR                  float64           `json:"mrr"`
+	ARR                  float64           `json:"arr"`
+	ActiveSubscribers    int               `json:"active_subscribers"`
+	ActiveSubscriptions  int               `json:"active_subscriptions"`
+	NewSubscriptions     int               `json:"new_subscriptions"`
+	ChurnedSubscriptions int               `json:"churned_subscriptions"`
+	LogoChurnRate        float64           `json:"logo_churn_rate"`
+	NetRevenueRetention  float64           `json:"net_revenue_retention"`
+	Categories           []CategoryMetrics `json:"categories"`
+	Series               []MetricsPoint    `json:"series"`
+}
+
+// CohortQuery filters and sizes a cohort retention report
+type CohortQuery struct {
+	ProductID *entities.ID
+	Category  string
+	Months    int
+}
+
+// CohortPoint holds the retention of a cohort a given number of months after signup
+type CohortPoint struct {
+	Offset     int     `json:"offset"`
+	Retained   int     `json:"retained"`
+	Percentage float64 `json:"percentage"`
+}
+
+// Cohort groups the subscriptions started in the same month
+type Cohort struct {
+	Month     string        `json:"month"`
+	Size      int           `json:"size"`
+	Retention []CohortPoint `json:"retention"`
+}
+
+// CohortReport holds the retention of every monthly signup cohort
+type CohortReport struct {
+	Months  int      `json:"months"`
+	Cohorts []Cohort `json:"cohorts"`
+}
+
+// ReportUseCase handles reporting and analytics business logic
+type ReportUseCase struct {
+	subscriptionRepo repositories.SubscriptionRepository
+}
+
+// NewReportUseCase creates a new report use case
+func NewReportUseCase(subscriptionRepo repositories.SubscriptionRepository) *ReportUseCase {
+	return &ReportUseCase{
+		subscriptionRepo: subscriptionRepo,
+	}
+}
+
+// GetMetrics computes MRR, ARR, churn and retention for the given query.
+// Subscriptions are considered running between their start date and end date,
+// paused ones are left out, and revenue is based on the price locked at subscription start.
+func (uc *ReportUseCase) GetMetrics(ctx context.Context, query MetricsQuery) (*MetricsReport, error) {
+	periods, err := splitPeriods(query.From, query.To, query.Interval)
+	if err != nil {
+		return nil, err
+	}
+
+	subscriptions, err := uc.subscriptionRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Snapshot at the end of the range
+	end := query.To.Add(-time.Nanosecond)
+	mrr, activeSubscriptions, subscribers := snapshot(subscriptions, end)
+
+	report := &MetricsReport{
+		From:                query.From,
+		To:                  query.To,
+		Interval:            query.Interval,
+		MRR:                 round2(mrr),
+		ARR:                 round2(mrr * 12),
+		ActiveSubscribers:   len(subscribers),
+		ActiveSubscriptions: activeSubscriptions,
+		Categories:          []CategoryMetrics{},
+		Series:              make([]MetricsPoint, 0, len(periods)),
+	}
+
+	categories := make(map[string]*CategoryMetrics)
+	for _, sub := range subscriptions {
+		category, ok := categories[sub.Category]
+		if !ok {
+			category = &CategoryMetrics{Category: sub.Category}
+			categories[sub.Category] = category
+		}
+
+		if sub.IsActiveAt(end) {
+			category.MRR += sub.MonthlyAmount()
+			category.ActiveSubscriptions++
+		}
+		if inRange(sub.StartDate, query.From, query.To) {
+			category.NewSubscriptions++
+			report.NewSubscriptions++
+		}
+		if sub.EndDate != nil && inRange(*sub.EndDate, query.From, query.To) {
+			category.ChurnedSubscriptions++
+			report.ChurnedSubscriptions++
+		}
+	}
+
+	for _, category := range categories {
+		category.ARR = round2(category.MRR * 12)
+		category.MRR = round2(category.MRR)
+		report.Categories = append(report.Categories, *category)
+	}
+	sort.Slice(report.Categories, func(i, j int) bool {
+		return report.Categories[i].Category < report.Categories[j].Category
+	})
+
+	report.LogoChurnRate, report.NetRevenueRetention = retention(subscriptions, query.From, end)
+
+	for _, period := range periods {
+		point := MetricsPoint{
+			PeriodStart: period[0],
+			PeriodEnd:   period[1],
+		}
+
+		pointEnd := period[1].Add(-time.Nanosecond)
+		pointMRR, pointActive, pointSubscribers := snapshot(subscriptions, pointEnd)
+		point.MRR = round2(pointMRR)
+		point.ARR = round2(pointMRR * 12)
+		point.ActiveSubscriptions = pointActive
+		point.ActiveSubscribers = len(pointSubscribers)
+
+		for _, sub := range subscriptions {
+			if inRange(sub.StartDate, period[0], period[1]) {
+				point.NewSubscriptions++
+			}
+			if sub.EndDate != nil && inRange(*sub.EndDate, period[0], period[1]) {
+				point.ChurnedSubscriptions++
+			}
+		}
+
+		report.Series = append(report.Series, point)
+	}
+
+	return report, nil
+}
+
+// GetCohorts groups subscriptions by the month of their start date and reports,
+// for each following month, the percentage still running at the start of that month.
+// Subscriptions that are no longer active but have no end date are treated as
+// churned right after signup.
+func (uc *ReportUseCase) GetCohorts(ctx context.Context, query CohortQuery) (*CohortReport, error) {
+	if query.Months < 1 || query.Months > 120 {
+		return nil, fmt.Errorf("%w: months must be between 1 and 120", ErrInvalidReportQuery)
+	}
+
+	subscriptions, err := uc.subscriptionRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cohorts := make(map[time.Time][]*entities.SubscriptionWithProduct)
+	for _, sub := range subscriptions {
+		if query.ProductID != nil && sub.ProductID != *query.ProductID {
+			continue
+		}
+		if query.Category != "" && sub.Category != query.Category {
+			continue
+		}
+		month := monthStart(sub.StartDate)
+		cohorts[month] = append(cohorts[month], sub)
+	}
+
+	months := make([]time.Time, 0, len(cohorts))
+	for month := range cohorts {
+		months = append(months, month)
+	}
+	sort.Slice(months, func(i, j int) bool { return months[i].Before(months[j]) })
+
+	now := time.Now()
+	report := &CohortReport{
+		Months:  query.Months,
+		Cohorts: make([]Cohort, 0, len(months)),
+	}
+
+	for _, month := range months {
+		members := cohorts[month]
+		cohort := Cohort{
+			Month:     month.Format("2006-01"),
+			Size:      len(members),
+			Retention: []CohortPoint{},
+		}
+
+		for offset := 0; offset <= query.Months; offset++ {
+			at := month.AddDate(0, offset, 0)
+			if offset > 0 && at.After(now) {
+				break
+			}
+
+			retained := 0
+			for _, sub := range members {
+				if offset == 0 || retainedAt(sub, at) {
+					retained++
+				}
+			}
+
+			cohort.Retention = append(cohort.Retention, CohortPoint{
+				Offset:     offset,
+				Retained:   retained,
+				Percentage: round2(float64(retained) * 100 / float64(len(members))),
+			})
+		}
+
+		report.Cohorts = append(report.Cohorts, cohort)
+	}
+
+	return report, nil
+}
+
+// retainedAt returns true if the subscription had not churned at the given time
+func retainedAt(sub *entities.SubscriptionWithProduct, at time.Time) bool {
+	if sub.EndDate != nil {
+		return sub.EndDate.After(at)
+	}
+	return sub.Status == "active"
+}
+
+// monthStart returns the first instant of the month of t
+func monthStart(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+}
+
+// snapshot returns the MRR, the number of running subscriptions and the set of
+// subscribers with at least one running subscription at the given time
+func snapshot(subscriptions []*entities.SubscriptionWithProduct, at time.Time) (float64, int, map[entities.ID]float64) {
+	var mrr float64
+	active := 0
+	subscribers := make(map[entities.ID]float64)
+
+	for _, sub := range subscriptions {
+		if !sub.IsActiveAt(at) {
+			continue
+		}
+		mrr += sub.MonthlyAmount()
+		active++
+		subscribers[sub.UserID] += sub.MonthlyAmount()
+	}
+
+	return mrr, active, subscribers
+}
+
+// retention returns the logo churn rate and the net revenue retention of the
+// subscribers that were active at the start of the range
+func retention(subscriptions []*entities.SubscriptionWithProduct, from, end time.Time) (float64, float64) {
+	_, _, startSubscribers := snapshot(subscriptions, from)
+	if len(startSubscribers) == 0 {
+		return 0, 0
+	}
+	_, _, endSubscribers := snapshot(subscriptions, end)
+
+	var startMRR, retainedMRR float64
+	churned := 0
+	for userID, amount := range startSubscribers {
+		startMRR += amount
+		if endAmount, ok := endSubscribers[userID]; ok {
+			retainedMRR += endAmount
+		} else {
+			churned++
+		}
+	}
+
+	logoChurn := float64(churned) / float64(len(startSubscribers))
+	nrr := 0.0
+	if startMRR > 0 {
+		nrr = retainedMRR / startMRR
+	}
+
+	return round4(logoChurn), round4(nrr)
+}
+
+// splitPeriods splits the range into consecutive [start, end) buckets
+func splitPeriods(from, to time.Time, interval string) ([][2]time.Time, error) {
+	if from.IsZero() || to.IsZero() || !from.Before(to) {
+		return nil, fmt.Errorf("%w: from must be before to", ErrInvalidReportQuery)
+	}
+
+	var next func(time.Time) time.Time
+	switch interval {
+	case IntervalDay:
+		next = func(t time.Time) time.Time { return t.AddDate(0, 0, 1) }
+	case IntervalWeek:
+		next = func(t time.Time) time.Time { return t.AddDate(0, 0, 7) }
+	case IntervalMonth:
+		next = func(t time.Time) time.Time { return t.AddDate(0, 1, 0) }
+	default:
+		return nil, fmt.Errorf("%w: unknown interval %q", ErrInvalidReportQuery, interval)
+	}
+
+	var periods [][2]time.Time
+	for start := from; start.Before(to); start = next(start) {
+		if len(periods) == maxSeriesPoints {
+			return nil, fmt.Errorf("%w: range produces more than %d %s buckets", ErrInvalidReportQuery, maxSeriesPoints, interval)
+		}
+		end := next(start)
+		if end.After(to) {
+			end = to
+		}
+		periods = append(periods, [2]time.Time{start, end})
+	}
+
+	return periods, nil
+}
+
+// inRange returns true if t is in [from, to)
+func inRange(t, from, to time.Time) bool {
+	return !t.Before(from) && t.Before(to)
+}
+
+func round2(v float64) float64 {
+	return float64(int64(v*100+0.5)) / 100
+}
+
+func round4(v float64) float64 {
+	return float64(int64(v*10000+0.5)) / 10000
+}
@@ -0,0 +1,165 @@
+package usecases
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/frtasoniero/subsmanager/internal/domain/entities"
+	"github.com/frtasoniero/subsmanager/internal/domain/repositories"
+	"github.com/frtasoniero/subsmanager/pkg/textsearch"
+)
+
+// Result sizes of searches
+const (
+	DefaultSearchLimit = 20
+	MaxSearchLimit     = 100
+	// MaxSearchLength bounds the length of a query in bytes
+	MaxSearchLength = 256
+)
+
+// searchCandidates bounds the matching products a user's subscriptions are
+// ranked against
+const searchCandidates = 500
+
+// ErrInvalidSearchQuery is returned when a search is requested with invalid parameters
+var ErrInvalidSearchQuery = errors.New("invalid search query")
+
+// SearchQuery is a full-text search. Subscriptions are only searched when
+// the search is scoped to a user.
+type SearchQuery struct {
+	Text   string
+	UserID *entities.ID
+	Limit  int
+}
+
+// ProductHit is a product matching a search. Highlights hold the matching
+// fields, HTML-escaped, with the matching words wrapped in <mark> tags.
+type ProductHit struct {
+	Product    *entities.Product `json:"product"`
+	Score      float64           `json:"score"`
+	Highlights map[string]string `json:"highlights"`
+}
+
+// SubscriptionHit is a subscription of the user whose product matches a search
+type SubscriptionHit struct {
+	Subscription *entities.SubscriptionWithProduct `json:"subscription"`
+	Score        float64                           `json:"score"`
+	Highlights   map[string]string                 `json:"highlights"`
+}
+
+// SearchResults holds the hits of a search, most relevant first.
+// Subscriptions is null when the search is not scoped to a user.
+type SearchResults struct {
+	Query         string            `json:"query"`
+	Products      []ProductHit      `json:"products"`
+	Subscriptions []SubscriptionHit `json:"subscriptions"`
+}
+
+// SearchUseCase searches the product catalog and a user's subscriptions
+type SearchUseCase struct {
+	productRepo      repositories.ProductRepository
+	subscriptionRepo repositories.SubscriptionRepository
+}
+
+// NewSearchUseCase creates a new search use case
+func NewSearchUseCase(productRepo repositories.ProductRepository, subscriptionRepo repositories.SubscriptionRepository) *SearchUseCase {
+	return &SearchUseCase{
+		productRepo:      productRepo,
+		subscriptionRepo: subscriptionRepo,
+	}
+}
+
+// Search ranks the products whose name, category or description match the
+// words of the query. With a user, it also ranks the user's subscriptions by
+// the relevance of their product, so a user only ever sees their own
+// subscriptions.
+func (uc *SearchUseCase) Search(ctx context.Context, query SearchQuery) (*SearchResults, error) {
+	text := strings.TrimSpace(query.Text)
+	if len(text) > MaxSearchLength {
+		return nil, fmt.Errorf("%w: q must be at most %d characters", ErrInvalidSearchQuery, MaxSearchLength)
+	}
+	terms := textsearch.Terms(text)
+	if len(terms) == 0 {
+		return nil, fmt.Errorf("%w: q must contain a word", ErrInvalidSearchQuery)
+	}
+	if query.Limit == 0 {
+		query.Limit = DefaultSearchLimit
+	}
+	if query.Limit < 1 || query.Limit > MaxSearchLimit {
+		return nil, fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidSearchQuery, MaxSearchLimit)
+	}
+
+	// The normalized terms are searched, so no word acts as an operator of
+	// the backend, such as a MongoDB negation or phrase
+	limit := query.Limit
+	if query.UserID != nil {
+		limit = searchCandidates
+	}
+	matches, err := uc.productRepo.Search(ctx, strings.Join(terms, " "), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	results := &SearchResults{Query: text, Products: []ProductHit{}}
+	for _, match := range matches[:min(len(matches), query.Limit)] {
+		results.Products = append(results.Products, ProductHit{
+			Product: match.Product,
+			Score:   match.Score,
+			Highlights: highlight(terms, map[string]string{
+				"name":        match.Product.Name,
+				"category":    match.Product.Category,
+				"description": match.Product.Description,
+			}),
+		})
+	}
+
+	if query.UserID == nil {
+		return results, nil
+	}
+	subscriptions, err := uc.subscriptionRepo.GetByUserID(ctx, *query.UserID)
+	if err != nil {
+		return nil, err
+	}
+	scores := make(map[entities.ID]float64, len(matches))
+	for _, match := range matches {
+		scores[match.Product.ID] = match.Score
+	}
+	results.Subscriptions = []SubscriptionHit{}
+	for _, subscription := range subscriptions {
+		score, ok := scores[subscription.ProductID]
+		if !ok {
+			continue
+		}
+		results.Subscriptions = append(results.Subscriptions, SubscriptionHit{
+			Subscription: subscription,
+			Score:        score,
+			Highlights: highlight(terms, map[string]string{
+				"product_name": subscription.ProductName,
+				"category":     subscription.Category,
+				"description":  subscription.Description,
+			}),
+		})
+	}
+	slices.SortStableFunc(results.Subscriptions, func(a, b SubscriptionHit) int {
+		return cmp.Compare(b.Score, a.Score)
+	})
+	if len(results.Subscriptions) > query.Limit {
+		results.Subscriptions = results.Subscriptions[:query.Limit]
+	}
+	return results, nil
+}
+
+// highlight returns the highlighted form of the fields with a matching word
+func highlight(terms []string, fields map[string]string) map[string]string {
+	highlights := make(map[string]string)
+	for name, text := range fields {
+		if highlighted, ok := textsearch.Highlight(text, terms); ok {
+			highlights[name] = highlighted
+		}
+	}
+	return highlights
+}
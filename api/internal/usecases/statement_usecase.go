@@ -0,0 +1,331 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/frtasoniero/subsmanager/internal/domain/entities"
+	"github.com/frtasoniero/subsmanager/internal/domain/repositories"
+)
+
+// ErrNoCandidatesConfirmed is returned when a confirmation contains no candidate
+var ErrNoCandidatesConfirmed = errors.New("no candidates to confirm")
+
+// recurrencePattern describes a billing period and the day gaps accepted for it
+type recurrencePattern struct {
+	billingType string
+	minDays     float64
+	maxDays     float64
+	minCount    int
+}
+
+// recurrencePatterns are checked in order against the typical gap between charges
+var recurrencePatterns = []recurrencePattern{
+	{billingType: "weekly", minDays: 6, maxDays: 8, minCount: 3},
+	{billingType: "monthly", minDays: 26, maxDays: 35, minCount: 2},
+	{billingType: "yearly", minDays: 350, maxDays: 380, minCount: 2},
+}
+
+// amountTolerance is the relative difference allowed between charges of the same subscription
+const amountTolerance = 0.1
+
+// regularityThreshold is the share of gaps and amounts that must match the pattern
+const regularityThreshold = 0.75
+
+var (
+	merchantNoise  = regexp.MustCompile(`\b(POS|PURCHASE|DEBIT|CARD|RECURRING|PAYMENT|DIRECT|DD|SEPA|VISA|MASTERCARD|ONLINE|WWW|COM|NET|INC|LTD|LLC|PLC|CORP|GMBH|AB|BV|SA|SARL|[A-Z])\b`)
+	merchantDigits = regexp.MustCompile(`[0-9]+`)
+	merchantSymbol = regexp.MustCompile(`[^A-Z ]+`)
+)
+
+// RecurringCandidate is a group of bank transactions that looks like a subscription
+type RecurringCandidate struct {
+	Merchant          string       `json:"merchant"`
+	Description       string       `json:"description"`
+	BillingPeriod     string       `json:"billing_period"`
+	Amount            float64      `json:"amount"`
+	Occurrences       int          `json:"occurrences"`
+	FirstSeen         time.Time    `json:"first_seen"`
+	LastSeen          time.Time    `json:"last_seen"`
+	NextBilling       time.Time    `json:"next_billing"`
+	Confidence        float64      `json:"confidence"`
+	ProductID         *entities.ID `json:"product_id,omitempty"`
+	ProductName       string       `json:"product_name"`
+	Category          string       `json:"category,omitempty"`
+	AlreadySubscribed bool         `json:"already_subscribed"`
+}
+
+// ConfirmedCandidate is a candidate the user accepted, possibly after editing it
+type ConfirmedCandidate struct {
+	ProductName   string    `json:"product_name"`
+	Amount        float64   `json:"amount"`
+	BillingPeriod string    `json:"billing_period"`
+	FirstSeen     time.Time `json:"first_seen"`
+	NextBilling   time.Time `json:"next_billing"`
+	Category      string    `json:"category,omitempty"`
+}
+
+// StatementUseCase detects forgotten subscriptions in bank statements
+type StatementUseCase struct {
+	subscriptionRepo repositories.SubscriptionRepository
+	productRepo      repositories.ProductRepository
+	importUseCase    *ImportUseCase
+}
+
+// NewStatementUseCase creates a new statement use case
+func NewStatementUseCase(subscriptionRepo repositories.SubscriptionRepository, productRepo repositories.ProductRepository, importUseCase *ImportUseCase) *StatementUseCase {
+	return &StatementUseCase{
+		subscriptionRepo: subscriptionRepo,
+		productRepo:      productRepo,
+		importUseCase:    importUseCase,
+	}
+}
+
+// DetectRecurring groups the debits of a statement by normalized merchant and
+// returns the groups charged a similar amount at weekly, monthly or yearly
+// intervals. Candidates are matched against existing products by name and
+// flagged when the user already has an active subscription to that product.
+func (uc *StatementUseCase) DetectRecurring(ctx context.Context, userID entities.ID, transactions []entities.BankTransaction) ([]RecurringCandidate, error) {
+	groups := make(map[string][]entities.BankTransaction)
+	for _, transaction := range transactions {
+		if transaction.Amount >= 0 {
+			continue
+		}
+		merchant := NormalizeMerchant(transaction.Description)
+		if merchant == "" {
+			continue
+		}
+		groups[merchant] = append(groups[merchant], transaction)
+	}
+
+	products, err := uc.productRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	subscriptions, err := uc.subscriptionRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	subscribed := make(map[entities.ID]bool)
+	for _, sub := range subscriptions {
+		if sub.Status == "active" {
+			subscribed[sub.ProductID] = true
+		}
+	}
+
+	candidates := []RecurringCandidate{}
+	for merchant, group := range groups {
+		candidate, ok := detectRecurrence(merchant, group)
+		if !ok {
+			continue
+		}
+
+		if product := matchProduct(merchant, products); product != nil {
+			id := product.ID
+			candidate.ProductID = &id
+			candidate.ProductName = product.Name
+			candidate.Category = product.Category
+			candidate.AlreadySubscribed = subscribed[product.ID]
+		}
+
+		candidates = append(candidates, candidate)
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].Confidence != candidates[j].Confidence {
+			return candidates[i].Confidence > candidates[j].Confidence
+		}
+		return candidates[i].Merchant < candidates[j].Merchant
+	})
+
+	return candidates, nil
+}
+
+// ConfirmCandidates creates the subscriptions the user confirmed. Products are
+// matched by name or created, exactly like an import.
+func (uc *StatementUseCase) ConfirmCandidates(ctx context.Context, userID entities.ID, confirmed []ConfirmedCandidate) (*ImportResult, error) {
+	if len(confirmed) == 0 {
+		return nil, ErrNoCandidatesConfirmed
+	}
+
+	// The import only sees formatted dates, where a missing date would read
+	// as 0001-01-01
+	var dateErrors []ImportRowError
+	for i, candidate := range confirmed {
+		if candidate.FirstSeen.IsZero() {
+			dateErrors = append(dateErrors, ImportRowError{Row: i + 1, Field: "first_seen", Message: "first seen date is required"})
+		}
+		if candidate.NextBilling.IsZero() {
+			dateErrors = append(dateErrors, ImportRowError{Row: i + 1, Field: "next_billing", Message: "next billing date is required"})
+		}
+	}
+	if len(dateErrors) > 0 {
+		return &ImportResult{
+			Total:           len(confirmed),
+			CreatedProducts: []string{},
+			Errors:          dateErrors,
+			Subscriptions:   []*entities.Subscription{},
+		}, ErrImportValidation
+	}
+
+	rows := make([]ImportRow, 0, len(confirmed))
+	for _, candidate := range confirmed {
+		rows = append(rows, ImportRow{
+			ProductName:   candidate.ProductName,
+			Price:         json.Number(strconv.FormatFloat(candidate.Amount, 'f', 2, 64)),
+			BillingPeriod: candidate.BillingPeriod,
+			StartDate:     candidate.FirstSeen.Format(importDateLayout),
+			NextBilling:   candidate.NextBilling.Format(importDateLayout),
+			Category:      candidate.Category,
+		})
+	}
+
+	return uc.importUseCase.ImportSubscriptions(ctx, userID, rows, false)
+}
+
+// NormalizeMerchant reduces a transaction description to a stable merchant key,
+// dropping card network noise, reference numbers and punctuation
+func NormalizeMerchant(description string) string {
+	merchant := strings.ToUpper(description)
+	// Payment processor prefixes such as "PAYPAL *NETFLIX" or "SQ *COFFEE"
+	if i := strings.LastIndex(merchant, "*"); i >= 0 && i < len(merchant)-1 {
+		merchant = merchant[i+1:]
+	}
+	merchant = merchantDigits.ReplaceAllString(merchant, " ")
+	merchant = merchantSymbol.ReplaceAllString(merchant, " ")
+	merchant = merchantNoise.ReplaceAllString(merchant, " ")
+
+	// The first two words are stable across statements, later ones often are not
+	words := strings.Fields(merchant)
+	if len(words) > 2 {
+		words = words[:2]
+	}
+	return strings.Join(words, " ")
+}
+
+// detectRecurrence checks whether a group of charges repeats regularly
+func detectRecurrence(merchant string, group []entities.BankTransaction) (RecurringCandidate, bool) {
+	sort.Slice(group, func(i, j int) bool { return group[i].Date.Before(group[j].Date) })
+
+	if len(group) < 2 {
+		return RecurringCandidate{}, false
+	}
+
+	gaps := make([]float64, 0, len(group)-1)
+	for i := 1; i < len(group); i++ {
+		gaps = append(gaps, group[i].Date.Sub(group[i-1].Date).Hours()/24)
+	}
+	typicalGap := median(gaps)
+
+	var pattern *recurrencePattern
+	for i := range recurrencePatterns {
+		if typicalGap >= recurrencePatterns[i].minDays && typicalGap <= recurrencePatterns[i].maxDays {
+			pattern = &recurrencePatterns[i]
+			break
+		}
+	}
+	if pattern == nil || len(group) < pattern.minCount {
+		return RecurringCandidate{}, false
+	}
+
+	regularGaps := 0
+	for _, gap := range gaps {
+		if gap >= pattern.minDays && gap <= pattern.maxDays {
+			regularGaps++
+		}
+	}
+
+	amounts := make([]float64, 0, len(group))
+	for _, transaction := range group {
+		amounts = append(amounts, math.Abs(transaction.Amount))
+	}
+	typicalAmount := median(amounts)
+	regularAmounts := 0
+	for _, amount := range amounts {
+		if math.Abs(amount-typicalAmount) <= typicalAmount*amountTolerance {
+			regularAmounts++
+		}
+	}
+
+	gapRegularity := float64(regularGaps) / float64(len(gaps))
+	amountRegularity := float64(regularAmounts) / float64(len(amounts))
+	if gapRegularity < regularityThreshold || amountRegularity < regularityThreshold {
+		return RecurringCandidate{}, false
+	}
+
+	// More occurrences make a coincidence less likely
+	occurrenceFactor := math.Min(1, float64(len(group))/float64(pattern.minCount+2))
+	confidence := gapRegularity * amountRegularity * (0.5 + 0.5*occurrenceFactor)
+
+	first := group[0]
+	last := group[len(group)-1]
+	return RecurringCandidate{
+		Merchant:      merchant,
+		Description:   last.Description,
+		BillingPeriod: pattern.billingType,
+		Amount:        math.Abs(last.Amount),
+		Occurrences:   len(group),
+		FirstSeen:     first.Date,
+		LastSeen:      last.Date,
+		NextBilling:   entities.NextBillingDate(last.Date, pattern.billingType),
+		Confidence:    round2(confidence),
+		ProductName:   titleCase(merchant),
+	}, true
+}
+
+// minCompactMatch is the shortest product name matched without its spaces, so
+// short names do not match parts of unrelated merchants
+const minCompactMatch = 4
+
+// matchProduct returns the product whose normalized name is part of the merchant
+// key, preferring the longest name. Names match whole words, or without spaces
+// the whole merchant key or one of its words, e.g. "Disney Plus" and "DISNEYPLUS".
+func matchProduct(merchant string, products []*entities.Product) *entities.Product {
+	var best *entities.Product
+	bestLength := 0
+	padded := " " + merchant + " "
+	words := strings.Fields(merchant)
+	compactMerchant := strings.Join(words, "")
+
+	for _, product := range products {
+		name := NormalizeMerchant(product.Name)
+		if name == "" {
+			continue
+		}
+		compact := strings.ReplaceAll(name, " ", "")
+		matches := strings.Contains(padded, " "+name+" ") ||
+			len(compact) >= minCompactMatch && (compact == compactMerchant || slices.Contains(words, compact))
+		if matches && len(name) > bestLength {
+			best = product
+			bestLength = len(name)
+		}
+	}
+
+	return best
+}
+
+func median(values []float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[middle-1] + sorted[middle]) / 2
+	}
+	return sorted[middle]
+}
+
+func titleCase(value string) string {
+	words := strings.Fields(strings.ToLower(value))
+	for i, word := range words {
+		words[i] = strings.ToUpper(word[:1]) + word[1:]
+	}
+	return strings.Join(words, " ")
+}
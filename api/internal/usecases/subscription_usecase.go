@@ -2,12 +2,21 @@ package usecases
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
 
 	"github.com/frtasoniero/subsmanager/internal/domain/entities"
 	"github.com/frtasoniero/subsmanager/internal/domain/repositories"
-	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// ErrInvalidForecastHorizon is returned when a forecast is requested for an unsupported horizon
+var ErrInvalidForecastHorizon = errors.New("forecast horizon must be between 1 and 60 months")
+
+// ErrInvalidStatusChange is returned when a subscription cannot move to a status from its current one
+var ErrInvalidStatusChange = errors.New("invalid status change")
+
 type SubscriptionUseCase struct {
 	subscriptionRepo repositories.SubscriptionRepository
 }
@@ -22,10 +31,259 @@ func (uc *SubscriptionUseCase) GetAllSubscriptions(ctx context.Context) ([]*enti
 	return uc.subscriptionRepo.GetAll(ctx)
 }
 
-func (uc *SubscriptionUseCase) GetSubscriptionsByUser(ctx context.Context, userID primitive.ObjectID) ([]*entities.SubscriptionWithProduct, error) {
+// SubscriptionListQuery selects a page of subscriptions. Sort, Order and Limit
+// default to created_at, asc and DefaultPageSize.
+type SubscriptionListQuery struct {
+	Filter repositories.SubscriptionFilter
+	Sort   string
+	Order  string
+	Limit  int
+	// Cursor is the NextCursor of the previous page, empty for the first page
+	Cursor string
+}
+
+// SubscriptionPage is a page of subscriptions with its pagination metadata
+type SubscriptionPage struct {
+	Subscriptions []*entities.SubscriptionWithProduct
+	Pagination    Pagination
+}
+
+// ListSubscriptions returns a page of subscriptions using keyset pagination,
+// so pages stay consistent while subscriptions are added
+func (uc *SubscriptionUseCase) ListSubscriptions(ctx context.Context, query SubscriptionListQuery) (*SubscriptionPage, error) {
+	if err := validatePage(&query.Sort, &query.Order, &query.Limit); err != nil {
+		return nil, err
+	}
+	filter := query.Filter
+	if filter.MinPrice != nil && filter.MaxPrice != nil && *filter.MinPrice > *filter.MaxPrice {
+		return nil, fmt.Errorf("%w: min_price must not exceed max_price", ErrInvalidListQuery)
+	}
+	if dates := filter.NextBilling; dates.From != nil && dates.To != nil && !dates.From.Before(*dates.To) {
+		return nil, fmt.Errorf("%w: next_billing_from must be before next_billing_to", ErrInvalidListQuery)
+	}
+
+	listQuery := repositories.SubscriptionQuery{
+		Filter:     filter,
+		Sort:       query.Sort,
+		Descending: query.Order == OrderDesc,
+		// One more than requested tells whether another page follows
+		Limit: query.Limit + 1,
+	}
+	if query.Cursor != "" {
+		after, err := decodeCursor(query.Cursor, query.Sort, query.Order)
+		if err != nil {
+			return nil, err
+		}
+		listQuery.After = after
+	}
+
+	subscriptions, err := uc.subscriptionRepo.Find(ctx, listQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &SubscriptionPage{
+		Subscriptions: subscriptions,
+		Pagination:    Pagination{Limit: query.Limit, Sort: query.Sort, Order: query.Order},
+	}
+	if len(subscriptions) > query.Limit {
+		page.Subscriptions = subscriptions[:query.Limit]
+		last := page.Subscriptions[query.Limit-1]
+		page.Pagination.HasMore = true
+		page.Pagination.NextCursor, err = encodeCursor(query.Sort, query.Order, repositories.KeyOf(query.Sort, last))
+		if err != nil {
+			return nil, err
+		}
+	}
+	if page.Subscriptions == nil {
+		page.Subscriptions = []*entities.SubscriptionWithProduct{}
+	}
+	return page, nil
+}
+
+// FindSubscriptions returns every subscription matching filter with its product
+func (uc *SubscriptionUseCase) FindSubscriptions(ctx context.Context, filter repositories.SubscriptionFilter) ([]*entities.SubscriptionWithProduct, error) {
+	return uc.subscriptionRepo.Find(ctx, repositories.SubscriptionQuery{Filter: filter})
+}
+
+func (uc *SubscriptionUseCase) GetSubscriptionsByUser(ctx context.Context, userID entities.ID) ([]*entities.SubscriptionWithProduct, error) {
 	return uc.subscriptionRepo.GetByUserID(ctx, userID)
 }
 
 func (uc *SubscriptionUseCase) CreateSubscription(ctx context.Context, subscription *entities.Subscription) error {
+	if subscription.Status == "" {
+		subscription.Status = "active"
+	}
+	now := time.Now()
+	if subscription.StartDate.IsZero() {
+		subscription.StartDate = now
+	}
+	subscription.CreatedAt = now
+	subscription.UpdatedAt = now
+
 	return uc.subscriptionRepo.Create(ctx, subscription)
 }
+
+func (uc *SubscriptionUseCase) GetSubscriptionByID(ctx context.Context, id entities.ID) (*entities.Subscription, error) {
+	return uc.subscriptionRepo.GetByID(ctx, id)
+}
+
+func (uc *SubscriptionUseCase) UpdateSubscription(ctx context.Context, subscription *entities.Subscription) error {
+	subscription.UpdatedAt = time.Now()
+	return uc.subscriptionRepo.Update(ctx, subscription)
+}
+
+// CancelSubscription cancels a subscription, ending it now
+func (uc *SubscriptionUseCase) CancelSubscription(ctx context.Context, id entities.ID) (*entities.Subscription, error) {
+	subscription, err := uc.subscriptionRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if subscription.IsCancelled() {
+		return nil, fmt.Errorf("%w: subscription is already cancelled", ErrInvalidStatusChange)
+	}
+
+	subscription.Cancel()
+	if err := uc.subscriptionRepo.Update(ctx, subscription); err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+// PauseSubscription pauses an active subscription. It keeps its dates but is
+// left out of forecasts and calendar reminders until it is set back to active.
+func (uc *SubscriptionUseCase) PauseSubscription(ctx context.Context, id entities.ID) (*entities.Subscription, error) {
+	subscription, err := uc.subscriptionRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !subscription.IsActive() {
+		return nil, fmt.Errorf("%w: only active subscriptions can be paused, this one is %s", ErrInvalidStatusChange, subscription.Status)
+	}
+
+	subscription.Pause()
+	if err := uc.subscriptionRepo.Update(ctx, subscription); err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+func (uc *SubscriptionUseCase) DeleteSubscription(ctx context.Context, id entities.ID) error {
+	return uc.subscriptionRepo.Delete(ctx, id)
+}
+
+// ProjectedCharge is a single upcoming charge of a subscription
+type ProjectedCharge struct {
+	Date           time.Time   `json:"date"`
+	SubscriptionID entities.ID `json:"subscription_id"`
+	ProductID      entities.ID `json:"product_id"`
+	ProductName    string      `json:"product_name"`
+	Amount         float64     `json:"amount"`
+}
+
+// ForecastDay groups the charges projected for a single day
+type ForecastDay struct {
+	Date    string            `json:"date"`
+	Total   float64           `json:"total"`
+	Charges []ProjectedCharge `json:"charges"`
+}
+
+// ForecastMonth holds the projected total of a single month
+type ForecastMonth struct {
+	Month   string  `json:"month"`
+	Total   float64 `json:"total"`
+	Charges int     `json:"charges"`
+}
+
+// SpendingForecast holds every charge projected for a user over a horizon
+type SpendingForecast struct {
+	UserID entities.ID     `json:"user_id"`
+	From   time.Time       `json:"from"`
+	To     time.Time       `json:"to"`
+	Total  float64         `json:"total"`
+	Days   []ForecastDay   `json:"days"`
+	Months []ForecastMonth `json:"months"`
+}
+
+// ForecastSpending projects the upcoming charges of a user's active subscriptions
+// from now until the given number of months ahead. Billing dates are advanced with
+// entities.NextBillingDate, the same rule used when renewing a subscription, and no
+// charges are projected on or after a scheduled end date.
+func (uc *SubscriptionUseCase) ForecastSpending(ctx context.Context, userID entities.ID, months int) (*SpendingForecast, error) {
+	if months < 1 || months > 60 {
+		return nil, ErrInvalidForecastHorizon
+	}
+
+	subscriptions, err := uc.subscriptionRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	from := time.Now().UTC()
+	to := from.AddDate(0, months, 0)
+
+	var charges []ProjectedCharge
+	for _, sub := range subscriptions {
+		if sub.Status != "active" {
+			continue
+		}
+
+		// Billing dates behind us are skipped
+		date := entities.BillingDateOnOrAfter(sub.NextBilling, sub.BillingType, from)
+		for ; date.Before(to); date = entities.NextBillingDate(date, sub.BillingType) {
+			if !sub.BillsOn(date) {
+				break
+			}
+			charges = append(charges, ProjectedCharge{
+				Date:           date.UTC(),
+				SubscriptionID: sub.ID,
+				ProductID:      sub.ProductID,
+				ProductName:    sub.ProductName,
+				Amount:         sub.ChargedPrice(),
+			})
+		}
+	}
+
+	// Charges on the same date are ordered by subscription, so the forecast
+	// reads the same on every call
+	sort.Slice(charges, func(i, j int) bool {
+		if !charges[i].Date.Equal(charges[j].Date) {
+			return charges[i].Date.Before(charges[j].Date)
+		}
+		return charges[i].SubscriptionID < charges[j].SubscriptionID
+	})
+
+	forecast := &SpendingForecast{
+		UserID: userID,
+		From:   from,
+		To:     to,
+		Days:   []ForecastDay{},
+		Months: []ForecastMonth{},
+	}
+
+	// Every month of the horizon is listed, including the ones without charges
+	monthIndex := make(map[string]int)
+	for month := monthStart(from); month.Before(to); month = month.AddDate(0, 1, 0) {
+		key := month.Format("2006-01")
+		monthIndex[key] = len(forecast.Months)
+		forecast.Months = append(forecast.Months, ForecastMonth{Month: key})
+	}
+
+	for _, charge := range charges {
+		day := charge.Date.Format("2006-01-02")
+		if n := len(forecast.Days); n == 0 || forecast.Days[n-1].Date != day {
+			forecast.Days = append(forecast.Days, ForecastDay{Date: day})
+		}
+		current := &forecast.Days[len(forecast.Days)-1]
+		current.Charges = append(current.Charges, charge)
+		current.Total = round2(current.Total + charge.Amount)
+
+		month := &forecast.Months[monthIndex[charge.Date.Format("2006-01")]]
+		month.Total = round2(month.Total + charge.Amount)
+		month.Charges++
+
+		forecast.Total = round2(forecast.Total + charge.Amount)
+	}
+
+	return forecast, nil
+}
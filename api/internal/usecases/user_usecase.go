@@ -3,10 +3,10 @@ package usecases
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/frtasoniero/subsmanager/internal/domain/entities"
 	"github.com/frtasoniero/subsmanager/internal/domain/repositories"
-	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // UserUseCase handles user business logic
@@ -42,11 +42,25 @@ func (uc *UserUseCase) CreateUser(ctx context.Context, user *entities.User) erro
 	// Set password (should be hashed in production)
 	user.SetPassword(user.Password)
 
-	return uc.userRepo.Create(ctx, user)
+	if user.Status == "" {
+		user.Status = "active"
+	}
+	now := time.Now()
+	user.CreatedAt = now
+	user.UpdatedAt = now
+
+	// The unique indexes catch a concurrent create that passed the checks above
+	if err := uc.userRepo.Create(ctx, user); err != nil {
+		if errors.Is(err, repositories.ErrDuplicate) {
+			return errors.New("user with this email or username already exists")
+		}
+		return err
+	}
+	return nil
 }
 
 // GetUserByID retrieves a user by ID
-func (uc *UserUseCase) GetUserByID(ctx context.Context, id primitive.ObjectID) (*entities.User, error) {
+func (uc *UserUseCase) GetUserByID(ctx context.Context, id entities.ID) (*entities.User, error) {
 	return uc.userRepo.GetByID(ctx, id)
 }
 
@@ -62,14 +76,27 @@ func (uc *UserUseCase) UpdateUser(ctx context.Context, user *entities.User) erro
 		return errors.New("invalid email format")
 	}
 
+	user.UpdatedAt = time.Now()
 	return uc.userRepo.Update(ctx, user)
 }
 
 // DeleteUser deletes a user
-func (uc *UserUseCase) DeleteUser(ctx context.Context, id primitive.ObjectID) error {
+func (uc *UserUseCase) DeleteUser(ctx context.Context, id entities.ID) error {
 	return uc.userRepo.Delete(ctx, id)
 }
 
+// DeactivateUser deactivates a user
+func (uc *UserUseCase) DeactivateUser(ctx context.Context, id entities.ID) error {
+	user, err := uc.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	user.Status = "inactive"
+	user.UpdatedAt = time.Now()
+	return uc.userRepo.Update(ctx, user)
+}
+
 // AuthenticateUser authenticates a user by email and password
 func (uc *UserUseCase) AuthenticateUser(ctx context.Context, email, password string) (*entities.User, error) {
 	user, err := uc.userRepo.GetByEmail(ctx, email)
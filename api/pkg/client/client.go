@@ -0,0 +1,272 @@
+// Package client is a Go client of the subscription manager REST API.
+//
+// Methods take a context, decode the data of the APIResponse envelope into
+// typed results and return an *Error for failed requests:
+//
+//	c, err := client.New(client.Config{BaseURL: "http://localhost:8080"})
+//	...
+//	for subscription, err := range c.Subscriptions(ctx, client.ListOptions{Status: "active"}) {
+//		...
+//	}
+//
+// Products and users are listed as the API serves them, which it does not
+// yet: those methods return an *Error matching ErrNotImplemented. There are
+// no methods for authentication or invoices, which have no routes yet.
+//
+// GET requests are retried with exponential backoff on network errors and on
+// 429, 502, 503 and 504 responses. Other requests are not retried, since the
+// API cannot tell whether they were applied.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Defaults of Config
+const (
+	DefaultTimeout     = 30 * time.Second
+	DefaultMaxAttempts = 4
+	DefaultMinBackoff  = 100 * time.Millisecond
+	DefaultMaxBackoff  = 5 * time.Second
+)
+
+// Config configures a Client. Only BaseURL is required.
+type Config struct {
+	// BaseURL is the root of the API, such as http://localhost:8080
+	BaseURL string
+	// HTTPClient sends the requests, defaults to a client with DefaultTimeout
+	HTTPClient *http.Client
+	// MaxAttempts bounds the attempts of a GET request, retries included.
+	// Defaults to DefaultMaxAttempts; 1 disables retries.
+	MaxAttempts int
+	// MinBackoff and MaxBackoff bound the wait before a retry, which doubles
+	// after every attempt
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+	// UserAgent is sent with every request when set
+	UserAgent string
+}
+
+// Client calls the API. It is safe for concurrent use.
+type Client struct {
+	baseURL    *url.URL
+	httpClient *http.Client
+	config     Config
+}
+
+// New creates a client
+func New(config Config) (*Client, error) {
+	baseURL, err := url.Parse(strings.TrimSuffix(config.BaseURL, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid base URL %q: %w", config.BaseURL, err)
+	}
+	if baseURL.Scheme != "http" && baseURL.Scheme != "https" {
+		return nil, fmt.Errorf("invalid base URL %q: expected an http or https URL", config.BaseURL)
+	}
+	if config.HTTPClient == nil {
+		config.HTTPClient = &http.Client{Timeout: DefaultTimeout}
+	}
+	if config.MaxAttempts < 1 {
+		config.MaxAttempts = DefaultMaxAttempts
+	}
+	if config.MinBackoff <= 0 {
+		config.MinBackoff = DefaultMinBackoff
+	}
+	if config.MaxBackoff < config.MinBackoff {
+		config.MaxBackoff = max(DefaultMaxBackoff, config.MinBackoff)
+	}
+	return &Client{baseURL: baseURL, httpClient: config.HTTPClient, config: config}, nil
+}
+
+// Ping checks that the API is up
+func (c *Client) Ping(ctx context.Context) error {
+	resp, err := c.send(ctx, request{method: http.MethodGet, path: "/ping"})
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// request is an API call. The body is kept in memory so it can be resent.
+type request struct {
+	method      string
+	path        string
+	query       url.Values
+	body        []byte
+	contentType string
+}
+
+// envelope is the APIResponse wrapping every JSON response
+type envelope struct {
+	Success    bool            `json:"success"`
+	Message    string          `json:"message"`
+	Data       json.RawMessage `json:"data"`
+	Pagination *Pagination     `json:"pagination"`
+	Error      string          `json:"error"`
+}
+
+// call sends a request and decodes the data of the response into out, and
+// its pagination, if any, into page. On failure, the data of the error
+// response is still decoded into out when present.
+func (c *Client) call(ctx context.Context, req request, out any, page *Pagination) error {
+	resp, err := c.send(ctx, req)
+	var apiErr *Error
+	if errors.As(err, &apiErr) && out != nil && len(apiErr.Data) > 0 {
+		if decodeErr := json.Unmarshal(apiErr.Data, out); decodeErr != nil {
+			return errors.Join(err, fmt.Errorf("failed to decode error data: %w", decodeErr))
+		}
+	}
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var body envelope
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return fmt.Errorf("failed to decode %s %s response: %w", req.method, req.path, err)
+	}
+	if out != nil && len(body.Data) > 0 {
+		if err := json.Unmarshal(body.Data, out); err != nil {
+			return fmt.Errorf("failed to decode %s %s data: %w", req.method, req.path, err)
+		}
+	}
+	if page != nil && body.Pagination != nil {
+		*page = *body.Pagination
+	}
+	return nil
+}
+
+// send sends a request, retrying GET requests, and returns the response of
+// the first attempt with a 2xx status. The caller closes its body. Other
+// statuses are returned as an *Error.
+func (c *Client) send(ctx context.Context, req request) (*http.Response, error) {
+	attempts := 1
+	if req.method == http.MethodGet {
+		attempts = c.config.MaxAttempts
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < attempts; attempt++ {
+		if attempt > 0 {
+			if err := sleep(ctx, c.backoff(attempt, lastErr)); err != nil {
+				return nil, errors.Join(err, lastErr)
+			}
+		}
+
+		resp, err := c.do(ctx, req)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, err
+			}
+			lastErr = err
+			continue
+		}
+		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			return resp, nil
+		}
+		lastErr = decodeError(resp)
+		resp.Body.Close()
+		if !retryable(resp.StatusCode) {
+			return nil, lastErr
+		}
+	}
+	return nil, lastErr
+}
+
+func (c *Client) do(ctx context.Context, req request) (*http.Response, error) {
+	target := c.baseURL.JoinPath(req.path)
+	target.RawQuery = req.query.Encode()
+
+	var body io.Reader
+	if req.body != nil {
+		body = bytes.NewReader(req.body)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, req.method, target.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Accept", "application/json")
+	if req.contentType != "" {
+		httpReq.Header.Set("Content-Type", req.contentType)
+	}
+	if c.config.UserAgent != "" {
+		httpReq.Header.Set("User-Agent", c.config.UserAgent)
+	}
+	return c.httpClient.Do(httpReq)
+}
+
+// backoff returns the wait before an attempt: the Retry-After of a 429 or
+// 503 response when given, otherwise an exponential backoff with jitter
+func (c *Client) backoff(attempt int, lastErr error) time.Duration {
+	var apiErr *Error
+	if errors.As(lastErr, &apiErr) && apiErr.RetryAfter > 0 {
+		return min(apiErr.RetryAfter, c.config.MaxBackoff)
+	}
+	wait := c.config.MinBackoff << (attempt - 1)
+	if wait <= 0 || wait > c.config.MaxBackoff {
+		wait = c.config.MaxBackoff
+	}
+	// Full jitter within the upper half spreads the retries of many clients
+	return wait/2 + rand.N(wait/2+1)
+}
+
+// retryable reports whether a status is worth retrying
+func retryable(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+func sleep(ctx context.Context, wait time.Duration) error {
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// retryAfter parses a Retry-After header given in seconds
+func retryAfter(header string) time.Duration {
+	seconds, err := strconv.Atoi(strings.TrimSpace(header))
+	if err != nil || seconds < 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// userPath returns the escaped path of a resource of a user
+func userPath(userID, resource string) string {
+	return "/api/v1/users/" + url.PathEscape(userID) + "/" + resource
+}
+
+// setInt adds an optional integer query parameter
+func setInt(query url.Values, name string, value int) {
+	if value != 0 {
+		query.Set(name, strconv.Itoa(value))
+	}
+}
+
+// setDate adds an optional YYYY-MM-DD query parameter
+func setDate(query url.Values, name string, value time.Time) {
+	if !value.IsZero() {
+		query.Set(name, value.Format(DateLayout))
+	}
+}
@@ -0,0 +1,391 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/frtasoniero/subsmanager/internal/app"
+	"github.com/frtasoniero/subsmanager/internal/domain/entities"
+	"github.com/frtasoniero/subsmanager/pkg/client"
+)
+
+// newAppClient serves the API over the seeded in-memory storage
+func newAppClient(t *testing.T) *client.Client {
+	t.Helper()
+	t.Setenv("APP_STORAGE", "memory")
+	t.Setenv("CACHE_SIZE", "0")
+
+	a, err := app.NewApp()
+	if err != nil {
+		t.Fatalf("new app: %v", err)
+	}
+	t.Cleanup(func() { a.Close(context.Background()) })
+	server := httptest.NewServer(app.NewServer(a).Handler())
+	t.Cleanup(server.Close)
+
+	return newClient(t, server.URL, client.Config{})
+}
+
+func newClient(t *testing.T, baseURL string, config client.Config) *client.Client {
+	t.Helper()
+	config.BaseURL = baseURL
+	c, err := client.New(config)
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	return c
+}
+
+// flakyServer answers requests with respond, called with the 1-based number
+// of the request, and counts them
+func flakyServer(t *testing.T, respond func(w http.ResponseWriter, r *http.Request, n int)) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		respond(w, r, int(requests.Add(1)))
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func writeJSON(w http.ResponseWriter, status int, body string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	fmt.Fprint(w, body)
+}
+
+// fastRetries keeps the backoff of the retry tests short
+var fastRetries = client.Config{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+
+func TestAppSubscriptionPages(t *testing.T) {
+	c := newAppClient(t)
+	ctx := context.Background()
+
+	first, err := c.ListSubscriptions(ctx, client.ListOptions{Limit: 2})
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(first.Subscriptions) != 2 || !first.Pagination.HasMore || first.Pagination.NextCursor == "" {
+		t.Fatalf("first page: got %d subscriptions and %+v, want 2 and a next cursor", len(first.Subscriptions), first.Pagination)
+	}
+
+	seen := map[string]bool{}
+	for subscription, err := range c.Subscriptions(ctx, client.ListOptions{Limit: 2}) {
+		if err != nil {
+			t.Fatalf("iterate: %v", err)
+		}
+		if seen[subscription.ID] {
+			t.Fatalf("subscription %s returned twice", subscription.ID)
+		}
+		seen[subscription.ID] = true
+	}
+	for _, subscription := range first.Subscriptions {
+		if !seen[subscription.ID] {
+			t.Errorf("subscription %s of the first page not iterated", subscription.ID)
+		}
+	}
+	if len(seen) <= 2 {
+		t.Errorf("iterated %d subscriptions, want more than one page", len(seen))
+	}
+}
+
+func TestAppUserSubscriptions(t *testing.T) {
+	c := newAppClient(t)
+	ctx := context.Background()
+
+	page, err := c.ListSubscriptions(ctx, client.ListOptions{Limit: 1})
+	if err != nil || len(page.Subscriptions) == 0 {
+		t.Fatalf("list: %v, %d subscriptions", err, len(page.Subscriptions))
+	}
+	userID := page.Subscriptions[0].UserID
+
+	count := 0
+	for subscription, err := range c.UserSubscriptions(ctx, userID, client.ListOptions{}) {
+		if err != nil {
+			t.Fatalf("iterate: %v", err)
+		}
+		if subscription.UserID != userID {
+			t.Errorf("subscription %s belongs to %s, want %s", subscription.ID, subscription.UserID, userID)
+		}
+		count++
+	}
+	if count == 0 {
+		t.Errorf("user %s has no subscriptions", userID)
+	}
+
+	forecast, err := c.Forecast(ctx, userID, 3)
+	if err != nil {
+		t.Fatalf("forecast: %v", err)
+	}
+	if forecast.UserID != userID {
+		t.Errorf("forecast of %s, want %s", forecast.UserID, userID)
+	}
+}
+
+func TestAppErrors(t *testing.T) {
+	c := newAppClient(t)
+	ctx := context.Background()
+
+	t.Run("invalid rows", func(t *testing.T) {
+		page, err := c.ListSubscriptions(ctx, client.ListOptions{Limit: 1})
+		if err != nil || len(page.Subscriptions) == 0 {
+			t.Fatalf("list: %v", err)
+		}
+		rows := []client.ImportRow{{ProductName: "Netflix", Price: -1, BillingPeriod: "monthly", StartDate: "2025-01-01", NextBilling: "2025-02-01"}}
+		result, err := c.ImportSubscriptions(ctx, page.Subscriptions[0].UserID, rows, true)
+		if !errors.Is(err, client.ErrUnprocessable) {
+			t.Fatalf("got error %v, want ErrUnprocessable", err)
+		}
+		if len(result.Errors) == 0 {
+			t.Errorf("result lists no invalid rows")
+		}
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		rows := []client.ImportRow{{ProductName: "Netflix", Price: 10, BillingPeriod: "monthly", StartDate: "2025-01-01", NextBilling: "2025-02-01"}}
+		_, err := c.ImportSubscriptions(ctx, string(entities.NewID()), rows, true)
+		var apiErr *client.Error
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+			t.Fatalf("got error %v, want a 404 *client.Error", err)
+		}
+		if !errors.Is(err, client.ErrNotFound) {
+			t.Errorf("error %v does not match ErrNotFound", err)
+		}
+	})
+
+	t.Run("invalid ID", func(t *testing.T) {
+		_, err := c.Forecast(ctx, "not-an-id", 0)
+		if !errors.Is(err, client.ErrBadRequest) {
+			t.Errorf("got error %v, want ErrBadRequest", err)
+		}
+	})
+
+	t.Run("not implemented", func(t *testing.T) {
+		_, productsErr := c.ListProducts(ctx)
+		_, usersErr := c.ListUsers(ctx)
+		for _, err := range []error{productsErr, usersErr} {
+			var apiErr *client.Error
+			if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotImplemented {
+				t.Errorf("got error %v, want a 501 *client.Error", err)
+			}
+			if !errors.Is(err, client.ErrNotImplemented) {
+				t.Errorf("error %v does not match ErrNotImplemented", err)
+			}
+		}
+	})
+}
+
+func TestRetry(t *testing.T) {
+	server, requests := flakyServer(t, func(w http.ResponseWriter, r *http.Request, n int) {
+		if n < 3 {
+			writeJSON(w, http.StatusServiceUnavailable, `{"success":false,"message":"Unavailable"}`)
+			return
+		}
+		writeJSON(w, http.StatusOK, `{"success":true,"data":[{"id":"a"}],"pagination":{"limit":50}}`)
+	})
+	c := newClient(t, server.URL, fastRetries)
+
+	page, err := c.ListSubscriptions(context.Background(), client.ListOptions{})
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(page.Subscriptions) != 1 || page.Subscriptions[0].ID != "a" {
+		t.Errorf("got %+v, want subscription a", page.Subscriptions)
+	}
+	if got := requests.Load(); got != 3 {
+		t.Errorf("sent %d requests, want 3", got)
+	}
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	server, requests := flakyServer(t, func(w http.ResponseWriter, r *http.Request, n int) {
+		writeJSON(w, http.StatusBadGateway, `{"success":false,"message":"Upstream failed","error":"connection reset"}`)
+	})
+	c := newClient(t, server.URL, fastRetries)
+
+	_, err := c.ListSubscriptions(context.Background(), client.ListOptions{})
+	var apiErr *client.Error
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("got error %v, want a *client.Error", err)
+	}
+	if apiErr.StatusCode != http.StatusBadGateway || apiErr.Message != "Upstream failed" || apiErr.Detail != "connection reset" {
+		t.Errorf("got %+v, want the decoded 502 response", apiErr)
+	}
+	if !errors.Is(err, client.ErrServer) {
+		t.Errorf("error %v does not match ErrServer", err)
+	}
+	if got := requests.Load(); got != 3 {
+		t.Errorf("sent %d requests, want 3", got)
+	}
+}
+
+func TestNoRetry(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		call   func(c *client.Client) error
+	}{
+		{"POST", http.StatusServiceUnavailable, func(c *client.Client) error {
+			_, err := c.ImportSubscriptions(context.Background(), "user", nil, false)
+			return err
+		}},
+		{"client error", http.StatusBadRequest, func(c *client.Client) error {
+			_, err := c.ListSubscriptions(context.Background(), client.ListOptions{})
+			return err
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, requests := flakyServer(t, func(w http.ResponseWriter, r *http.Request, n int) {
+				writeJSON(w, tt.status, `{"success":false,"message":"Failed"}`)
+			})
+			if err := tt.call(newClient(t, server.URL, fastRetries)); err == nil {
+				t.Fatalf("got no error, want %d", tt.status)
+			}
+			if got := requests.Load(); got != 1 {
+				t.Errorf("sent %d requests, want 1", got)
+			}
+		})
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	server, requests := flakyServer(t, func(w http.ResponseWriter, r *http.Request, n int) {
+		if n == 1 {
+			w.Header().Set("Retry-After", "1")
+			writeJSON(w, http.StatusTooManyRequests, `{"success":false,"message":"Slow down"}`)
+			return
+		}
+		writeJSON(w, http.StatusOK, `{"success":true,"data":[]}`)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		requests.Store(0)
+		c := newClient(t, server.URL, client.Config{MaxAttempts: 1})
+		_, err := c.ListSubscriptions(context.Background(), client.ListOptions{})
+		var apiErr *client.Error
+		if !errors.As(err, &apiErr) || apiErr.RetryAfter != time.Second {
+			t.Fatalf("got error %#v, want a 429 *client.Error with RetryAfter 1s", err)
+		}
+		if !errors.Is(err, client.ErrRateLimited) {
+			t.Errorf("error %v does not match ErrRateLimited", err)
+		}
+	})
+
+	t.Run("wait", func(t *testing.T) {
+		requests.Store(0)
+		c := newClient(t, server.URL, client.Config{MaxAttempts: 2, MinBackoff: time.Millisecond, MaxBackoff: 5 * time.Second})
+		start := time.Now()
+		if _, err := c.ListSubscriptions(context.Background(), client.ListOptions{}); err != nil {
+			t.Fatalf("list: %v", err)
+		}
+		if elapsed := time.Since(start); elapsed < time.Second {
+			t.Errorf("retried after %v, want the 1s of Retry-After", elapsed)
+		}
+	})
+
+	t.Run("capped by MaxBackoff", func(t *testing.T) {
+		requests.Store(0)
+		c := newClient(t, server.URL, client.Config{MaxAttempts: 2, MinBackoff: time.Millisecond, MaxBackoff: 10 * time.Millisecond})
+		start := time.Now()
+		if _, err := c.ListSubscriptions(context.Background(), client.ListOptions{}); err != nil {
+			t.Fatalf("list: %v", err)
+		}
+		if elapsed := time.Since(start); elapsed >= time.Second {
+			t.Errorf("retried after %v, want at most MaxBackoff", elapsed)
+		}
+	})
+}
+
+func TestRetryCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	server, _ := flakyServer(t, func(w http.ResponseWriter, r *http.Request, n int) {
+		cancel()
+		writeJSON(w, http.StatusServiceUnavailable, `{"success":false,"message":"Unavailable"}`)
+	})
+	c := newClient(t, server.URL, client.Config{MaxAttempts: 3, MinBackoff: time.Minute})
+
+	_, err := c.ListSubscriptions(ctx, client.ListOptions{})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("got error %v, want context.Canceled", err)
+	}
+}
+
+func TestIterator(t *testing.T) {
+	pages := map[string]string{
+		"":   `{"success":true,"data":[{"id":"a"},{"id":"b"}],"pagination":{"limit":2,"has_more":true,"next_cursor":"c1"}}`,
+		"c1": `{"success":true,"data":[{"id":"c"}],"pagination":{"limit":2,"has_more":true,"next_cursor":"c2"}}`,
+	}
+	server, requests := flakyServer(t, func(w http.ResponseWriter, r *http.Request, n int) {
+		if r.URL.Query().Get("status") != "active" {
+			writeJSON(w, http.StatusBadRequest, `{"success":false,"message":"Filters lost"}`)
+			return
+		}
+		page, ok := pages[r.URL.Query().Get("cursor")]
+		if !ok {
+			writeJSON(w, http.StatusBadRequest, `{"success":false,"message":"Invalid cursor"}`)
+			return
+		}
+		writeJSON(w, http.StatusOK, page)
+	})
+	c := newClient(t, server.URL, fastRetries)
+
+	t.Run("follows cursors until an error", func(t *testing.T) {
+		requests.Store(0)
+		var ids []string
+		var iterErr error
+		for subscription, err := range c.Subscriptions(context.Background(), client.ListOptions{Status: "active"}) {
+			if err != nil {
+				iterErr = err
+				continue
+			}
+			ids = append(ids, subscription.ID)
+		}
+		if fmt.Sprint(ids) != "[a b c]" {
+			t.Errorf("iterated %v, want [a b c]", ids)
+		}
+		if !errors.Is(iterErr, client.ErrBadRequest) {
+			t.Errorf("got error %v, want the ErrBadRequest of the third page", iterErr)
+		}
+		if got := requests.Load(); got != 3 {
+			t.Errorf("sent %d requests, want 3", got)
+		}
+	})
+
+	t.Run("stops on break", func(t *testing.T) {
+		requests.Store(0)
+		for subscription, err := range c.Subscriptions(context.Background(), client.ListOptions{Status: "active"}) {
+			if err != nil {
+				t.Fatalf("iterate: %v", err)
+			}
+			if subscription.ID == "b" {
+				break
+			}
+		}
+		if got := requests.Load(); got != 1 {
+			t.Errorf("sent %d requests, want 1", got)
+		}
+	})
+}
+
+func TestErrorWithoutEnvelope(t *testing.T) {
+	server, _ := flakyServer(t, func(w http.ResponseWriter, r *http.Request, n int) {
+		http.Error(w, "gateway page", http.StatusNotFound)
+	})
+	c := newClient(t, server.URL, fastRetries)
+
+	_, err := c.Search(context.Background(), client.SearchOptions{Query: "netflix"})
+	var apiErr *client.Error
+	if !errors.As(err, &apiErr) || apiErr.Message != http.StatusText(http.StatusNotFound) {
+		t.Fatalf("got error %v, want a 404 *client.Error with the status text", err)
+	}
+	if !errors.Is(err, client.ErrNotFound) {
+		t.Errorf("error %v does not match ErrNotFound", err)
+	}
+}
@@ -0,0 +1,93 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Errors matched with errors.Is against the *Error of a failed request
+var (
+	ErrBadRequest     = errors.New("bad request")
+	ErrUnauthorized   = errors.New("unauthorized")
+	ErrNotFound       = errors.New("not found")
+	ErrUnprocessable  = errors.New("unprocessable request")
+	ErrRateLimited    = errors.New("rate limited")
+	ErrNotImplemented = errors.New("not implemented")
+	ErrServer         = errors.New("server error")
+)
+
+// maxErrorBody bounds the part of an error response that is read
+const maxErrorBody = 1 << 20
+
+// Error is a response of the API with a non-2xx status
+type Error struct {
+	StatusCode int
+	// Message is the message of the APIResponse, or the status text when the
+	// response is not an APIResponse
+	Message string
+	// Detail is the error field of the APIResponse
+	Detail string
+	// Data is the data field of the APIResponse, set by imports and
+	// confirmations with invalid rows
+	Data json.RawMessage
+	// RetryAfter is the wait requested by a 429 or 503 response
+	RetryAfter time.Duration
+}
+
+func (e *Error) Error() string {
+	if e.Detail == "" {
+		return fmt.Sprintf("subsmanager: %d %s", e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("subsmanager: %d %s: %s", e.StatusCode, e.Message, e.Detail)
+}
+
+// Unwrap returns the error of the status class, such as ErrNotFound, or nil
+func (e *Error) Unwrap() error {
+	switch {
+	case e.StatusCode == http.StatusBadRequest:
+		return ErrBadRequest
+	case e.StatusCode == http.StatusUnauthorized:
+		return ErrUnauthorized
+	case e.StatusCode == http.StatusNotFound:
+		return ErrNotFound
+	case e.StatusCode == http.StatusUnprocessableEntity:
+		return ErrUnprocessable
+	case e.StatusCode == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case e.StatusCode == http.StatusNotImplemented:
+		return ErrNotImplemented
+	case e.StatusCode >= 500:
+		return ErrServer
+	default:
+		return nil
+	}
+}
+
+// decodeError reads the APIResponse of a failed request
+func decodeError(resp *http.Response) *Error {
+	apiErr := &Error{
+		StatusCode: resp.StatusCode,
+		Message:    http.StatusText(resp.StatusCode),
+		RetryAfter: retryAfter(resp.Header.Get("Retry-After")),
+	}
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	if err != nil {
+		return apiErr
+	}
+	var body envelope
+	if json.Unmarshal(raw, &body) != nil {
+		return apiErr
+	}
+	if body.Message != "" {
+		apiErr.Message = body.Message
+	}
+	apiErr.Detail = body.Error
+	if len(body.Data) > 0 && string(body.Data) != "null" {
+		apiErr.Data = body.Data
+	}
+	return apiErr
+}
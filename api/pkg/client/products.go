@@ -0,0 +1,30 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// Product is a product of the catalog
+type Product struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Price       float64   `json:"price"`
+	BillingType string    `json:"billing_type"`
+	Category    string    `json:"category"`
+	Status      string    `json:"status"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// ListProducts returns the products of the catalog. The API does not serve
+// them yet: the error matches ErrNotImplemented until it does.
+func (c *Client) ListProducts(ctx context.Context) ([]Product, error) {
+	var products []Product
+	if err := c.call(ctx, request{method: http.MethodGet, path: "/api/v1/products"}, &products, nil); err != nil {
+		return nil, err
+	}
+	return products, nil
+}
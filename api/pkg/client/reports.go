@@ -0,0 +1,163 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Export formats
+const (
+	FormatCSV    = "csv"
+	FormatTSV    = "tsv"
+	FormatNDJSON = "ndjson"
+	FormatODS    = "ods"
+)
+
+// MetricsOptions selects the period of a metrics report. Zero values are left
+// to the API defaults: the last 12 months, by month.
+type MetricsOptions struct {
+	// From and To are inclusive dates
+	From time.Time
+	To   time.Time
+	// Interval is day, week or month
+	Interval string
+}
+
+// MetricsPoint holds the metrics of one period of a series
+type MetricsPoint struct {
+	PeriodStart          time.Time `json:"period_start"`
+	PeriodEnd            time.Time `json:"period_end"`
+	MRR                  float64   `json:"mrr"`
+	ARR                  float64   `json:"arr"`
+	ActiveSubscribers    int       `json:"active_subscribers"`
+	ActiveSubscriptions  int       `json:"active_subscriptions"`
+	NewSubscriptions     int       `json:"new_subscriptions"`
+	ChurnedSubscriptions int       `json:"churned_subscriptions"`
+}
+
+// CategoryMetrics holds the metrics of a product category
+type CategoryMetrics struct {
+	Category             string  `json:"category"`
+	MRR                  float64 `json:"mrr"`
+	ARR                  float64 `json:"arr"`
+	ActiveSubscriptions  int     `json:"active_subscriptions"`
+	NewSubscriptions     int     `json:"new_subscriptions"`
+	ChurnedSubscriptions int     `json:"churned_subscriptions"`
+}
+
+// MetricsReport holds MRR, ARR, churn and retention metrics
+type MetricsReport struct {
+	From                 time.Time         `json:"from"`
+	To                   time.Time         `json:"to"`
+	Interval             string            `json:"interval"`
+	MRR                  float64           `json:"mrr"`
+	ARR                  float64           `json:"arr"`
+	ActiveSubscribers    int               `json:"active_subscribers"`
+	ActiveSubscriptions  int               `json:"active_subscriptions"`
+	NewSubscriptions     int               `json:"new_subscriptions"`
+	ChurnedSubscriptions int               `json:"churned_subscriptions"`
+	LogoChurnRate        float64           `json:"logo_churn_rate"`
+	NetRevenueRetention  float64           `json:"net_revenue_retention"`
+	Categories           []CategoryMetrics `json:"categories"`
+	Series               []MetricsPoint    `json:"series"`
+}
+
+// Metrics returns the business metrics of a period
+func (c *Client) Metrics(ctx context.Context, options MetricsOptions) (*MetricsReport, error) {
+	query := url.Values{}
+	setDate(query, "from", options.From)
+	setDate(query, "to", options.To)
+	if options.Interval != "" {
+		query.Set("interval", options.Interval)
+	}
+	var report MetricsReport
+	if err := c.call(ctx, request{method: http.MethodGet, path: "/api/v1/admin/metrics", query: query}, &report, nil); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// CohortOptions filters and sizes a cohort report. Months defaults to 12.
+type CohortOptions struct {
+	ProductID string
+	Category  string
+	Months    int
+}
+
+// CohortPoint holds the retention of a cohort some months after signup
+type CohortPoint struct {
+	Offset     int     `json:"offset"`
+	Retained   int     `json:"retained"`
+	Percentage float64 `json:"percentage"`
+}
+
+// Cohort is the retention of the subscriptions started in a month
+type Cohort struct {
+	Month     string        `json:"month"`
+	Size      int           `json:"size"`
+	Retention []CohortPoint `json:"retention"`
+}
+
+// CohortReport is the monthly signup cohort retention report
+type CohortReport struct {
+	Months  int      `json:"months"`
+	Cohorts []Cohort `json:"cohorts"`
+}
+
+// Cohorts returns the monthly signup cohort retention report
+func (c *Client) Cohorts(ctx context.Context, options CohortOptions) (*CohortReport, error) {
+	query := url.Values{}
+	if options.ProductID != "" {
+		query.Set("product_id", options.ProductID)
+	}
+	if options.Category != "" {
+		query.Set("category", options.Category)
+	}
+	setInt(query, "months", options.Months)
+	var report CohortReport
+	if err := c.call(ctx, request{method: http.MethodGet, path: "/api/v1/admin/reports/cohorts", query: query}, &report, nil); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// ExportSubscriptions writes all subscriptions with their product to w in a
+// format, FormatCSV when empty
+func (c *Client) ExportSubscriptions(ctx context.Context, format string, w io.Writer) error {
+	return c.export(ctx, "/api/v1/admin/exports/subscriptions", format, w)
+}
+
+// ExportSpending writes the spending of every user per category to w in a
+// format, FormatCSV when empty
+func (c *Client) ExportSpending(ctx context.Context, format string, w io.Writer) error {
+	return c.export(ctx, "/api/v1/admin/exports/spending", format, w)
+}
+
+// export streams an export to w. A failure after streaming started is
+// returned as a read error, and w then holds a partial file.
+func (c *Client) export(ctx context.Context, path, format string, w io.Writer) error {
+	query := url.Values{}
+	if format != "" {
+		query.Set("format", format)
+	}
+	resp, err := c.send(ctx, request{method: http.MethodGet, path: path, query: query})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, err = io.Copy(w, resp.Body)
+	return err
+}
+
+// download returns the body of a response that is not an APIResponse
+func (c *Client) download(ctx context.Context, req request) ([]byte, error) {
+	resp, err := c.send(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return io.ReadAll(resp.Body)
+}
@@ -0,0 +1,53 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// SearchOptions is a full-text search. Limit defaults to 20.
+type SearchOptions struct {
+	Query string
+	// UserID also searches the subscriptions of this user
+	UserID string
+	Limit  int
+}
+
+// ProductHit is a product matching a search. Highlights hold the matching
+// fields, HTML-escaped, with the matching words wrapped in <mark> tags.
+type ProductHit struct {
+	Product    Product           `json:"product"`
+	Score      float64           `json:"score"`
+	Highlights map[string]string `json:"highlights"`
+}
+
+// SubscriptionHit is a subscription whose product matches a search
+type SubscriptionHit struct {
+	Subscription Subscription      `json:"subscription"`
+	Score        float64           `json:"score"`
+	Highlights   map[string]string `json:"highlights"`
+}
+
+// SearchResults holds the hits of a search, most relevant first.
+// Subscriptions is nil when the search is not scoped to a user.
+type SearchResults struct {
+	Query         string            `json:"query"`
+	Products      []ProductHit      `json:"products"`
+	Subscriptions []SubscriptionHit `json:"subscriptions"`
+}
+
+// Search ranks the products, and the subscriptions of a user, matching the
+// words of a query
+func (c *Client) Search(ctx context.Context, options SearchOptions) (*SearchResults, error) {
+	query := url.Values{"q": {options.Query}}
+	if options.UserID != "" {
+		query.Set("user_id", options.UserID)
+	}
+	setInt(query, "limit", options.Limit)
+	var results SearchResults
+	if err := c.call(ctx, request{method: http.MethodGet, path: "/api/v1/search", query: query}, &results, nil); err != nil {
+		return nil, err
+	}
+	return &results, nil
+}
@@ -0,0 +1,339 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// DateLayout is the format of the date query parameters of the API
+const DateLayout = "2006-01-02"
+
+// Subscription is a subscription joined with its product
+type Subscription struct {
+	ID           string     `json:"id"`
+	UserID       string     `json:"user_id"`
+	ProductID    string     `json:"product_id"`
+	ProductName  string     `json:"product_name"`
+	Description  string     `json:"description"`
+	Price        float64    `json:"price"`
+	BillingType  string     `json:"billing_type"`
+	Category     string     `json:"category"`
+	PriceAtStart float64    `json:"price_at_start"`
+	Status       string     `json:"status"`
+	StartDate    time.Time  `json:"start_date"`
+	EndDate      *time.Time `json:"end_date,omitempty"`
+	NextBilling  time.Time  `json:"next_billing"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// Pagination describes a page of a list and how to request the next one
+type Pagination struct {
+	Limit      int    `json:"limit"`
+	Sort       string `json:"sort"`
+	Order      string `json:"order"`
+	HasMore    bool   `json:"has_more"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// ListOptions selects a page of subscriptions. Zero values are left to the
+// API defaults: 50 subscriptions sorted by created_at, ascending.
+type ListOptions struct {
+	Limit int
+	// Cursor is the NextCursor of the previous page
+	Cursor string
+	// Sort is next_billing, price or created_at
+	Sort string
+	// Order is asc or desc
+	Order     string
+	Status    string
+	ProductID string
+	Category  string
+	MinPrice  *float64
+	MaxPrice  *float64
+	// NextBillingFrom and NextBillingTo are inclusive dates
+	NextBillingFrom time.Time
+	NextBillingTo   time.Time
+}
+
+func (o ListOptions) query() url.Values {
+	query := url.Values{}
+	setInt(query, "limit", o.Limit)
+	for name, value := range map[string]string{
+		"cursor":     o.Cursor,
+		"sort":       o.Sort,
+		"order":      o.Order,
+		"status":     o.Status,
+		"product_id": o.ProductID,
+		"category":   o.Category,
+	} {
+		if value != "" {
+			query.Set(name, value)
+		}
+	}
+	if o.MinPrice != nil {
+		query.Set("min_price", strconv.FormatFloat(*o.MinPrice, 'f', -1, 64))
+	}
+	if o.MaxPrice != nil {
+		query.Set("max_price", strconv.FormatFloat(*o.MaxPrice, 'f', -1, 64))
+	}
+	setDate(query, "next_billing_from", o.NextBillingFrom)
+	setDate(query, "next_billing_to", o.NextBillingTo)
+	return query
+}
+
+// SubscriptionPage is a page of subscriptions
+type SubscriptionPage struct {
+	Subscriptions []Subscription
+	Pagination    Pagination
+}
+
+// ListSubscriptions returns a page of all subscriptions
+func (c *Client) ListSubscriptions(ctx context.Context, options ListOptions) (*SubscriptionPage, error) {
+	return c.listSubscriptions(ctx, "/api/v1/subscriptions", options)
+}
+
+// ListUserSubscriptions returns a page of the subscriptions of a user
+func (c *Client) ListUserSubscriptions(ctx context.Context, userID string, options ListOptions) (*SubscriptionPage, error) {
+	return c.listSubscriptions(ctx, userPath(userID, "subscriptions"), options)
+}
+
+// Subscriptions iterates over all subscriptions matching options, requesting
+// the following pages as needed. Iteration stops after the first error.
+func (c *Client) Subscriptions(ctx context.Context, options ListOptions) iter.Seq2[Subscription, error] {
+	return c.iterateSubscriptions(ctx, "/api/v1/subscriptions", options)
+}
+
+// UserSubscriptions iterates over the subscriptions of a user like Subscriptions
+func (c *Client) UserSubscriptions(ctx context.Context, userID string, options ListOptions) iter.Seq2[Subscription, error] {
+	return c.iterateSubscriptions(ctx, userPath(userID, "subscriptions"), options)
+}
+
+func (c *Client) listSubscriptions(ctx context.Context, path string, options ListOptions) (*SubscriptionPage, error) {
+	page := &SubscriptionPage{}
+	req := request{method: http.MethodGet, path: path, query: options.query()}
+	if err := c.call(ctx, req, &page.Subscriptions, &page.Pagination); err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+func (c *Client) iterateSubscriptions(ctx context.Context, path string, options ListOptions) iter.Seq2[Subscription, error] {
+	return func(yield func(Subscription, error) bool) {
+		for {
+			page, err := c.listSubscriptions(ctx, path, options)
+			if err != nil {
+				yield(Subscription{}, err)
+				return
+			}
+			for _, subscription := range page.Subscriptions {
+				if !yield(subscription, nil) {
+					return
+				}
+			}
+			if !page.Pagination.HasMore {
+				return
+			}
+			options.Cursor = page.Pagination.NextCursor
+		}
+	}
+}
+
+// ProjectedCharge is a charge expected on a date
+type ProjectedCharge struct {
+	Date           time.Time `json:"date"`
+	SubscriptionID string    `json:"subscription_id"`
+	ProductID      string    `json:"product_id"`
+	ProductName    string    `json:"product_name"`
+	Amount         float64   `json:"amount"`
+}
+
+// ForecastDay holds the charges expected on a day
+type ForecastDay struct {
+	Date    string            `json:"date"`
+	Total   float64           `json:"total"`
+	Charges []ProjectedCharge `json:"charges"`
+}
+
+// ForecastMonth sums the charges expected in a month
+type ForecastMonth struct {
+	Month   string  `json:"month"`
+	Total   float64 `json:"total"`
+	Charges int     `json:"charges"`
+}
+
+// SpendingForecast is the projected spending of a user
+type SpendingForecast struct {
+	UserID string          `json:"user_id"`
+	From   time.Time       `json:"from"`
+	To     time.Time       `json:"to"`
+	Total  float64         `json:"total"`
+	Days   []ForecastDay   `json:"days"`
+	Months []ForecastMonth `json:"months"`
+}
+
+// Forecast projects the charges of a user over months, 12 when zero
+func (c *Client) Forecast(ctx context.Context, userID string, months int) (*SpendingForecast, error) {
+	query := url.Values{}
+	setInt(query, "months", months)
+	var forecast SpendingForecast
+	if err := c.call(ctx, request{method: http.MethodGet, path: userPath(userID, "forecast"), query: query}, &forecast, nil); err != nil {
+		return nil, err
+	}
+	return &forecast, nil
+}
+
+// ImportRow is a subscription to import. Dates are YYYY-MM-DD.
+type ImportRow struct {
+	ProductName   string  `json:"product_name"`
+	Price         float64 `json:"price"`
+	BillingPeriod string  `json:"billing_period"`
+	StartDate     string  `json:"start_date"`
+	NextBilling   string  `json:"next_billing"`
+	Category      string  `json:"category,omitempty"`
+}
+
+// ImportRowError describes why a row cannot be imported. Rows are numbered
+// from 1, not counting a CSV header.
+type ImportRowError struct {
+	Row     int    `json:"row"`
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// CreatedSubscription is a subscription created by an import
+type CreatedSubscription struct {
+	ID           string     `json:"id"`
+	UserID       string     `json:"user_id"`
+	ProductID    string     `json:"product_id"`
+	Status       string     `json:"status"`
+	StartDate    time.Time  `json:"start_date"`
+	EndDate      *time.Time `json:"end_date,omitempty"`
+	NextBilling  time.Time  `json:"next_billing"`
+	PriceAtStart float64    `json:"price_at_start"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// ImportResult reports the outcome of an import. Nothing is written when
+// Errors is not empty.
+type ImportResult struct {
+	DryRun          bool                  `json:"dry_run"`
+	Total           int                   `json:"total"`
+	Imported        int                   `json:"imported"`
+	CreatedProducts []string              `json:"created_products"`
+	Errors          []ImportRowError      `json:"errors"`
+	Subscriptions   []CreatedSubscription `json:"subscriptions"`
+}
+
+// ImportSubscriptions imports rows as subscriptions of a user, or only
+// validates them with dryRun. A failed import still returns the result the
+// API reported: when rows are invalid, the error matches ErrUnprocessable and
+// the result lists them.
+func (c *Client) ImportSubscriptions(ctx context.Context, userID string, rows []ImportRow, dryRun bool) (*ImportResult, error) {
+	body, err := json.Marshal(rows)
+	if err != nil {
+		return nil, err
+	}
+	return c.ImportFile(ctx, userID, "json", body, dryRun)
+}
+
+// ImportFile imports a CSV or JSON file of subscriptions like ImportSubscriptions
+func (c *Client) ImportFile(ctx context.Context, userID, format string, file []byte, dryRun bool) (*ImportResult, error) {
+	query := url.Values{"format": {format}, "dry_run": {strconv.FormatBool(dryRun)}}
+	req := request{method: http.MethodPost, path: userPath(userID, "subscriptions/import"), query: query, body: file, contentType: contentType(format)}
+	var result ImportResult
+	err := c.call(ctx, req, &result, nil)
+	return &result, err
+}
+
+// RecurringCandidate is a recurring charge found in a bank statement
+type RecurringCandidate struct {
+	Merchant          string    `json:"merchant"`
+	Description       string    `json:"description"`
+	BillingPeriod     string    `json:"billing_period"`
+	Amount            float64   `json:"amount"`
+	Occurrences       int       `json:"occurrences"`
+	FirstSeen         time.Time `json:"first_seen"`
+	LastSeen          time.Time `json:"last_seen"`
+	NextBilling       time.Time `json:"next_billing"`
+	Confidence        float64   `json:"confidence"`
+	ProductID         string    `json:"product_id,omitempty"`
+	ProductName       string    `json:"product_name"`
+	Category          string    `json:"category,omitempty"`
+	AlreadySubscribed bool      `json:"already_subscribed"`
+}
+
+// ConfirmedCandidate is a candidate the user confirmed as a subscription
+type ConfirmedCandidate struct {
+	ProductName   string    `json:"product_name"`
+	Amount        float64   `json:"amount"`
+	BillingPeriod string    `json:"billing_period"`
+	FirstSeen     time.Time `json:"first_seen"`
+	NextBilling   time.Time `json:"next_billing"`
+	Category      string    `json:"category,omitempty"`
+}
+
+// AnalyzeStatement finds the recurring charges of a bank statement in the
+// ofx, qfx or csv format. Nothing is stored.
+func (c *Client) AnalyzeStatement(ctx context.Context, userID, format string, statement []byte) ([]RecurringCandidate, error) {
+	req := request{
+		method:      http.MethodPost,
+		path:        userPath(userID, "statements/analyze"),
+		query:       url.Values{"format": {format}},
+		body:        statement,
+		contentType: contentType(format),
+	}
+	var candidates []RecurringCandidate
+	if err := c.call(ctx, req, &candidates, nil); err != nil {
+		return nil, err
+	}
+	return candidates, nil
+}
+
+// ConfirmCandidates creates subscriptions from confirmed candidates. Like
+// ImportSubscriptions, a failed confirmation still returns the result.
+func (c *Client) ConfirmCandidates(ctx context.Context, userID string, candidates []ConfirmedCandidate) (*ImportResult, error) {
+	body, err := json.Marshal(candidates)
+	if err != nil {
+		return nil, err
+	}
+	req := request{method: http.MethodPost, path: userPath(userID, "statements/confirm"), body: body, contentType: "application/json"}
+	var result ImportResult
+	err = c.call(ctx, req, &result, nil)
+	return &result, err
+}
+
+// CalendarOptions selects the calendar feed of a user
+type CalendarOptions struct {
+	// Token is the calendar token of the user, from "cli calendar-url"
+	Token string
+	// AlarmDays raises alarms this many days before a charge, the configured
+	// value of the API when nil
+	AlarmDays *int
+}
+
+// Calendar returns the iCalendar feed of the upcoming billing dates of a user
+func (c *Client) Calendar(ctx context.Context, userID string, options CalendarOptions) ([]byte, error) {
+	query := url.Values{"token": {options.Token}}
+	if options.AlarmDays != nil {
+		query.Set("alarm_days", strconv.Itoa(*options.AlarmDays))
+	}
+	return c.download(ctx, request{method: http.MethodGet, path: userPath(userID, "calendar.ics"), query: query})
+}
+
+// contentType returns the content type of an uploaded file format
+func contentType(format string) string {
+	switch format {
+	case "json":
+		return "application/json"
+	case "csv":
+		return "text/csv"
+	default:
+		return "application/octet-stream"
+	}
+}
@@ -0,0 +1,27 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// User is a user of the API. Emails are not exposed until the API has
+// authentication.
+type User struct {
+	ID        string    `json:"id"`
+	Username  string    `json:"username"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ListUsers returns the users. The API does not serve them yet: the error
+// matches ErrNotImplemented until it does.
+func (c *Client) ListUsers(ctx context.Context) ([]User, error) {
+	var users []User
+	if err := c.call(ctx, request{method: http.MethodGet, path: "/api/v1/users"}, &users, nil); err != nil {
+		return nil, err
+	}
+	return users, nil
+}
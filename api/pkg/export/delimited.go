@@ -0,0 +1,65 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// delimitedWriter writes CSV or TSV with a header row
+type delimitedWriter struct {
+	writer *csv.Writer
+	record []string
+}
+
+func newDelimitedWriter(w io.Writer, comma rune, columns []string) (*delimitedWriter, error) {
+	writer := csv.NewWriter(w)
+	writer.Comma = comma
+	if err := writer.Write(columns); err != nil {
+		return nil, err
+	}
+	return &delimitedWriter{
+		writer: writer,
+		record: make([]string, len(columns)),
+	}, nil
+}
+
+func (d *delimitedWriter) WriteRow(values []any) error {
+	for i := range d.record {
+		d.record[i] = ""
+		if i < len(values) {
+			d.record[i] = formatValue(values[i])
+		}
+	}
+	return d.writer.Write(d.record)
+}
+
+func (d *delimitedWriter) Close() error {
+	d.writer.Flush()
+	return d.writer.Error()
+}
+
+// formatValue renders a value as text. Times are written in RFC 3339 and nil as empty.
+func formatValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case time.Time:
+		return v.UTC().Format(time.RFC3339)
+	case *time.Time:
+		if v == nil {
+			return ""
+		}
+		return v.UTC().Format(time.RFC3339)
+	case fmt.Stringer:
+		return v.String()
+	default:
+		return fmt.Sprint(v)
+	}
+}
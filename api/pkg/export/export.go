@@ -0,0 +1,66 @@
+// Package export writes tabular data as CSV, TSV, NDJSON or ODS spreadsheets,
+// one row at a time, so large exports can be streamed without buffering.
+package export
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Supported export formats
+const (
+	FormatCSV    = "csv"
+	FormatTSV    = "tsv"
+	FormatNDJSON = "ndjson"
+	FormatODS    = "ods"
+)
+
+// Writer writes rows of values matching the columns it was created with.
+// Close must be called once all rows are written to flush the output.
+type Writer interface {
+	WriteRow(values []any) error
+	Close() error
+}
+
+// NewWriter creates a writer for the given format
+func NewWriter(w io.Writer, format string, columns []string) (Writer, error) {
+	switch strings.ToLower(format) {
+	case FormatCSV:
+		return newDelimitedWriter(w, ',', columns)
+	case FormatTSV:
+		return newDelimitedWriter(w, '\t', columns)
+	case FormatNDJSON:
+		return newNDJSONWriter(w, columns), nil
+	case FormatODS:
+		return newODSWriter(w, columns)
+	default:
+		return nil, fmt.Errorf("unsupported export format %q", format)
+	}
+}
+
+// ContentType returns the MIME type of the given format
+func ContentType(format string) string {
+	switch strings.ToLower(format) {
+	case FormatCSV:
+		return "text/csv"
+	case FormatTSV:
+		return "text/tab-separated-values"
+	case FormatNDJSON:
+		return "application/x-ndjson"
+	case FormatODS:
+		return odsMimeType
+	default:
+		return "application/octet-stream"
+	}
+}
+
+// IsSupported returns true if the format can be exported
+func IsSupported(format string) bool {
+	switch strings.ToLower(format) {
+	case FormatCSV, FormatTSV, FormatNDJSON, FormatODS:
+		return true
+	default:
+		return false
+	}
+}
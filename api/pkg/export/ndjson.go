@@ -0,0 +1,70 @@
+package export
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// ndjsonWriter writes one JSON object per line, keeping the column order
+type ndjsonWriter struct {
+	writer  *bufio.Writer
+	columns [][]byte
+}
+
+func newNDJSONWriter(w io.Writer, columns []string) *ndjsonWriter {
+	keys := make([][]byte, len(columns))
+	for i, column := range columns {
+		keys[i], _ = json.Marshal(column)
+	}
+	return &ndjsonWriter{
+		writer:  bufio.NewWriter(w),
+		columns: keys,
+	}
+}
+
+func (n *ndjsonWriter) WriteRow(values []any) error {
+	n.writer.WriteByte('{')
+	for i, key := range n.columns {
+		if i > 0 {
+			n.writer.WriteByte(',')
+		}
+		n.writer.Write(key)
+		n.writer.WriteByte(':')
+
+		var value any
+		if i < len(values) {
+			value = jsonValue(values[i])
+		}
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		n.writer.Write(encoded)
+	}
+	n.writer.WriteByte('}')
+	return n.writer.WriteByte('\n')
+}
+
+func (n *ndjsonWriter) Close() error {
+	return n.writer.Flush()
+}
+
+// jsonValue converts values that have no natural JSON encoding
+func jsonValue(value any) any {
+	switch v := value.(type) {
+	case time.Time:
+		return v.UTC().Format(time.RFC3339)
+	case *time.Time:
+		if v == nil {
+			return nil
+		}
+		return v.UTC().Format(time.RFC3339)
+	case fmt.Stringer:
+		return v.String()
+	default:
+		return v
+	}
+}
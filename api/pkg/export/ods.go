@@ -0,0 +1,119 @@
+package export
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"io"
+	"strconv"
+	"time"
+)
+
+const odsMimeType = "application/vnd.oasis.opendocument.spreadsheet"
+
+const odsManifest = `<?xml version="1.0" encoding="UTF-8"?>
+<manifest:manifest xmlns:manifest="urn:oasis:names:tc:opendocument:xmlns:manifest:1.0" manifest:version="1.2">
+ <manifest:file-entry manifest:full-path="/" manifest:media-type="` + odsMimeType + `"/>
+ <manifest:file-entry manifest:full-path="content.xml" manifest:media-type="text/xml"/>
+</manifest:manifest>
+`
+
+const odsContentHeader = `<?xml version="1.0" encoding="UTF-8"?>
+<office:document-content xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0" xmlns:table="urn:oasis:names:tc:opendocument:xmlns:table:1.0" xmlns:text="urn:oasis:names:tc:opendocument:xmlns:text:1.0" office:version="1.2">
+<office:body><office:spreadsheet><table:table table:name="Export">
+`
+
+const odsContentFooter = `</table:table></office:spreadsheet></office:body></office:document-content>
+`
+
+// odsWriter writes an OpenDocument spreadsheet. The archive entries are written
+// in order and content.xml is streamed row by row.
+type odsWriter struct {
+	archive *zip.Writer
+	content *bufio.Writer
+}
+
+func newODSWriter(w io.Writer, columns []string) (*odsWriter, error) {
+	archive := zip.NewWriter(w)
+
+	// The mimetype entry must come first and be stored uncompressed
+	mimetype, err := archive.CreateHeader(&zip.FileHeader{Name: "mimetype", Method: zip.Store})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.WriteString(mimetype, odsMimeType); err != nil {
+		return nil, err
+	}
+
+	manifest, err := archive.Create("META-INF/manifest.xml")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.WriteString(manifest, odsManifest); err != nil {
+		return nil, err
+	}
+
+	content, err := archive.Create("content.xml")
+	if err != nil {
+		return nil, err
+	}
+
+	o := &odsWriter{
+		archive: archive,
+		content: bufio.NewWriter(content),
+	}
+	o.content.WriteString(odsContentHeader)
+
+	header := make([]any, len(columns))
+	for i, column := range columns {
+		header[i] = column
+	}
+	if err := o.WriteRow(header); err != nil {
+		return nil, err
+	}
+
+	return o, nil
+}
+
+func (o *odsWriter) WriteRow(values []any) error {
+	o.content.WriteString("<table:table-row>")
+	for _, value := range values {
+		switch v := value.(type) {
+		case float64:
+			number := strconv.FormatFloat(v, 'f', -1, 64)
+			o.content.WriteString(`<table:table-cell office:value-type="float" office:value="` + number + `"><text:p>` + number + `</text:p></table:table-cell>`)
+		case int:
+			number := strconv.Itoa(v)
+			o.content.WriteString(`<table:table-cell office:value-type="float" office:value="` + number + `"><text:p>` + number + `</text:p></table:table-cell>`)
+		case time.Time:
+			o.writeDate(v)
+		case *time.Time:
+			if v == nil {
+				o.content.WriteString("<table:table-cell/>")
+				continue
+			}
+			o.writeDate(*v)
+		default:
+			o.content.WriteString(`<table:table-cell office:value-type="string"><text:p>`)
+			if err := xml.EscapeText(o.content, []byte(formatValue(v))); err != nil {
+				return err
+			}
+			o.content.WriteString(`</text:p></table:table-cell>`)
+		}
+	}
+	_, err := o.content.WriteString("</table:table-row>\n")
+	return err
+}
+
+func (o *odsWriter) writeDate(t time.Time) {
+	value := t.UTC().Format("2006-01-02T15:04:05")
+	o.content.WriteString(`<table:table-cell office:value-type="date" office:date-value="` + value + `"><text:p>` + value + `</text:p></table:table-cell>`)
+}
+
+func (o *odsWriter) Close() error {
+	o.content.WriteString(odsContentFooter)
+	if err := o.content.Flush(); err != nil {
+		return err
+	}
+	return o.archive.Close()
+}
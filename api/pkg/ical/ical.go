@@ -0,0 +1,158 @@
+// Package ical writes iCalendar (RFC 5545) feeds.
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Event statuses
+const (
+	StatusConfirmed = "CONFIRMED"
+	StatusTentative = "TENTATIVE"
+	StatusCancelled = "CANCELLED"
+)
+
+// maxLineOctets is the maximum length of a content line before folding
+const maxLineOctets = 75
+
+// Calendar is a VCALENDAR object
+type Calendar struct {
+	ProdID string
+	Name   string
+	Events []Event
+}
+
+// Event is an all-day VEVENT, optionally recurring and with a display alarm
+type Event struct {
+	UID         string
+	Summary     string
+	Description string
+	Date        time.Time
+	// RRule is the recurrence rule value, e.g. "FREQ=MONTHLY"
+	RRule string
+	// RDates are occurrences added to the one on Date, for dates no rule
+	// describes
+	RDates []time.Time
+	Status string
+	// AlarmDays triggers a display alarm the given number of days before the
+	// event. Zero disables the alarm.
+	AlarmDays int
+	Stamp     time.Time
+}
+
+// Write writes the calendar to w using CRLF line endings and line folding
+func (c *Calendar) Write(w io.Writer) error {
+	out := &lineWriter{w: bufio.NewWriter(w)}
+
+	out.line("BEGIN:VCALENDAR")
+	out.line("VERSION:2.0")
+	out.line("PRODID:" + c.ProdID)
+	out.line("CALSCALE:GREGORIAN")
+	out.line("METHOD:PUBLISH")
+	if c.Name != "" {
+		out.line("X-WR-CALNAME:" + Escape(c.Name))
+	}
+
+	for _, event := range c.Events {
+		event.write(out)
+	}
+
+	out.line("END:VCALENDAR")
+	if out.err != nil {
+		return out.err
+	}
+	return out.w.Flush()
+}
+
+func (e *Event) write(out *lineWriter) {
+	stamp := e.Stamp
+	if stamp.IsZero() {
+		stamp = time.Now()
+	}
+
+	out.line("BEGIN:VEVENT")
+	out.line("UID:" + e.UID)
+	out.line("DTSTAMP:" + stamp.UTC().Format("20060102T150405Z"))
+	out.line("DTSTART;VALUE=DATE:" + FormatDate(e.Date))
+	out.line("DTEND;VALUE=DATE:" + FormatDate(e.Date.AddDate(0, 0, 1)))
+	if e.RRule != "" {
+		out.line("RRULE:" + e.RRule)
+	}
+	if len(e.RDates) > 0 {
+		dates := make([]string, len(e.RDates))
+		for i, date := range e.RDates {
+			dates[i] = FormatDate(date)
+		}
+		out.line("RDATE;VALUE=DATE:" + strings.Join(dates, ","))
+	}
+	out.line("SUMMARY:" + Escape(e.Summary))
+	if e.Description != "" {
+		out.line("DESCRIPTION:" + Escape(e.Description))
+	}
+	if e.Status != "" {
+		out.line("STATUS:" + e.Status)
+	}
+	out.line("TRANSP:TRANSPARENT")
+	if e.AlarmDays > 0 {
+		out.line("BEGIN:VALARM")
+		out.line("ACTION:DISPLAY")
+		out.line(fmt.Sprintf("TRIGGER:-P%dD", e.AlarmDays))
+		out.line("DESCRIPTION:" + Escape(e.Summary))
+		out.line("END:VALARM")
+	}
+	out.line("END:VEVENT")
+}
+
+// FormatDate formats a DATE value
+func FormatDate(t time.Time) string {
+	return t.Format("20060102")
+}
+
+// Escape escapes a TEXT value
+func Escape(value string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(value)
+}
+
+// lineWriter writes folded content lines and keeps the first error
+type lineWriter struct {
+	w   *bufio.Writer
+	err error
+}
+
+// line writes a content line, folding it so no physical line exceeds 75 octets
+// without splitting a multi-byte character
+func (l *lineWriter) line(content string) {
+	if l.err != nil {
+		return
+	}
+
+	limit := maxLineOctets
+	for len(content) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(content[cut]) {
+			cut--
+		}
+		l.write(content[:cut] + "\r\n ")
+		content = content[cut:]
+		// Continuation lines start with a space
+		limit = maxLineOctets - 1
+	}
+	l.write(content + "\r\n")
+}
+
+func (l *lineWriter) write(s string) {
+	if l.err == nil {
+		_, l.err = l.w.WriteString(s)
+	}
+}
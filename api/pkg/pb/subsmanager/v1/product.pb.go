@@ -0,0 +1,625 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: subsmanager/v1/product.proto
+
+package subsmanagerv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ProductStatus int32
+
+const (
+	ProductStatus_PRODUCT_STATUS_UNSPECIFIED ProductStatus = 0
+	ProductStatus_PRODUCT_STATUS_ACTIVE      ProductStatus = 1
+	ProductStatus_PRODUCT_STATUS_INACTIVE    ProductStatus = 2
+)
+
+// Enum value maps for ProductStatus.
+var (
+	ProductStatus_name = map[int32]string{
+		0: "PRODUCT_STATUS_UNSPECIFIED",
+		1: "PRODUCT_STATUS_ACTIVE",
+		2: "PRODUCT_STATUS_INACTIVE",
+	}
+	ProductStatus_value = map[string]int32{
+		"PRODUCT_STATUS_UNSPECIFIED": 0,
+		"PRODUCT_STATUS_ACTIVE":      1,
+		"PRODUCT_STATUS_INACTIVE":    2,
+	}
+)
+
+func (x ProductStatus) Enum() *ProductStatus {
+	p := new(ProductStatus)
+	*p = x
+	return p
+}
+
+func (x ProductStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ProductStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_subsmanager_v1_product_proto_enumTypes[0].Descriptor()
+}
+
+func (ProductStatus) Type() protoreflect.EnumType {
+	return &file_subsmanager_v1_product_proto_enumTypes[0]
+}
+
+func (x ProductStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ProductStatus.Descriptor instead.
+func (ProductStatus) EnumDescriptor() ([]byte, []int) {
+	return file_subsmanager_v1_product_proto_rawDescGZIP(), []int{0}
+}
+
+type BillingType int32
+
+const (
+	BillingType_BILLING_TYPE_UNSPECIFIED BillingType = 0
+	BillingType_BILLING_TYPE_WEEKLY      BillingType = 1
+	BillingType_BILLING_TYPE_MONTHLY     BillingType = 2
+	BillingType_BILLING_TYPE_YEARLY      BillingType = 3
+)
+
+// Enum value maps for BillingType.
+var (
+	BillingType_name = map[int32]string{
+		0: "BILLING_TYPE_UNSPECIFIED",
+		1: "BILLING_TYPE_WEEKLY",
+		2: "BILLING_TYPE_MONTHLY",
+		3: "BILLING_TYPE_YEARLY",
+	}
+	BillingType_value = map[string]int32{
+		"BILLING_TYPE_UNSPECIFIED": 0,
+		"BILLING_TYPE_WEEKLY":      1,
+		"BILLING_TYPE_MONTHLY":     2,
+		"BILLING_TYPE_YEARLY":      3,
+	}
+)
+
+func (x BillingType) Enum() *BillingType {
+	p := new(BillingType)
+	*p = x
+	return p
+}
+
+func (x BillingType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BillingType) Descriptor() protoreflect.EnumDescriptor {
+	return file_subsmanager_v1_product_proto_enumTypes[1].Descriptor()
+}
+
+func (BillingType) Type() protoreflect.EnumType {
+	return &file_subsmanager_v1_product_proto_enumTypes[1]
+}
+
+func (x BillingType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BillingType.Descriptor instead.
+func (BillingType) EnumDescriptor() ([]byte, []int) {
+	return file_subsmanager_v1_product_proto_rawDescGZIP(), []int{1}
+}
+
+type Product struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Price         float64                `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`
+	BillingType   BillingType            `protobuf:"varint,5,opt,name=billing_type,json=billingType,proto3,enum=subsmanager.v1.BillingType" json:"billing_type,omitempty"`
+	Category      string                 `protobuf:"bytes,6,opt,name=category,proto3" json:"category,omitempty"`
+	Status        ProductStatus          `protobuf:"varint,7,opt,name=status,proto3,enum=subsmanager.v1.ProductStatus" json:"status,omitempty"`
+	CreateTime    *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	UpdateTime    *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Product) Reset() {
+	*x = Product{}
+	mi := &file_subsmanager_v1_product_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Product) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Product) ProtoMessage() {}
+
+func (x *Product) ProtoReflect() protoreflect.Message {
+	mi := &file_subsmanager_v1_product_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Product.ProtoReflect.Descriptor instead.
+func (*Product) Descriptor() ([]byte, []int) {
+	return file_subsmanager_v1_product_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Product) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Product) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Product) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Product) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *Product) GetBillingType() BillingType {
+	if x != nil {
+		return x.BillingType
+	}
+	return BillingType_BILLING_TYPE_UNSPECIFIED
+}
+
+func (x *Product) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *Product) GetStatus() ProductStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ProductStatus_PRODUCT_STATUS_UNSPECIFIED
+}
+
+func (x *Product) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+func (x *Product) GetUpdateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdateTime
+	}
+	return nil
+}
+
+type GetProductRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductRequest) Reset() {
+	*x = GetProductRequest{}
+	mi := &file_subsmanager_v1_product_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductRequest) ProtoMessage() {}
+
+func (x *GetProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_subsmanager_v1_product_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductRequest.ProtoReflect.Descriptor instead.
+func (*GetProductRequest) Descriptor() ([]byte, []int) {
+	return file_subsmanager_v1_product_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetProductRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetProductResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductResponse) Reset() {
+	*x = GetProductResponse{}
+	mi := &file_subsmanager_v1_product_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductResponse) ProtoMessage() {}
+
+func (x *GetProductResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_subsmanager_v1_product_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductResponse.ProtoReflect.Descriptor instead.
+func (*GetProductResponse) Descriptor() ([]byte, []int) {
+	return file_subsmanager_v1_product_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetProductResponse) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+type BatchGetProductsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// At most 500 IDs.
+	Ids           []string `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetProductsRequest) Reset() {
+	*x = BatchGetProductsRequest{}
+	mi := &file_subsmanager_v1_product_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetProductsRequest) ProtoMessage() {}
+
+func (x *BatchGetProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_subsmanager_v1_product_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetProductsRequest.ProtoReflect.Descriptor instead.
+func (*BatchGetProductsRequest) Descriptor() ([]byte, []int) {
+	return file_subsmanager_v1_product_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *BatchGetProductsRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type BatchGetProductsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Products      []*Product             `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetProductsResponse) Reset() {
+	*x = BatchGetProductsResponse{}
+	mi := &file_subsmanager_v1_product_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetProductsResponse) ProtoMessage() {}
+
+func (x *BatchGetProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_subsmanager_v1_product_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetProductsResponse.ProtoReflect.Descriptor instead.
+func (*BatchGetProductsResponse) Descriptor() ([]byte, []int) {
+	return file_subsmanager_v1_product_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *BatchGetProductsResponse) GetProducts() []*Product {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+type ListProductsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only products of this category when set.
+	Category string `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
+	// Only active products.
+	ActiveOnly    bool `protobuf:"varint,2,opt,name=active_only,json=activeOnly,proto3" json:"active_only,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProductsRequest) Reset() {
+	*x = ListProductsRequest{}
+	mi := &file_subsmanager_v1_product_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProductsRequest) ProtoMessage() {}
+
+func (x *ListProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_subsmanager_v1_product_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProductsRequest.ProtoReflect.Descriptor instead.
+func (*ListProductsRequest) Descriptor() ([]byte, []int) {
+	return file_subsmanager_v1_product_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListProductsRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *ListProductsRequest) GetActiveOnly() bool {
+	if x != nil {
+		return x.ActiveOnly
+	}
+	return false
+}
+
+type ListProductsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Products      []*Product             `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProductsResponse) Reset() {
+	*x = ListProductsResponse{}
+	mi := &file_subsmanager_v1_product_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProductsResponse) ProtoMessage() {}
+
+func (x *ListProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_subsmanager_v1_product_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProductsResponse.ProtoReflect.Descriptor instead.
+func (*ListProductsResponse) Descriptor() ([]byte, []int) {
+	return file_subsmanager_v1_product_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListProductsResponse) GetProducts() []*Product {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+var File_subsmanager_v1_product_proto protoreflect.FileDescriptor
+
+const file_subsmanager_v1_product_proto_rawDesc = "" +
+	"\n" +
+	"\x1csubsmanager/v1/product.proto\x12\x0esubsmanager.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xf2\x02\n" +
+	"\aProduct\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x14\n" +
+	"\x05price\x18\x04 \x01(\x01R\x05price\x12>\n" +
+	"\fbilling_type\x18\x05 \x01(\x0e2\x1b.subsmanager.v1.BillingTypeR\vbillingType\x12\x1a\n" +
+	"\bcategory\x18\x06 \x01(\tR\bcategory\x125\n" +
+	"\x06status\x18\a \x01(\x0e2\x1d.subsmanager.v1.ProductStatusR\x06status\x12;\n" +
+	"\vcreate_time\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"createTime\x12;\n" +
+	"\vupdate_time\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"updateTime\"#\n" +
+	"\x11GetProductRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"G\n" +
+	"\x12GetProductResponse\x121\n" +
+	"\aproduct\x18\x01 \x01(\v2\x17.subsmanager.v1.ProductR\aproduct\"+\n" +
+	"\x17BatchGetProductsRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\tR\x03ids\"O\n" +
+	"\x18BatchGetProductsResponse\x123\n" +
+	"\bproducts\x18\x01 \x03(\v2\x17.subsmanager.v1.ProductR\bproducts\"R\n" +
+	"\x13ListProductsRequest\x12\x1a\n" +
+	"\bcategory\x18\x01 \x01(\tR\bcategory\x12\x1f\n" +
+	"\vactive_only\x18\x02 \x01(\bR\n" +
+	"activeOnly\"K\n" +
+	"\x14ListProductsResponse\x123\n" +
+	"\bproducts\x18\x01 \x03(\v2\x17.subsmanager.v1.ProductR\bproducts*g\n" +
+	"\rProductStatus\x12\x1e\n" +
+	"\x1aPRODUCT_STATUS_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15PRODUCT_STATUS_ACTIVE\x10\x01\x12\x1b\n" +
+	"\x17PRODUCT_STATUS_INACTIVE\x10\x02*w\n" +
+	"\vBillingType\x12\x1c\n" +
+	"\x18BILLING_TYPE_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13BILLING_TYPE_WEEKLY\x10\x01\x12\x18\n" +
+	"\x14BILLING_TYPE_MONTHLY\x10\x02\x12\x17\n" +
+	"\x13BILLING_TYPE_YEARLY\x10\x032\xa7\x02\n" +
+	"\x0eProductService\x12S\n" +
+	"\n" +
+	"GetProduct\x12!.subsmanager.v1.GetProductRequest\x1a\".subsmanager.v1.GetProductResponse\x12e\n" +
+	"\x10BatchGetProducts\x12'.subsmanager.v1.BatchGetProductsRequest\x1a(.subsmanager.v1.BatchGetProductsResponse\x12Y\n" +
+	"\fListProducts\x12#.subsmanager.v1.ListProductsRequest\x1a$.subsmanager.v1.ListProductsResponseBHZFgithub.com/frtasoniero/subsmanager/pkg/pb/subsmanager/v1;subsmanagerv1b\x06proto3"
+
+var (
+	file_subsmanager_v1_product_proto_rawDescOnce sync.Once
+	file_subsmanager_v1_product_proto_rawDescData []byte
+)
+
+func file_subsmanager_v1_product_proto_rawDescGZIP() []byte {
+	file_subsmanager_v1_product_proto_rawDescOnce.Do(func() {
+		file_subsmanager_v1_product_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_subsmanager_v1_product_proto_rawDesc), len(file_subsmanager_v1_product_proto_rawDesc)))
+	})
+	return file_subsmanager_v1_product_proto_rawDescData
+}
+
+var file_subsmanager_v1_product_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_subsmanager_v1_product_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_subsmanager_v1_product_proto_goTypes = []any{
+	(ProductStatus)(0),               // 0: subsmanager.v1.ProductStatus
+	(BillingType)(0),                 // 1: subsmanager.v1.BillingType
+	(*Product)(nil),                  // 2: subsmanager.v1.Product
+	(*GetProductRequest)(nil),        // 3: subsmanager.v1.GetProductRequest
+	(*GetProductResponse)(nil),       // 4: subsmanager.v1.GetProductResponse
+	(*BatchGetProductsRequest)(nil),  // 5: subsmanager.v1.BatchGetProductsRequest
+	(*BatchGetProductsResponse)(nil), // 6: subsmanager.v1.BatchGetProductsResponse
+	(*ListProductsRequest)(nil),      // 7: subsmanager.v1.ListProductsRequest
+	(*ListProductsResponse)(nil),     // 8: subsmanager.v1.ListProductsResponse
+	(*timestamppb.Timestamp)(nil),    // 9: google.protobuf.Timestamp
+}
+var file_subsmanager_v1_product_proto_depIdxs = []int32{
+	1,  // 0: subsmanager.v1.Product.billing_type:type_name -> subsmanager.v1.BillingType
+	0,  // 1: subsmanager.v1.Product.status:type_name -> subsmanager.v1.ProductStatus
+	9,  // 2: subsmanager.v1.Product.create_time:type_name -> google.protobuf.Timestamp
+	9,  // 3: subsmanager.v1.Product.update_time:type_name -> google.protobuf.Timestamp
+	2,  // 4: subsmanager.v1.GetProductResponse.product:type_name -> subsmanager.v1.Product
+	2,  // 5: subsmanager.v1.BatchGetProductsResponse.products:type_name -> subsmanager.v1.Product
+	2,  // 6: subsmanager.v1.ListProductsResponse.products:type_name -> subsmanager.v1.Product
+	3,  // 7: subsmanager.v1.ProductService.GetProduct:input_type -> subsmanager.v1.GetProductRequest
+	5,  // 8: subsmanager.v1.ProductService.BatchGetProducts:input_type -> subsmanager.v1.BatchGetProductsRequest
+	7,  // 9: subsmanager.v1.ProductService.ListProducts:input_type -> subsmanager.v1.ListProductsRequest
+	4,  // 10: subsmanager.v1.ProductService.GetProduct:output_type -> subsmanager.v1.GetProductResponse
+	6,  // 11: subsmanager.v1.ProductService.BatchGetProducts:output_type -> subsmanager.v1.BatchGetProductsResponse
+	8,  // 12: subsmanager.v1.ProductService.ListProducts:output_type -> subsmanager.v1.ListProductsResponse
+	10, // [10:13] is the sub-list for method output_type
+	7,  // [7:10] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_subsmanager_v1_product_proto_init() }
+func file_subsmanager_v1_product_proto_init() {
+	if File_subsmanager_v1_product_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_subsmanager_v1_product_proto_rawDesc), len(file_subsmanager_v1_product_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_subsmanager_v1_product_proto_goTypes,
+		DependencyIndexes: file_subsmanager_v1_product_proto_depIdxs,
+		EnumInfos:         file_subsmanager_v1_product_proto_enumTypes,
+		MessageInfos:      file_subsmanager_v1_product_proto_msgTypes,
+	}.Build()
+	File_subsmanager_v1_product_proto = out.File
+	file_subsmanager_v1_product_proto_goTypes = nil
+	file_subsmanager_v1_product_proto_depIdxs = nil
+}
@@ -0,0 +1,1128 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: subsmanager/v1/subscription.proto
+
+package subsmanagerv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type SubscriptionStatus int32
+
+const (
+	SubscriptionStatus_SUBSCRIPTION_STATUS_UNSPECIFIED SubscriptionStatus = 0
+	SubscriptionStatus_SUBSCRIPTION_STATUS_ACTIVE      SubscriptionStatus = 1
+	SubscriptionStatus_SUBSCRIPTION_STATUS_PAUSED      SubscriptionStatus = 2
+	SubscriptionStatus_SUBSCRIPTION_STATUS_CANCELLED   SubscriptionStatus = 3
+	SubscriptionStatus_SUBSCRIPTION_STATUS_EXPIRED     SubscriptionStatus = 4
+)
+
+// Enum value maps for SubscriptionStatus.
+var (
+	SubscriptionStatus_name = map[int32]string{
+		0: "SUBSCRIPTION_STATUS_UNSPECIFIED",
+		1: "SUBSCRIPTION_STATUS_ACTIVE",
+		2: "SUBSCRIPTION_STATUS_PAUSED",
+		3: "SUBSCRIPTION_STATUS_CANCELLED",
+		4: "SUBSCRIPTION_STATUS_EXPIRED",
+	}
+	SubscriptionStatus_value = map[string]int32{
+		"SUBSCRIPTION_STATUS_UNSPECIFIED": 0,
+		"SUBSCRIPTION_STATUS_ACTIVE":      1,
+		"SUBSCRIPTION_STATUS_PAUSED":      2,
+		"SUBSCRIPTION_STATUS_CANCELLED":   3,
+		"SUBSCRIPTION_STATUS_EXPIRED":     4,
+	}
+)
+
+func (x SubscriptionStatus) Enum() *SubscriptionStatus {
+	p := new(SubscriptionStatus)
+	*p = x
+	return p
+}
+
+func (x SubscriptionStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SubscriptionStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_subsmanager_v1_subscription_proto_enumTypes[0].Descriptor()
+}
+
+func (SubscriptionStatus) Type() protoreflect.EnumType {
+	return &file_subsmanager_v1_subscription_proto_enumTypes[0]
+}
+
+func (x SubscriptionStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SubscriptionStatus.Descriptor instead.
+func (SubscriptionStatus) EnumDescriptor() ([]byte, []int) {
+	return file_subsmanager_v1_subscription_proto_rawDescGZIP(), []int{0}
+}
+
+type SubscriptionSort int32
+
+const (
+	// Sorts by creation time.
+	SubscriptionSort_SUBSCRIPTION_SORT_UNSPECIFIED  SubscriptionSort = 0
+	SubscriptionSort_SUBSCRIPTION_SORT_NEXT_BILLING SubscriptionSort = 1
+	// Sorts by the current product price.
+	SubscriptionSort_SUBSCRIPTION_SORT_PRICE       SubscriptionSort = 2
+	SubscriptionSort_SUBSCRIPTION_SORT_CREATE_TIME SubscriptionSort = 3
+)
+
+// Enum value maps for SubscriptionSort.
+var (
+	SubscriptionSort_name = map[int32]string{
+		0: "SUBSCRIPTION_SORT_UNSPECIFIED",
+		1: "SUBSCRIPTION_SORT_NEXT_BILLING",
+		2: "SUBSCRIPTION_SORT_PRICE",
+		3: "SUBSCRIPTION_SORT_CREATE_TIME",
+	}
+	SubscriptionSort_value = map[string]int32{
+		"SUBSCRIPTION_SORT_UNSPECIFIED":  0,
+		"SUBSCRIPTION_SORT_NEXT_BILLING": 1,
+		"SUBSCRIPTION_SORT_PRICE":        2,
+		"SUBSCRIPTION_SORT_CREATE_TIME":  3,
+	}
+)
+
+func (x SubscriptionSort) Enum() *SubscriptionSort {
+	p := new(SubscriptionSort)
+	*p = x
+	return p
+}
+
+func (x SubscriptionSort) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SubscriptionSort) Descriptor() protoreflect.EnumDescriptor {
+	return file_subsmanager_v1_subscription_proto_enumTypes[1].Descriptor()
+}
+
+func (SubscriptionSort) Type() protoreflect.EnumType {
+	return &file_subsmanager_v1_subscription_proto_enumTypes[1]
+}
+
+func (x SubscriptionSort) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SubscriptionSort.Descriptor instead.
+func (SubscriptionSort) EnumDescriptor() ([]byte, []int) {
+	return file_subsmanager_v1_subscription_proto_rawDescGZIP(), []int{1}
+}
+
+type SubscriptionEventType int32
+
+const (
+	SubscriptionEventType_SUBSCRIPTION_EVENT_TYPE_UNSPECIFIED SubscriptionEventType = 0
+	SubscriptionEventType_SUBSCRIPTION_EVENT_TYPE_CREATED     SubscriptionEventType = 1
+	// Any change, including cancelling and pausing.
+	SubscriptionEventType_SUBSCRIPTION_EVENT_TYPE_UPDATED SubscriptionEventType = 2
+	SubscriptionEventType_SUBSCRIPTION_EVENT_TYPE_DELETED SubscriptionEventType = 3
+)
+
+// Enum value maps for SubscriptionEventType.
+var (
+	SubscriptionEventType_name = map[int32]string{
+		0: "SUBSCRIPTION_EVENT_TYPE_UNSPECIFIED",
+		1: "SUBSCRIPTION_EVENT_TYPE_CREATED",
+		2: "SUBSCRIPTION_EVENT_TYPE_UPDATED",
+		3: "SUBSCRIPTION_EVENT_TYPE_DELETED",
+	}
+	SubscriptionEventType_value = map[string]int32{
+		"SUBSCRIPTION_EVENT_TYPE_UNSPECIFIED": 0,
+		"SUBSCRIPTION_EVENT_TYPE_CREATED":     1,
+		"SUBSCRIPTION_EVENT_TYPE_UPDATED":     2,
+		"SUBSCRIPTION_EVENT_TYPE_DELETED":     3,
+	}
+)
+
+func (x SubscriptionEventType) Enum() *SubscriptionEventType {
+	p := new(SubscriptionEventType)
+	*p = x
+	return p
+}
+
+func (x SubscriptionEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SubscriptionEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_subsmanager_v1_subscription_proto_enumTypes[2].Descriptor()
+}
+
+func (SubscriptionEventType) Type() protoreflect.EnumType {
+	return &file_subsmanager_v1_subscription_proto_enumTypes[2]
+}
+
+func (x SubscriptionEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SubscriptionEventType.Descriptor instead.
+func (SubscriptionEventType) EnumDescriptor() ([]byte, []int) {
+	return file_subsmanager_v1_subscription_proto_rawDescGZIP(), []int{2}
+}
+
+type Subscription struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId    string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ProductId string                 `protobuf:"bytes,3,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Status    SubscriptionStatus     `protobuf:"varint,4,opt,name=status,proto3,enum=subsmanager.v1.SubscriptionStatus" json:"status,omitempty"`
+	StartDate *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	// Unset while the subscription has not ended.
+	EndDate       *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	NextBilling   *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=next_billing,json=nextBilling,proto3" json:"next_billing,omitempty"`
+	PriceAtStart  float64                `protobuf:"fixed64,8,opt,name=price_at_start,json=priceAtStart,proto3" json:"price_at_start,omitempty"`
+	CreateTime    *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Subscription) Reset() {
+	*x = Subscription{}
+	mi := &file_subsmanager_v1_subscription_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Subscription) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Subscription) ProtoMessage() {}
+
+func (x *Subscription) ProtoReflect() protoreflect.Message {
+	mi := &file_subsmanager_v1_subscription_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Subscription.ProtoReflect.Descriptor instead.
+func (*Subscription) Descriptor() ([]byte, []int) {
+	return file_subsmanager_v1_subscription_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Subscription) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Subscription) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Subscription) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *Subscription) GetStatus() SubscriptionStatus {
+	if x != nil {
+		return x.Status
+	}
+	return SubscriptionStatus_SUBSCRIPTION_STATUS_UNSPECIFIED
+}
+
+func (x *Subscription) GetStartDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartDate
+	}
+	return nil
+}
+
+func (x *Subscription) GetEndDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndDate
+	}
+	return nil
+}
+
+func (x *Subscription) GetNextBilling() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NextBilling
+	}
+	return nil
+}
+
+func (x *Subscription) GetPriceAtStart() float64 {
+	if x != nil {
+		return x.PriceAtStart
+	}
+	return 0
+}
+
+func (x *Subscription) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+type GetSubscriptionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSubscriptionRequest) Reset() {
+	*x = GetSubscriptionRequest{}
+	mi := &file_subsmanager_v1_subscription_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSubscriptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSubscriptionRequest) ProtoMessage() {}
+
+func (x *GetSubscriptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_subsmanager_v1_subscription_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSubscriptionRequest.ProtoReflect.Descriptor instead.
+func (*GetSubscriptionRequest) Descriptor() ([]byte, []int) {
+	return file_subsmanager_v1_subscription_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetSubscriptionRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetSubscriptionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subscription  *Subscription          `protobuf:"bytes,1,opt,name=subscription,proto3" json:"subscription,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSubscriptionResponse) Reset() {
+	*x = GetSubscriptionResponse{}
+	mi := &file_subsmanager_v1_subscription_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSubscriptionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSubscriptionResponse) ProtoMessage() {}
+
+func (x *GetSubscriptionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_subsmanager_v1_subscription_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSubscriptionResponse.ProtoReflect.Descriptor instead.
+func (*GetSubscriptionResponse) Descriptor() ([]byte, []int) {
+	return file_subsmanager_v1_subscription_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetSubscriptionResponse) GetSubscription() *Subscription {
+	if x != nil {
+		return x.Subscription
+	}
+	return nil
+}
+
+type ListSubscriptionsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Defaults to 50, at most 200.
+	PageSize int32 `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// The next_page_token of the previous page. It only works with the sort
+	// and order it was issued for.
+	PageToken  string           `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	Sort       SubscriptionSort `protobuf:"varint,3,opt,name=sort,proto3,enum=subsmanager.v1.SubscriptionSort" json:"sort,omitempty"`
+	Descending bool             `protobuf:"varint,4,opt,name=descending,proto3" json:"descending,omitempty"`
+	// Filters, ignored when unset.
+	UserId        string             `protobuf:"bytes,5,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ProductId     string             `protobuf:"bytes,6,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Status        SubscriptionStatus `protobuf:"varint,7,opt,name=status,proto3,enum=subsmanager.v1.SubscriptionStatus" json:"status,omitempty"`
+	Category      string             `protobuf:"bytes,8,opt,name=category,proto3" json:"category,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSubscriptionsRequest) Reset() {
+	*x = ListSubscriptionsRequest{}
+	mi := &file_subsmanager_v1_subscription_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSubscriptionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSubscriptionsRequest) ProtoMessage() {}
+
+func (x *ListSubscriptionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_subsmanager_v1_subscription_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSubscriptionsRequest.ProtoReflect.Descriptor instead.
+func (*ListSubscriptionsRequest) Descriptor() ([]byte, []int) {
+	return file_subsmanager_v1_subscription_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListSubscriptionsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListSubscriptionsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListSubscriptionsRequest) GetSort() SubscriptionSort {
+	if x != nil {
+		return x.Sort
+	}
+	return SubscriptionSort_SUBSCRIPTION_SORT_UNSPECIFIED
+}
+
+func (x *ListSubscriptionsRequest) GetDescending() bool {
+	if x != nil {
+		return x.Descending
+	}
+	return false
+}
+
+func (x *ListSubscriptionsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListSubscriptionsRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ListSubscriptionsRequest) GetStatus() SubscriptionStatus {
+	if x != nil {
+		return x.Status
+	}
+	return SubscriptionStatus_SUBSCRIPTION_STATUS_UNSPECIFIED
+}
+
+func (x *ListSubscriptionsRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+type ListSubscriptionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subscriptions []*Subscription        `protobuf:"bytes,1,rep,name=subscriptions,proto3" json:"subscriptions,omitempty"`
+	// Empty on the last page.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSubscriptionsResponse) Reset() {
+	*x = ListSubscriptionsResponse{}
+	mi := &file_subsmanager_v1_subscription_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSubscriptionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSubscriptionsResponse) ProtoMessage() {}
+
+func (x *ListSubscriptionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_subsmanager_v1_subscription_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSubscriptionsResponse.ProtoReflect.Descriptor instead.
+func (*ListSubscriptionsResponse) Descriptor() ([]byte, []int) {
+	return file_subsmanager_v1_subscription_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListSubscriptionsResponse) GetSubscriptions() []*Subscription {
+	if x != nil {
+		return x.Subscriptions
+	}
+	return nil
+}
+
+func (x *ListSubscriptionsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type CreateSubscriptionRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	UserId    string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ProductId string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	// Defaults to now.
+	StartDate *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	// Defaults to one billing period after the start date.
+	NextBilling *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=next_billing,json=nextBilling,proto3" json:"next_billing,omitempty"`
+	// Defaults to the product price.
+	PriceAtStart  *float64 `protobuf:"fixed64,5,opt,name=price_at_start,json=priceAtStart,proto3,oneof" json:"price_at_start,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSubscriptionRequest) Reset() {
+	*x = CreateSubscriptionRequest{}
+	mi := &file_subsmanager_v1_subscription_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSubscriptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSubscriptionRequest) ProtoMessage() {}
+
+func (x *CreateSubscriptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_subsmanager_v1_subscription_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSubscriptionRequest.ProtoReflect.Descriptor instead.
+func (*CreateSubscriptionRequest) Descriptor() ([]byte, []int) {
+	return file_subsmanager_v1_subscription_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CreateSubscriptionRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CreateSubscriptionRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *CreateSubscriptionRequest) GetStartDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartDate
+	}
+	return nil
+}
+
+func (x *CreateSubscriptionRequest) GetNextBilling() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NextBilling
+	}
+	return nil
+}
+
+func (x *CreateSubscriptionRequest) GetPriceAtStart() float64 {
+	if x != nil && x.PriceAtStart != nil {
+		return *x.PriceAtStart
+	}
+	return 0
+}
+
+type CreateSubscriptionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subscription  *Subscription          `protobuf:"bytes,1,opt,name=subscription,proto3" json:"subscription,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSubscriptionResponse) Reset() {
+	*x = CreateSubscriptionResponse{}
+	mi := &file_subsmanager_v1_subscription_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSubscriptionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSubscriptionResponse) ProtoMessage() {}
+
+func (x *CreateSubscriptionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_subsmanager_v1_subscription_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSubscriptionResponse.ProtoReflect.Descriptor instead.
+func (*CreateSubscriptionResponse) Descriptor() ([]byte, []int) {
+	return file_subsmanager_v1_subscription_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CreateSubscriptionResponse) GetSubscription() *Subscription {
+	if x != nil {
+		return x.Subscription
+	}
+	return nil
+}
+
+type CancelSubscriptionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelSubscriptionRequest) Reset() {
+	*x = CancelSubscriptionRequest{}
+	mi := &file_subsmanager_v1_subscription_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelSubscriptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelSubscriptionRequest) ProtoMessage() {}
+
+func (x *CancelSubscriptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_subsmanager_v1_subscription_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelSubscriptionRequest.ProtoReflect.Descriptor instead.
+func (*CancelSubscriptionRequest) Descriptor() ([]byte, []int) {
+	return file_subsmanager_v1_subscription_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CancelSubscriptionRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type CancelSubscriptionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subscription  *Subscription          `protobuf:"bytes,1,opt,name=subscription,proto3" json:"subscription,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelSubscriptionResponse) Reset() {
+	*x = CancelSubscriptionResponse{}
+	mi := &file_subsmanager_v1_subscription_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelSubscriptionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelSubscriptionResponse) ProtoMessage() {}
+
+func (x *CancelSubscriptionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_subsmanager_v1_subscription_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelSubscriptionResponse.ProtoReflect.Descriptor instead.
+func (*CancelSubscriptionResponse) Descriptor() ([]byte, []int) {
+	return file_subsmanager_v1_subscription_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CancelSubscriptionResponse) GetSubscription() *Subscription {
+	if x != nil {
+		return x.Subscription
+	}
+	return nil
+}
+
+type PauseSubscriptionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PauseSubscriptionRequest) Reset() {
+	*x = PauseSubscriptionRequest{}
+	mi := &file_subsmanager_v1_subscription_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PauseSubscriptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PauseSubscriptionRequest) ProtoMessage() {}
+
+func (x *PauseSubscriptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_subsmanager_v1_subscription_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PauseSubscriptionRequest.ProtoReflect.Descriptor instead.
+func (*PauseSubscriptionRequest) Descriptor() ([]byte, []int) {
+	return file_subsmanager_v1_subscription_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *PauseSubscriptionRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type PauseSubscriptionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subscription  *Subscription          `protobuf:"bytes,1,opt,name=subscription,proto3" json:"subscription,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PauseSubscriptionResponse) Reset() {
+	*x = PauseSubscriptionResponse{}
+	mi := &file_subsmanager_v1_subscription_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PauseSubscriptionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PauseSubscriptionResponse) ProtoMessage() {}
+
+func (x *PauseSubscriptionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_subsmanager_v1_subscription_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PauseSubscriptionResponse.ProtoReflect.Descriptor instead.
+func (*PauseSubscriptionResponse) Descriptor() ([]byte, []int) {
+	return file_subsmanager_v1_subscription_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *PauseSubscriptionResponse) GetSubscription() *Subscription {
+	if x != nil {
+		return x.Subscription
+	}
+	return nil
+}
+
+type WatchSubscriptionsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only the subscriptions of this user when set.
+	UserId        string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchSubscriptionsRequest) Reset() {
+	*x = WatchSubscriptionsRequest{}
+	mi := &file_subsmanager_v1_subscription_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchSubscriptionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchSubscriptionsRequest) ProtoMessage() {}
+
+func (x *WatchSubscriptionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_subsmanager_v1_subscription_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchSubscriptionsRequest.ProtoReflect.Descriptor instead.
+func (*WatchSubscriptionsRequest) Descriptor() ([]byte, []int) {
+	return file_subsmanager_v1_subscription_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *WatchSubscriptionsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type WatchSubscriptionsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Type  SubscriptionEventType  `protobuf:"varint,1,opt,name=type,proto3,enum=subsmanager.v1.SubscriptionEventType" json:"type,omitempty"`
+	// The subscription after the write, or before it for DELETED.
+	Subscription  *Subscription          `protobuf:"bytes,2,opt,name=subscription,proto3" json:"subscription,omitempty"`
+	EventTime     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=event_time,json=eventTime,proto3" json:"event_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchSubscriptionsResponse) Reset() {
+	*x = WatchSubscriptionsResponse{}
+	mi := &file_subsmanager_v1_subscription_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchSubscriptionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchSubscriptionsResponse) ProtoMessage() {}
+
+func (x *WatchSubscriptionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_subsmanager_v1_subscription_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchSubscriptionsResponse.ProtoReflect.Descriptor instead.
+func (*WatchSubscriptionsResponse) Descriptor() ([]byte, []int) {
+	return file_subsmanager_v1_subscription_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *WatchSubscriptionsResponse) GetType() SubscriptionEventType {
+	if x != nil {
+		return x.Type
+	}
+	return SubscriptionEventType_SUBSCRIPTION_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *WatchSubscriptionsResponse) GetSubscription() *Subscription {
+	if x != nil {
+		return x.Subscription
+	}
+	return nil
+}
+
+func (x *WatchSubscriptionsResponse) GetEventTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EventTime
+	}
+	return nil
+}
+
+var File_subsmanager_v1_subscription_proto protoreflect.FileDescriptor
+
+const file_subsmanager_v1_subscription_proto_rawDesc = "" +
+	"\n" +
+	"!subsmanager/v1/subscription.proto\x12\x0esubsmanager.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xa6\x03\n" +
+	"\fSubscription\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x03 \x01(\tR\tproductId\x12:\n" +
+	"\x06status\x18\x04 \x01(\x0e2\".subsmanager.v1.SubscriptionStatusR\x06status\x129\n" +
+	"\n" +
+	"start_date\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tstartDate\x125\n" +
+	"\bend_date\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\aendDate\x12=\n" +
+	"\fnext_billing\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\vnextBilling\x12$\n" +
+	"\x0eprice_at_start\x18\b \x01(\x01R\fpriceAtStart\x12;\n" +
+	"\vcreate_time\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"createTime\"(\n" +
+	"\x16GetSubscriptionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"[\n" +
+	"\x17GetSubscriptionResponse\x12@\n" +
+	"\fsubscription\x18\x01 \x01(\v2\x1c.subsmanager.v1.SubscriptionR\fsubscription\"\xbc\x02\n" +
+	"\x18ListSubscriptionsRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\x124\n" +
+	"\x04sort\x18\x03 \x01(\x0e2 .subsmanager.v1.SubscriptionSortR\x04sort\x12\x1e\n" +
+	"\n" +
+	"descending\x18\x04 \x01(\bR\n" +
+	"descending\x12\x17\n" +
+	"\auser_id\x18\x05 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x06 \x01(\tR\tproductId\x12:\n" +
+	"\x06status\x18\a \x01(\x0e2\".subsmanager.v1.SubscriptionStatusR\x06status\x12\x1a\n" +
+	"\bcategory\x18\b \x01(\tR\bcategory\"\x87\x01\n" +
+	"\x19ListSubscriptionsResponse\x12B\n" +
+	"\rsubscriptions\x18\x01 \x03(\v2\x1c.subsmanager.v1.SubscriptionR\rsubscriptions\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\x8b\x02\n" +
+	"\x19CreateSubscriptionRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x129\n" +
+	"\n" +
+	"start_date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tstartDate\x12=\n" +
+	"\fnext_billing\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\vnextBilling\x12)\n" +
+	"\x0eprice_at_start\x18\x05 \x01(\x01H\x00R\fpriceAtStart\x88\x01\x01B\x11\n" +
+	"\x0f_price_at_start\"^\n" +
+	"\x1aCreateSubscriptionResponse\x12@\n" +
+	"\fsubscription\x18\x01 \x01(\v2\x1c.subsmanager.v1.SubscriptionR\fsubscription\"+\n" +
+	"\x19CancelSubscriptionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"^\n" +
+	"\x1aCancelSubscriptionResponse\x12@\n" +
+	"\fsubscription\x18\x01 \x01(\v2\x1c.subsmanager.v1.SubscriptionR\fsubscription\"*\n" +
+	"\x18PauseSubscriptionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"]\n" +
+	"\x19PauseSubscriptionResponse\x12@\n" +
+	"\fsubscription\x18\x01 \x01(\v2\x1c.subsmanager.v1.SubscriptionR\fsubscription\"4\n" +
+	"\x19WatchSubscriptionsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"\xd4\x01\n" +
+	"\x1aWatchSubscriptionsResponse\x129\n" +
+	"\x04type\x18\x01 \x01(\x0e2%.subsmanager.v1.SubscriptionEventTypeR\x04type\x12@\n" +
+	"\fsubscription\x18\x02 \x01(\v2\x1c.subsmanager.v1.SubscriptionR\fsubscription\x129\n" +
+	"\n" +
+	"event_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\teventTime*\xbd\x01\n" +
+	"\x12SubscriptionStatus\x12#\n" +
+	"\x1fSUBSCRIPTION_STATUS_UNSPECIFIED\x10\x00\x12\x1e\n" +
+	"\x1aSUBSCRIPTION_STATUS_ACTIVE\x10\x01\x12\x1e\n" +
+	"\x1aSUBSCRIPTION_STATUS_PAUSED\x10\x02\x12!\n" +
+	"\x1dSUBSCRIPTION_STATUS_CANCELLED\x10\x03\x12\x1f\n" +
+	"\x1bSUBSCRIPTION_STATUS_EXPIRED\x10\x04*\x99\x01\n" +
+	"\x10SubscriptionSort\x12!\n" +
+	"\x1dSUBSCRIPTION_SORT_UNSPECIFIED\x10\x00\x12\"\n" +
+	"\x1eSUBSCRIPTION_SORT_NEXT_BILLING\x10\x01\x12\x1b\n" +
+	"\x17SUBSCRIPTION_SORT_PRICE\x10\x02\x12!\n" +
+	"\x1dSUBSCRIPTION_SORT_CREATE_TIME\x10\x03*\xaf\x01\n" +
+	"\x15SubscriptionEventType\x12'\n" +
+	"#SUBSCRIPTION_EVENT_TYPE_UNSPECIFIED\x10\x00\x12#\n" +
+	"\x1fSUBSCRIPTION_EVENT_TYPE_CREATED\x10\x01\x12#\n" +
+	"\x1fSUBSCRIPTION_EVENT_TYPE_UPDATED\x10\x02\x12#\n" +
+	"\x1fSUBSCRIPTION_EVENT_TYPE_DELETED\x10\x032\x96\x05\n" +
+	"\x13SubscriptionService\x12b\n" +
+	"\x0fGetSubscription\x12&.subsmanager.v1.GetSubscriptionRequest\x1a'.subsmanager.v1.GetSubscriptionResponse\x12h\n" +
+	"\x11ListSubscriptions\x12(.subsmanager.v1.ListSubscriptionsRequest\x1a).subsmanager.v1.ListSubscriptionsResponse\x12k\n" +
+	"\x12CreateSubscription\x12).subsmanager.v1.CreateSubscriptionRequest\x1a*.subsmanager.v1.CreateSubscriptionResponse\x12k\n" +
+	"\x12CancelSubscription\x12).subsmanager.v1.CancelSubscriptionRequest\x1a*.subsmanager.v1.CancelSubscriptionResponse\x12h\n" +
+	"\x11PauseSubscription\x12(.subsmanager.v1.PauseSubscriptionRequest\x1a).subsmanager.v1.PauseSubscriptionResponse\x12m\n" +
+	"\x12WatchSubscriptions\x12).subsmanager.v1.WatchSubscriptionsRequest\x1a*.subsmanager.v1.WatchSubscriptionsResponse0\x01BHZFgithub.com/frtasoniero/subsmanager/pkg/pb/subsmanager/v1;subsmanagerv1b\x06proto3"
+
+var (
+	file_subsmanager_v1_subscription_proto_rawDescOnce sync.Once
+	file_subsmanager_v1_subscription_proto_rawDescData []byte
+)
+
+func file_subsmanager_v1_subscription_proto_rawDescGZIP() []byte {
+	file_subsmanager_v1_subscription_proto_rawDescOnce.Do(func() {
+		file_subsmanager_v1_subscription_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_subsmanager_v1_subscription_proto_rawDesc), len(file_subsmanager_v1_subscription_proto_rawDesc)))
+	})
+	return file_subsmanager_v1_subscription_proto_rawDescData
+}
+
+var file_subsmanager_v1_subscription_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_subsmanager_v1_subscription_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_subsmanager_v1_subscription_proto_goTypes = []any{
+	(SubscriptionStatus)(0),            // 0: subsmanager.v1.SubscriptionStatus
+	(SubscriptionSort)(0),              // 1: subsmanager.v1.SubscriptionSort
+	(SubscriptionEventType)(0),         // 2: subsmanager.v1.SubscriptionEventType
+	(*Subscription)(nil),               // 3: subsmanager.v1.Subscription
+	(*GetSubscriptionRequest)(nil),     // 4: subsmanager.v1.GetSubscriptionRequest
+	(*GetSubscriptionResponse)(nil),    // 5: subsmanager.v1.GetSubscriptionResponse
+	(*ListSubscriptionsRequest)(nil),   // 6: subsmanager.v1.ListSubscriptionsRequest
+	(*ListSubscriptionsResponse)(nil),  // 7: subsmanager.v1.ListSubscriptionsResponse
+	(*CreateSubscriptionRequest)(nil),  // 8: subsmanager.v1.CreateSubscriptionRequest
+	(*CreateSubscriptionResponse)(nil), // 9: subsmanager.v1.CreateSubscriptionResponse
+	(*CancelSubscriptionRequest)(nil),  // 10: subsmanager.v1.CancelSubscriptionRequest
+	(*CancelSubscriptionResponse)(nil), // 11: subsmanager.v1.CancelSubscriptionResponse
+	(*PauseSubscriptionRequest)(nil),   // 12: subsmanager.v1.PauseSubscriptionRequest
+	(*PauseSubscriptionResponse)(nil),  // 13: subsmanager.v1.PauseSubscriptionResponse
+	(*WatchSubscriptionsRequest)(nil),  // 14: subsmanager.v1.WatchSubscriptionsRequest
+	(*WatchSubscriptionsResponse)(nil), // 15: subsmanager.v1.WatchSubscriptionsResponse
+	(*timestamppb.Timestamp)(nil),      // 16: google.protobuf.Timestamp
+}
+var file_subsmanager_v1_subscription_proto_depIdxs = []int32{
+	0,  // 0: subsmanager.v1.Subscription.status:type_name -> subsmanager.v1.SubscriptionStatus
+	16, // 1: subsmanager.v1.Subscription.start_date:type_name -> google.protobuf.Timestamp
+	16, // 2: subsmanager.v1.Subscription.end_date:type_name -> google.protobuf.Timestamp
+	16, // 3: subsmanager.v1.Subscription.next_billing:type_name -> google.protobuf.Timestamp
+	16, // 4: subsmanager.v1.Subscription.create_time:type_name -> google.protobuf.Timestamp
+	3,  // 5: subsmanager.v1.GetSubscriptionResponse.subscription:type_name -> subsmanager.v1.Subscription
+	1,  // 6: subsmanager.v1.ListSubscriptionsRequest.sort:type_name -> subsmanager.v1.SubscriptionSort
+	0,  // 7: subsmanager.v1.ListSubscriptionsRequest.status:type_name -> subsmanager.v1.SubscriptionStatus
+	3,  // 8: subsmanager.v1.ListSubscriptionsResponse.subscriptions:type_name -> subsmanager.v1.Subscription
+	16, // 9: subsmanager.v1.CreateSubscriptionRequest.start_date:type_name -> google.protobuf.Timestamp
+	16, // 10: subsmanager.v1.CreateSubscriptionRequest.next_billing:type_name -> google.protobuf.Timestamp
+	3,  // 11: subsmanager.v1.CreateSubscriptionResponse.subscription:type_name -> subsmanager.v1.Subscription
+	3,  // 12: subsmanager.v1.CancelSubscriptionResponse.subscription:type_name -> subsmanager.v1.Subscription
+	3,  // 13: subsmanager.v1.PauseSubscriptionResponse.subscription:type_name -> subsmanager.v1.Subscription
+	2,  // 14: subsmanager.v1.WatchSubscriptionsResponse.type:type_name -> subsmanager.v1.SubscriptionEventType
+	3,  // 15: subsmanager.v1.WatchSubscriptionsResponse.subscription:type_name -> subsmanager.v1.Subscription
+	16, // 16: subsmanager.v1.WatchSubscriptionsResponse.event_time:type_name -> google.protobuf.Timestamp
+	4,  // 17: subsmanager.v1.SubscriptionService.GetSubscription:input_type -> subsmanager.v1.GetSubscriptionRequest
+	6,  // 18: subsmanager.v1.SubscriptionService.ListSubscriptions:input_type -> subsmanager.v1.ListSubscriptionsRequest
+	8,  // 19: subsmanager.v1.SubscriptionService.CreateSubscription:input_type -> subsmanager.v1.CreateSubscriptionRequest
+	10, // 20: subsmanager.v1.SubscriptionService.CancelSubscription:input_type -> subsmanager.v1.CancelSubscriptionRequest
+	12, // 21: subsmanager.v1.SubscriptionService.PauseSubscription:input_type -> subsmanager.v1.PauseSubscriptionRequest
+	14, // 22: subsmanager.v1.SubscriptionService.WatchSubscriptions:input_type -> subsmanager.v1.WatchSubscriptionsRequest
+	5,  // 23: subsmanager.v1.SubscriptionService.GetSubscription:output_type -> subsmanager.v1.GetSubscriptionResponse
+	7,  // 24: subsmanager.v1.SubscriptionService.ListSubscriptions:output_type -> subsmanager.v1.ListSubscriptionsResponse
+	9,  // 25: subsmanager.v1.SubscriptionService.CreateSubscription:output_type -> subsmanager.v1.CreateSubscriptionResponse
+	11, // 26: subsmanager.v1.SubscriptionService.CancelSubscription:output_type -> subsmanager.v1.CancelSubscriptionResponse
+	13, // 27: subsmanager.v1.SubscriptionService.PauseSubscription:output_type -> subsmanager.v1.PauseSubscriptionResponse
+	15, // 28: subsmanager.v1.SubscriptionService.WatchSubscriptions:output_type -> subsmanager.v1.WatchSubscriptionsResponse
+	23, // [23:29] is the sub-list for method output_type
+	17, // [17:23] is the sub-list for method input_type
+	17, // [17:17] is the sub-list for extension type_name
+	17, // [17:17] is the sub-list for extension extendee
+	0,  // [0:17] is the sub-list for field type_name
+}
+
+func init() { file_subsmanager_v1_subscription_proto_init() }
+func file_subsmanager_v1_subscription_proto_init() {
+	if File_subsmanager_v1_subscription_proto != nil {
+		return
+	}
+	file_subsmanager_v1_subscription_proto_msgTypes[5].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_subsmanager_v1_subscription_proto_rawDesc), len(file_subsmanager_v1_subscription_proto_rawDesc)),
+			NumEnums:      3,
+			NumMessages:   13,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_subsmanager_v1_subscription_proto_goTypes,
+		DependencyIndexes: file_subsmanager_v1_subscription_proto_depIdxs,
+		EnumInfos:         file_subsmanager_v1_subscription_proto_enumTypes,
+		MessageInfos:      file_subsmanager_v1_subscription_proto_msgTypes,
+	}.Build()
+	File_subsmanager_v1_subscription_proto = out.File
+	file_subsmanager_v1_subscription_proto_goTypes = nil
+	file_subsmanager_v1_subscription_proto_depIdxs = nil
+}
@@ -0,0 +1,133 @@
+// Package textsearch splits free-text queries into terms, scores documents
+// against them and highlights the matching words.
+//
+// Matching is case-insensitive and approximates the stemming of a text index:
+// a word matches a term when the stem of the word starts with the stem of the
+// term, so "stream" matches "Streaming" and "streams" matches "stream".
+package textsearch
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+// MarkOpen and MarkClose surround the matching words of a highlight
+const (
+	MarkOpen  = "<mark>"
+	MarkClose = "</mark>"
+)
+
+// stopWords are left out of queries, like the English text indexes of MongoDB do
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true, "be": true, "by": true,
+	"for": true, "from": true, "in": true, "is": true, "it": true, "of": true, "on": true, "or": true,
+	"the": true, "to": true, "with": true,
+}
+
+// suffixes are stripped by Stem, longest first
+var suffixes = []string{"ing", "ed", "es", "s"}
+
+// Field is a searchable text of a document with the weight of its matches
+type Field struct {
+	Name   string
+	Text   string
+	Weight float64
+}
+
+// Terms splits a query into distinct lowercase terms without stop words
+func Terms(query string) []string {
+	var terms []string
+	seen := make(map[string]bool)
+	for _, word := range words(query) {
+		term := strings.ToLower(word.text)
+		if stopWords[term] || seen[term] {
+			continue
+		}
+		seen[term] = true
+		terms = append(terms, term)
+	}
+	return terms
+}
+
+// Stem strips a common English suffix from a lowercase word, keeping at least
+// three letters
+func Stem(word string) string {
+	for _, suffix := range suffixes {
+		if stem, ok := strings.CutSuffix(word, suffix); ok && len([]rune(stem)) >= 3 {
+			return stem
+		}
+	}
+	return word
+}
+
+// Matches reports whether a word of a document matches a query term
+func Matches(word, term string) bool {
+	return strings.HasPrefix(Stem(strings.ToLower(word)), Stem(term))
+}
+
+// Score returns the relevance of a document for terms: the weight of a field
+// counts once per matching word. It is zero when no term matches.
+func Score(terms []string, fields ...Field) float64 {
+	var score float64
+	for _, field := range fields {
+		for _, word := range words(field.Text) {
+			for _, term := range terms {
+				if Matches(word.text, term) {
+					score += field.Weight
+					break
+				}
+			}
+		}
+	}
+	return score
+}
+
+// Highlight HTML-escapes text and wraps its words matching terms in MarkOpen
+// and MarkClose. It returns false when no word matches.
+func Highlight(text string, terms []string) (string, bool) {
+	var highlighted strings.Builder
+	matched := false
+	last := 0
+	for _, word := range words(text) {
+		for _, term := range terms {
+			if !Matches(word.text, term) {
+				continue
+			}
+			highlighted.WriteString(html.EscapeString(text[last:word.start]))
+			highlighted.WriteString(MarkOpen)
+			highlighted.WriteString(html.EscapeString(word.text))
+			highlighted.WriteString(MarkClose)
+			last = word.start + len(word.text)
+			matched = true
+			break
+		}
+	}
+	highlighted.WriteString(html.EscapeString(text[last:]))
+	return highlighted.String(), matched
+}
+
+// word is a run of letters and digits of a text, starting at byte offset start
+type word struct {
+	text  string
+	start int
+}
+
+func words(text string) []word {
+	var found []word
+	start := -1
+	for i, r := range text {
+		isWordRune := unicode.IsLetter(r) || unicode.IsDigit(r)
+		switch {
+		case isWordRune && start < 0:
+			start = i
+		case !isWordRune && start >= 0:
+			found = append(found, word{text: text[start:i], start: start})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		found = append(found, word{text: text[start:], start: start})
+	}
+	return found
+}
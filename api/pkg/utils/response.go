@@ -5,10 +5,11 @@ import (
 )
 
 type APIResponse struct {
-	Success bool        `json:"success"`
-	Message string      `json:"message"`
-	Data    interface{} `json:"data,omitempty"`
-	Error   string      `json:"error,omitempty"`
+	Success    bool        `json:"success"`
+	Message    string      `json:"message"`
+	Data       interface{} `json:"data,omitempty"`
+	Pagination interface{} `json:"pagination,omitempty"`
+	Error      string      `json:"error,omitempty"`
 }
 
 func SuccessResponse(c *gin.Context, statusCode int, message string, data interface{}) {
@@ -19,6 +20,17 @@ func SuccessResponse(c *gin.Context, statusCode int, message string, data interf
 	})
 }
 
+// PaginatedResponse is a SuccessResponse for one page of a list, with the
+// metadata needed to request the next page
+func PaginatedResponse(c *gin.Context, statusCode int, message string, data interface{}, pagination interface{}) {
+	c.JSON(statusCode, APIResponse{
+		Success:    true,
+		Message:    message,
+		Data:       data,
+		Pagination: pagination,
+	})
+}
+
 func ErrorResponse(c *gin.Context, statusCode int, message string, err error) {
 	response := APIResponse{
 		Success: false,
@@ -31,3 +43,17 @@ func ErrorResponse(c *gin.Context, statusCode int, message string, err error) {
 
 	c.JSON(statusCode, response)
 }
+
+func ErrorResponseWithData(c *gin.Context, statusCode int, message string, data interface{}, err error) {
+	response := APIResponse{
+		Success: false,
+		Message: message,
+		Data:    data,
+	}
+
+	if err != nil {
+		response.Error = err.Error()
+	}
+
+	c.JSON(statusCode, response)
+}